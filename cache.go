@@ -0,0 +1,197 @@
+// Copyright 2020 - 2021 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// Fetcher fetches the content of remote schema documents by URL.
+type Fetcher interface {
+	Fetch(url string) ([]byte, error)
+}
+
+// FetcherFunc is an adapter to allow the use of ordinary functions as
+// fetchers.
+type FetcherFunc func(url string) ([]byte, error)
+
+// Fetch calls f(url).
+func (f FetcherFunc) Fetch(url string) ([]byte, error) {
+	return f(url)
+}
+
+// HTTPFetcher fetches remote schema documents by HTTP GET requests with the
+// Client, or a client with the DefaultFetchTimeout if the Client is nil.
+type HTTPFetcher struct {
+	Client *http.Client
+}
+
+// DefaultFetchTimeout is the time limit of the requests of an HTTPFetcher
+// without a client.
+const DefaultFetchTimeout = 30 * time.Second
+
+var defaultFetchClient = &http.Client{Timeout: DefaultFetchTimeout}
+
+// Fetch fetches the schema document at the URL, responses with a status
+// other than 200 OK are reported as errors.
+func (f *HTTPFetcher) Fetch(url string) ([]byte, error) {
+	client := f.Client
+	if client == nil {
+		client = defaultFetchClient
+	}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch %s: %s", url, resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// SchemaCache stores the remote schema documents in the Dir, so that they
+// can be parsed as local files. Documents are fetched by the Fetcher, or by
+// an HTTPFetcher if the Fetcher is nil, and stored by the SHA-256 digest of
+// their URL, so that the documents with the same content fetched from
+// different URLs are kept apart. In Offline mode documents are only read
+// from the cache.
+type SchemaCache struct {
+	Dir     string
+	Fetcher Fetcher
+	Offline bool
+
+	mu   sync.Mutex
+	urls map[string]string
+}
+
+// NewSchemaCache creates a schema cache in given directory with the default
+// HTTP fetcher.
+func NewSchemaCache(dir string) *SchemaCache {
+	return &SchemaCache{Dir: dir, Fetcher: &HTTPFetcher{}}
+}
+
+// Resolve returns the path of the cached copy of the schema document at the
+// URL, the document is fetched unless it's cached already.
+func (c *SchemaCache) Resolve(location string) (string, error) {
+	path := filepath.Join(c.Dir, "schemas", digest([]byte(location))+".xsd")
+	if _, err := os.Stat(path); err == nil {
+		c.record(path, location)
+		return path, nil
+	}
+	if c.Offline {
+		return "", fmt.Errorf("schema %s is not cached in offline mode", location)
+	}
+	fetcher := c.Fetcher
+	if fetcher == nil {
+		fetcher = &HTTPFetcher{}
+	}
+	content, err := fetcher.Fetch(location)
+	if err != nil {
+		return "", err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+	if err = writeFileAtomic(path, content); err != nil {
+		return "", err
+	}
+	c.record(path, location)
+	return path, nil
+}
+
+// writeFileAtomic writes the content to a temporary file in the directory of
+// the path and renames it to the path, so that an interrupted write or a
+// concurrent reader never sees a partial document.
+func writeFileAtomic(path string, content []byte) (err error) {
+	file, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(file.Name())
+		}
+	}()
+	if _, err = file.Write(content); err != nil {
+		file.Close()
+		return
+	}
+	if err = file.Close(); err != nil {
+		return
+	}
+	if err = os.Chmod(file.Name(), 0644); err != nil {
+		return
+	}
+	return os.Rename(file.Name(), path)
+}
+
+// URL returns the URL of the cached schema document by given path, or an
+// empty string if the path isn't a cached document resolved by the cache.
+func (c *SchemaCache) URL(path string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.urls[path]
+}
+
+// record indexes the URL of the cached schema document by the path.
+func (c *SchemaCache) record(path, location string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.urls == nil {
+		c.urls = make(map[string]string)
+	}
+	c.urls[path] = location
+}
+
+// digest returns the hex encoded SHA-256 digest of the content.
+func digest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// schemaPath returns the local path of the schema document at the schema
+// location referenced by the document at the base path. Locations relative
+// to a remote document are resolved against its URL, absolute locations are
+// mapped by the catalog, and remote documents are fetched into the schema
+// cache.
+func (opt *Options) schemaPath(base, location string) (string, error) {
+	if opt.Cache != nil {
+		if baseURL, err := url.Parse(opt.Cache.URL(base)); err == nil && baseURL.IsAbs() {
+			if ref, err := url.Parse(location); err == nil {
+				location = baseURL.ResolveReference(ref).String()
+			}
+		}
+	}
+	if u, err := url.Parse(location); err == nil && u.IsAbs() {
+		if resolved, ok := opt.resolveCatalog(location); ok {
+			location = resolved
+		}
+		location = fileURLPath(location)
+	}
+	if filepath.IsAbs(location) {
+		return location, nil
+	}
+	if !isValidURL(location) {
+		return filepath.Join(filepath.Dir(base), location), nil
+	}
+	if opt.Cache == nil {
+		return "", fmt.Errorf("remote schema %s is not fetched without a schema cache", location)
+	}
+	return opt.Cache.Resolve(location)
+}
@@ -0,0 +1,249 @@
+// Copyright 2020 - 2021 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const catalogNamespace = "urn:oasis:names:tc:entity:xmlns:xml:catalog"
+
+// Catalog maps namespace URIs, schema locations and system identifiers to
+// alternative locations, such as vendored local copies of remote schema
+// documents, by the entries of OASIS XML Catalog files. The uri, rewriteURI,
+// uriSuffix and delegateURI entries are used to resolve URIs, the system,
+// rewriteSystem, systemSuffix and delegateSystem entries are used to resolve
+// system identifiers, and the catalogs referenced by the nextCatalog entries
+// are consulted when no entry of the catalog matches.
+// https://www.oasis-open.org/committees/download.php/14809/xml-catalogs.html
+type Catalog struct {
+	entries []catalogEntry
+	next    []*Catalog
+}
+
+// catalogEntry is an entry of a catalog file. The match is the URI, system
+// identifier, start string or suffix matched by the entry, and the value is
+// the URI or rewrite prefix of the entry with the base URI of the entry
+// applied. The catalog of a delegate entry is the delegated catalog.
+type catalogEntry struct {
+	kind    string
+	match   string
+	value   string
+	catalog *Catalog
+}
+
+// LoadCatalog loads the OASIS XML Catalog files by given paths, the catalogs
+// are consulted in the order of the paths.
+func LoadCatalog(paths ...string) (*Catalog, error) {
+	catalog, loaded := &Catalog{}, make(map[string]*Catalog)
+	for _, path := range paths {
+		next, err := loadCatalogFile(path, loaded)
+		if err != nil {
+			return nil, err
+		}
+		catalog.next = append(catalog.next, next)
+	}
+	return catalog, nil
+}
+
+// loadCatalogFile loads the catalog file and the catalog files referenced by
+// it, each file is loaded only once.
+func loadCatalogFile(path string, loaded map[string]*Catalog) (*Catalog, error) {
+	if catalog, ok := loaded[path]; ok {
+		return catalog, nil
+	}
+	catalog := &Catalog{}
+	loaded[path] = catalog
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	bases := []string{path}
+	decoder := xml.NewDecoder(f)
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			base, attrs := bases[len(bases)-1], map[string]string{}
+			for _, attr := range element.Attr {
+				if attr.Name.Space == xmlNamespace && attr.Name.Local == "base" {
+					base = resolveCatalogReference(base, attr.Value)
+					continue
+				}
+				attrs[attr.Name.Local] = attr.Value
+			}
+			bases = append(bases, base)
+			if element.Name.Space != catalogNamespace {
+				continue
+			}
+			entry := catalogEntry{kind: element.Name.Local}
+			switch element.Name.Local {
+			case "uri":
+				entry.match, entry.value = attrs["name"], resolveCatalogReference(base, attrs["uri"])
+			case "system":
+				entry.match, entry.value = attrs["systemId"], resolveCatalogReference(base, attrs["uri"])
+			case "rewriteURI":
+				entry.match, entry.value = attrs["uriStartString"], resolveCatalogReference(base, attrs["rewritePrefix"])
+			case "rewriteSystem":
+				entry.match, entry.value = attrs["systemIdStartString"], resolveCatalogReference(base, attrs["rewritePrefix"])
+			case "uriSuffix":
+				entry.match, entry.value = attrs["uriSuffix"], resolveCatalogReference(base, attrs["uri"])
+			case "systemSuffix":
+				entry.match, entry.value = attrs["systemIdSuffix"], resolveCatalogReference(base, attrs["uri"])
+			case "delegateURI", "delegateSystem", "nextCatalog":
+				entry.match = attrs["uriStartString"] + attrs["systemIdStartString"]
+				if entry.catalog, err = loadCatalogFile(resolveCatalogReference(base, attrs["catalog"]), loaded); err != nil {
+					return nil, err
+				}
+				if element.Name.Local == "nextCatalog" {
+					catalog.next = append(catalog.next, entry.catalog)
+					continue
+				}
+			default:
+				continue
+			}
+			catalog.entries = append(catalog.entries, entry)
+		case xml.EndElement:
+			bases = bases[:len(bases)-1]
+		}
+	}
+	return catalog, nil
+}
+
+// resolveCatalogReference resolves the reference in a catalog file against
+// the base URI, which is either a file path or an absolute URL.
+func resolveCatalogReference(base, ref string) string {
+	if u, err := url.Parse(ref); err == nil && u.IsAbs() {
+		return ref
+	}
+	if u, err := url.Parse(base); err == nil && u.IsAbs() && u.Scheme != "file" {
+		if r, err := url.Parse(ref); err == nil {
+			return u.ResolveReference(r).String()
+		}
+	}
+	base, path := fileURLPath(base), filepath.FromSlash(ref)
+	if !filepath.IsAbs(path) {
+		if !strings.HasSuffix(base, "/") && !strings.HasSuffix(base, string(filepath.Separator)) {
+			base = filepath.Dir(base)
+		}
+		path = filepath.Join(base, path)
+	}
+	// keep the trailing slash of rewrite prefixes and base directories.
+	if strings.HasSuffix(ref, "/") && !strings.HasSuffix(path, string(filepath.Separator)) {
+		path += string(filepath.Separator)
+	}
+	return path
+}
+
+// fileURLPath returns the file path of the file URL, other values are
+// returned as is.
+func fileURLPath(location string) string {
+	if u, err := url.Parse(location); err == nil && u.Scheme == "file" {
+		return filepath.FromSlash(u.Path)
+	}
+	return location
+}
+
+// ResolveURI returns the alternative location of the URI by the uri,
+// rewriteURI, uriSuffix and delegateURI entries of the catalog.
+func (c *Catalog) ResolveURI(uri string) (string, bool) {
+	return c.resolve(uri, "uri", "rewriteURI", "uriSuffix", "delegateURI", map[*Catalog]bool{})
+}
+
+// ResolveSystem returns the alternative location of the system identifier by
+// the system, rewriteSystem, systemSuffix and delegateSystem entries of the
+// catalog.
+func (c *Catalog) ResolveSystem(systemID string) (string, bool) {
+	return c.resolve(systemID, "system", "rewriteSystem", "systemSuffix", "delegateSystem", map[*Catalog]bool{})
+}
+
+// resolve resolves the identifier by the entries of given kinds in the order
+// defined by the OASIS XML Catalogs specification: a matching exact entry,
+// the rewrite entry with the longest matching start string, the suffix entry
+// with the longest matching suffix, then the catalogs of the delegate entries
+// matching the identifier ordered by the length of their start strings, and
+// finally the next catalogs.
+func (c *Catalog) resolve(id, exact, rewrite, suffix, delegate string, visited map[*Catalog]bool) (string, bool) {
+	if c == nil || visited[c] {
+		return "", false
+	}
+	visited[c] = true
+	var rewriteEntry, suffixEntry *catalogEntry
+	var delegates []catalogEntry
+	for i, entry := range c.entries {
+		switch entry.kind {
+		case exact:
+			if entry.match == id {
+				return entry.value, true
+			}
+		case rewrite:
+			if strings.HasPrefix(id, entry.match) && (rewriteEntry == nil || len(entry.match) > len(rewriteEntry.match)) {
+				rewriteEntry = &c.entries[i]
+			}
+		case suffix:
+			if strings.HasSuffix(id, entry.match) && (suffixEntry == nil || len(entry.match) > len(suffixEntry.match)) {
+				suffixEntry = &c.entries[i]
+			}
+		case delegate:
+			if strings.HasPrefix(id, entry.match) {
+				delegates = append(delegates, entry)
+			}
+		}
+	}
+	if rewriteEntry != nil {
+		return rewriteEntry.value + strings.TrimPrefix(id, rewriteEntry.match), true
+	}
+	if suffixEntry != nil {
+		return suffixEntry.value, true
+	}
+	if len(delegates) > 0 {
+		sort.SliceStable(delegates, func(i, j int) bool {
+			return len(delegates[i].match) > len(delegates[j].match)
+		})
+		for _, entry := range delegates {
+			if location, ok := entry.catalog.resolve(id, exact, rewrite, suffix, delegate, map[*Catalog]bool{}); ok {
+				return location, true
+			}
+		}
+		return "", false
+	}
+	for _, next := range c.next {
+		if location, ok := next.resolve(id, exact, rewrite, suffix, delegate, visited); ok {
+			return location, true
+		}
+	}
+	return "", false
+}
+
+// resolveCatalog returns the alternative location of the schema location by
+// the catalog of the parser, the schema location is resolved as a system
+// identifier and then as a URI.
+func (opt *Options) resolveCatalog(location string) (string, bool) {
+	if opt.Catalog == nil {
+		return "", false
+	}
+	if resolved, ok := opt.Catalog.ResolveSystem(location); ok {
+		return resolved, true
+	}
+	return opt.Catalog.ResolveURI(location)
+}
@@ -0,0 +1,208 @@
+// Copyright 2020 - 2021 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+// chameleon copies the components of a schema document without target
+// namespace for the namespace of a document including it. The components
+// and the references without namespace take on the namespace, except for
+// the references to the build-in types, which the parser has mapped to the
+// build-in types of the language already.
+type chameleon struct {
+	namespace    string
+	buildInTypes map[string]bool
+}
+
+// schema returns the copy of the schema document for the namespace of the
+// chameleon.
+func (c *chameleon) schema(schema *Schema) *Schema {
+	chameleon := &Schema{
+		FilePath:        schema.FilePath,
+		URL:             schema.URL,
+		TargetNamespace: c.namespace,
+		Annotations:     schema.Annotations,
+	}
+	components := make(map[interface{}]interface{})
+	for _, ele := range schema.ProtoTree {
+		components[ele] = c.component(ele)
+		chameleon.ProtoTree = append(chameleon.ProtoTree, components[ele])
+	}
+	for _, e := range schema.GlobalElements {
+		if element, ok := components[e].(*Element); ok {
+			chameleon.GlobalElements = append(chameleon.GlobalElements, element)
+			continue
+		}
+		element := c.element(*e)
+		chameleon.GlobalElements = append(chameleon.GlobalElements, &element)
+	}
+	for _, redefine := range schema.Redefines {
+		r := *redefine
+		r.Components = nil
+		for _, ele := range redefine.Components {
+			r.Components = append(r.Components, c.component(ele))
+		}
+		chameleon.Redefines = append(chameleon.Redefines, &r)
+	}
+	for _, reference := range schema.References {
+		r := *reference
+		if r.Kind == "include" {
+			r.Namespace = c.namespace
+		}
+		chameleon.References = append(chameleon.References, &r)
+	}
+	return chameleon
+}
+
+// component returns the copy of the top-level component.
+func (c *chameleon) component(component interface{}) interface{} {
+	switch v := component.(type) {
+	case *SimpleType:
+		simpleType := c.simpleType(*v)
+		return &simpleType
+	case *ComplexType:
+		complexType := c.complexType(*v)
+		return &complexType
+	case *Element:
+		element := c.element(*v)
+		return &element
+	case *Attribute:
+		attribute := c.attribute(*v)
+		return &attribute
+	case *Group:
+		group := c.group(*v)
+		return &group
+	case *AttributeGroup:
+		attributeGroup := c.attributeGroup(*v)
+		return &attributeGroup
+	}
+	return component
+}
+
+// qualify returns the namespace of the reference by given name and
+// namespace, a reference without namespace takes on the namespace of the
+// chameleon.
+func (c *chameleon) qualify(name, ns string) string {
+	if name == "" || ns != "" {
+		return ns
+	}
+	return c.namespace
+}
+
+// qualifyType returns the namespace of the type reference by given type name
+// and namespace.
+func (c *chameleon) qualifyType(name, ns string) string {
+	if c.buildInTypes[name] {
+		return ns
+	}
+	return c.qualify(name, ns)
+}
+
+func (c *chameleon) simpleType(v SimpleType) SimpleType {
+	v.TargetNamespace = c.namespace
+	v.BaseNamespace = c.qualifyType(v.Base, v.BaseNamespace)
+	if v.MemberTypes != nil {
+		memberTypes, namespaces := make(map[string]string), make(map[string]string)
+		for member, memberType := range v.MemberTypes {
+			memberTypes[member] = memberType
+			if ns := c.qualifyType(memberType, v.MemberTypeNamespaces[member]); ns != "" {
+				namespaces[member] = ns
+			}
+		}
+		v.MemberTypes, v.MemberTypeNamespaces = memberTypes, namespaces
+	}
+	return v
+}
+
+func (c *chameleon) complexType(v ComplexType) ComplexType {
+	v.TargetNamespace = c.namespace
+	v.BaseNamespace = c.qualifyType(v.Base, v.BaseNamespace)
+	v.Elements = c.elements(v.Elements)
+	v.Attributes = c.attributes(v.Attributes)
+	v.Groups = c.groups(v.Groups)
+	v.Choice = c.choices(v.Choice)
+	var attributeGroups []AttributeGroup
+	for _, attributeGroup := range v.AttributeGroup {
+		attributeGroups = append(attributeGroups, c.attributeGroup(attributeGroup))
+	}
+	v.AttributeGroup = attributeGroups
+	return v
+}
+
+func (c *chameleon) element(v Element) Element {
+	v.TargetNamespace = c.namespace
+	v.TypeNamespace = c.qualifyType(v.Type, v.TypeNamespace)
+	v.RefNamespace = c.qualify(v.Ref, v.RefNamespace)
+	v.SubstitutionGroupNamespace = c.qualify(v.SubstitutionGroup, v.SubstitutionGroupNamespace)
+	var constraints []IdentityConstraint
+	for _, constraint := range v.IdentityConstraints {
+		constraint.TargetNamespace = c.namespace
+		constraint.ReferNamespace = c.qualify(constraint.Refer, constraint.ReferNamespace)
+		constraints = append(constraints, constraint)
+	}
+	v.IdentityConstraints = constraints
+	var alternatives []Alternative
+	for _, alternative := range v.Alternatives {
+		alternative.TypeNamespace = c.qualifyType(alternative.Type, alternative.TypeNamespace)
+		alternatives = append(alternatives, alternative)
+	}
+	v.Alternatives = alternatives
+	return v
+}
+
+func (c *chameleon) elements(elements []Element) (copies []Element) {
+	for _, element := range elements {
+		copies = append(copies, c.element(element))
+	}
+	return
+}
+
+func (c *chameleon) attribute(v Attribute) Attribute {
+	v.TargetNamespace = c.namespace
+	v.TypeNamespace = c.qualifyType(v.Type, v.TypeNamespace)
+	v.RefNamespace = c.qualify(v.Ref, v.RefNamespace)
+	return v
+}
+
+func (c *chameleon) attributes(attributes []Attribute) (copies []Attribute) {
+	for _, attribute := range attributes {
+		copies = append(copies, c.attribute(attribute))
+	}
+	return
+}
+
+func (c *chameleon) group(v Group) Group {
+	v.TargetNamespace = c.namespace
+	v.RefNamespace = c.qualify(v.Ref, v.RefNamespace)
+	v.Elements = c.elements(v.Elements)
+	v.Groups = c.groups(v.Groups)
+	v.Choice = c.choices(v.Choice)
+	return v
+}
+
+func (c *chameleon) groups(groups []Group) (copies []Group) {
+	for _, group := range groups {
+		copies = append(copies, c.group(group))
+	}
+	return
+}
+
+func (c *chameleon) choices(choices []Choice) (copies []Choice) {
+	for _, choice := range choices {
+		choice.Elements = c.elements(choice.Elements)
+		choice.Choice = c.choices(choice.Choice)
+		copies = append(copies, choice)
+	}
+	return
+}
+
+func (c *chameleon) attributeGroup(v AttributeGroup) AttributeGroup {
+	v.TargetNamespace = c.namespace
+	v.RefNamespace = c.qualify(v.Ref, v.RefNamespace)
+	v.Attributes = c.attributes(v.Attributes)
+	return v
+}
@@ -28,6 +28,8 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/xuri/xgen"
 )
@@ -39,6 +41,12 @@ type Config struct {
 	O       string
 	Pkg     string
 	Lang    string
+	Check   bool
+	Cache   string
+	Offline bool
+	Catalog string
+	Anon    string
+	DocLang string
 	Version string
 }
 
@@ -64,11 +72,17 @@ func parseFlags() *Config {
 	oPtr := flag.String("o", "xgen_out", "Output file path or directory for the generated code")
 	pkgPtr := flag.String("p", "", "Specify the package name")
 	langPtr := flag.String("l", "", "Specify the language of generated code")
+	checkPtr := flag.Bool("c", false, "Generate identity constraint and fixed value check helpers (Go only)")
+	cachePtr := flag.String("cache", defaultCacheDir(), "Cache directory for the remote XML schema definitions")
+	offlinePtr := flag.Bool("offline", false, "Only use cached remote XML schema definitions")
+	catalogPtr := flag.String("catalog", "", "Comma-separated OASIS XML Catalog files for resolving schema locations")
+	anonPtr := flag.String("anon", xgen.AnonymousNamingElement, "Naming strategy of anonymous types (element/path/suffix)")
+	docLangPtr := flag.String("doclang", "", "Preferred language of the documentation for doc comments, such as en")
 	verPtr := flag.Bool("v", false, "Show version and exit")
 	helpPtr := flag.Bool("h", false, "Show this help and exit")
 	flag.Parse()
 	if *helpPtr {
-		fmt.Printf("xgen version: %s\r\nCopyright (c) 2020 - 2021 Ri Xu https://xuri.me All rights reserved.\r\n\r\nUsage:\r\n$ xgen [<flag> ...] <XSD file or directory> ...\n  -i <path>\tInput file path or directory for the XML schema definition\r\n  -o <path>\tOutput file path or directory for the generated code\r\n  -p     \tSpecify the package name\r\n  -l      \tSpecify the language of generated code (Go/C/Java/Rust/TypeScript)\r\n  -h     \tOutput this help and exit\r\n  -v     \tOutput version and exit\r\n", Cfg.Version)
+		fmt.Printf("xgen version: %s\r\nCopyright (c) 2020 - 2021 Ri Xu https://xuri.me All rights reserved.\r\n\r\nUsage:\r\n$ xgen [<flag> ...] <XSD file or directory> ...\n  -i <path>\tInput file path or directory for the XML schema definition\r\n  -o <path>\tOutput file path or directory for the generated code\r\n  -p     \tSpecify the package name\r\n  -l      \tSpecify the language of generated code (Go/C/Java/Rust/TypeScript)\r\n  -c     \tGenerate identity constraint and fixed value check helpers (Go only)\r\n  -cache <path>\tCache directory for the remote XML schema definitions\r\n  -offline\tOnly use cached remote XML schema definitions\r\n  -catalog <path>\tComma-separated OASIS XML Catalog files for resolving schema locations\r\n  -anon   \tNaming strategy of anonymous types (element/path/suffix)\r\n  -doclang\tPreferred language of the documentation for doc comments, such as en\r\n  -h     \tOutput this help and exit\r\n  -v     \tOutput version and exit\r\n", Cfg.Version)
 		os.Exit(0)
 	}
 	if *verPtr {
@@ -95,38 +109,73 @@ func parseFlags() *Config {
 	if *pkgPtr != "" {
 		Cfg.Pkg = *pkgPtr
 	}
+	Cfg.Check = *checkPtr
+	Cfg.Cache, Cfg.Offline = *cachePtr, *offlinePtr
+	Cfg.Catalog = *catalogPtr
+	Cfg.DocLang = *docLangPtr
+	switch *anonPtr {
+	case xgen.AnonymousNamingElement, xgen.AnonymousNamingPath, xgen.AnonymousNamingSuffix:
+		Cfg.Anon = *anonPtr
+	default:
+		fmt.Println("unsupport naming strategy of anonymous types", *anonPtr)
+		os.Exit(1)
+	}
 	return &Cfg
 }
 
+// defaultCacheDir returns the default cache directory for the remote XML
+// schema definitions in the user cache directory.
+func defaultCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "xgen")
+}
+
 func main() {
 	cfg := parseFlags()
 	if err := xgen.PrepareOutputDir(cfg.O); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
-	files, err := xgen.GetFileList(cfg.I)
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
+	parser := xgen.NewParser(&xgen.Options{
+		InputDir:       cfg.I,
+		OutputDir:      cfg.O,
+		Lang:           cfg.Lang,
+		Package:        cfg.Pkg,
+		IntegrityCheck: cfg.Check,
+
+		AnonymousTypeNaming: cfg.Anon,
+		DocLanguage:         cfg.DocLang,
+	})
+	if cfg.Cache != "" {
+		parser.Cache = xgen.NewSchemaCache(cfg.Cache)
+		parser.Cache.Offline = cfg.Offline
 	}
-	for _, file := range files {
-		if err = xgen.NewParser(&xgen.Options{
-			FilePath:            file,
-			InputDir:            cfg.I,
-			OutputDir:           cfg.O,
-			Lang:                cfg.Lang,
-			Package:             cfg.Pkg,
-			IncludeMap:          make(map[string]bool),
-			LocalNameNSMap:      make(map[string]string),
-			NSSchemaLocationMap: make(map[string]string),
-			ParseFileList:       make(map[string]bool),
-			ParseFileMap:        make(map[string][]interface{}),
-			ProtoTree:           make([]interface{}, 0),
-			RemoteSchema:        make(map[string][]byte),
-		}).Parse(); err != nil {
-			fmt.Printf("process error on %s: %s\r\n", file, err.Error())
+	if cfg.Catalog != "" {
+		catalog, err := xgen.LoadCatalog(strings.Split(cfg.Catalog, ",")...)
+		if err != nil {
+			fmt.Println(err)
 			os.Exit(1)
 		}
+		parser.Catalog = catalog
+	}
+	set, err := parser.Load(cfg.I)
+	if set == nil {
+		fmt.Printf("process error: %s\r\n", err.Error())
+		os.Exit(1)
+	}
+	// the error diagnostic returned is in the diagnostics of the schema set.
+	for _, diagnostic := range set.Diagnostics {
+		fmt.Println(diagnostic.Error())
+	}
+	if err != nil {
+		os.Exit(1)
+	}
+	if err = parser.Generate(set); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
 	}
 	fmt.Println("done")
 }
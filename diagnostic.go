@@ -0,0 +1,113 @@
+// Copyright 2020 - 2021 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+)
+
+// Diagnostic codes reported by the parser.
+const (
+	DiagnosticUnknownElement      = "unknown-element"
+	DiagnosticRedefineNotFound    = "redefine-not-found"
+	DiagnosticUnsupported         = "unsupported"
+	DiagnosticUnresolvedLocation  = "unresolved-location"
+	DiagnosticSyntax              = "syntax"
+	DiagnosticInvalid             = "invalid"
+	DiagnosticUnresolvedReference = "unresolved-reference"
+)
+
+// Severities of the diagnostics. A warning doesn't stop the parsing, an
+// error stops the parsing of the document and is returned by the parser.
+const (
+	SeverityWarning = "warning"
+	SeverityError   = "error"
+)
+
+// Diagnostic describes a problem found in an XSD document. The Line and
+// Column are the 1-based position of the start tag of the element causing
+// the problem, or of the malformed XML for syntax errors, which are zero if
+// unknown. The Err is the underlying error of an error diagnostic, if any.
+type Diagnostic struct {
+	File     string
+	Line     int
+	Column   int
+	Severity string
+	Code     string
+	Message  string
+	Err      error
+}
+
+// Error returns the description of the diagnostic prefixed with the
+// position.
+func (d *Diagnostic) Error() string {
+	if d.Line == 0 {
+		return fmt.Sprintf("%s: %s", d.File, d.Message)
+	}
+	return fmt.Sprintf("%s: %s", Position{File: d.File, Line: d.Line, Column: d.Column}, d.Message)
+}
+
+// Unwrap returns the underlying error of the diagnostic.
+func (d *Diagnostic) Unwrap() error {
+	return d.Err
+}
+
+// Diagnostics holds the diagnostics reported while parsing XSD documents.
+type Diagnostics []*Diagnostic
+
+// Errors returns the diagnostics with error severity.
+func (d Diagnostics) Errors() (errs Diagnostics) {
+	for _, diagnostic := range d {
+		if diagnostic.Severity == SeverityError {
+			errs = append(errs, diagnostic)
+		}
+	}
+	return
+}
+
+// report adds a warning diagnostic about the element being parsed.
+func (opt *Options) report(code, format string, a ...interface{}) {
+	*opt.Diagnostics = append(*opt.Diagnostics, &Diagnostic{
+		File:     opt.FilePath,
+		Line:     opt.pos.Line,
+		Column:   opt.pos.Column,
+		Severity: SeverityWarning,
+		Code:     code,
+		Message:  fmt.Sprintf(format, a...),
+	})
+}
+
+// fail adds an error diagnostic for the error which stops the parsing of the
+// document and returns the diagnostic. The XML syntax errors are reported at
+// the position of the decoder, other errors at the element being parsed. The
+// diagnostic of a referenced document is returned as is.
+func (opt *Options) fail(err error) error {
+	var diagnostic *Diagnostic
+	if errors.As(err, &diagnostic) {
+		return diagnostic
+	}
+	diagnostic = &Diagnostic{
+		File:     opt.FilePath,
+		Line:     opt.pos.Line,
+		Column:   opt.pos.Column,
+		Severity: SeverityError,
+		Code:     DiagnosticInvalid,
+		Message:  err.Error(),
+		Err:      err,
+	}
+	var syntaxErr *xml.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		diagnostic.Line, diagnostic.Column = opt.decoder.InputPos()
+		diagnostic.Code, diagnostic.Message = DiagnosticSyntax, "XML syntax error: "+syntaxErr.Msg
+	}
+	*opt.Diagnostics = append(*opt.Diagnostics, diagnostic)
+	return diagnostic
+}
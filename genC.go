@@ -11,7 +11,6 @@ package xgen
 import (
 	"fmt"
 	"os"
-	"reflect"
 	"strings"
 )
 
@@ -34,17 +33,21 @@ var cBuildInType = map[string]bool{
 	"enum":           true,
 }
 
+// Fields capturing the content matched by element and attribute wildcards
+// for C language.
+const (
+	cAnyElement   = "\tchar Any[]; // any\n"
+	cAnyAttribute = "\tchar AnyAttr[]; // anyAttribute\n"
+)
+
 // GenC generates C programming language source code for XML schema definition
 // files.
 func (gen *CodeGenerator) GenC() error {
 	fieldNameCount = make(map[string]int)
-	for _, ele := range gen.ProtoTree {
-		if ele == nil {
-			continue
-		}
-		funcName := fmt.Sprintf("C%s", reflect.TypeOf(ele).String()[6:])
-		callFuncByName(gen, funcName, []reflect.Value{reflect.ValueOf(ele)})
+	if err := gen.genProtoTree(gen.CSimpleType, gen.CComplexType, gen.CGroup, gen.CAttributeGroup, gen.CElement, gen.CAttribute); err != nil {
+		return err
 	}
+	gen.genSubstitutionGroups(gen.CSubstitutionGroup)
 	f, err := os.Create(gen.File + ".h")
 	if err != nil {
 		return err
@@ -96,29 +99,33 @@ func genCFieldType(name string) string {
 	return "void"
 }
 
+// genCOptional returns the comment for the optional and not repeated fields.
+func genCOptional(optional, plural bool) string {
+	if optional && !plural {
+		return " // optional"
+	}
+	return ""
+}
+
 // CSimpleType generates code for simple type XML schema in C language
 // syntax.
 func (gen *CodeGenerator) CSimpleType(v *SimpleType) {
 	if v.List {
-		if _, ok := gen.StructAST[v.Name]; !ok {
-			fieldType := genCFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))
+		if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
+			fieldType := genCFieldType(getBasefromSimpleType(toQName(v.BaseNamespace, v.Base), gen.ProtoTree))
 			content := fmt.Sprintf("%s %s[];\n", genCFieldType(fieldType), genCFieldName(v.Name, false))
-			gen.StructAST[v.Name] = content
+			gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
 			fieldName := genCFieldName(v.Name, true)
-			gen.Field += fmt.Sprintf("%stypedef %s", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name])
+			gen.Field += fmt.Sprintf("%stypedef %s", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//")+genConstraintComment(v.Restriction.Assertions, nil, "//"), gen.StructAST[toQName(v.TargetNamespace, v.Name)])
 			return
 		}
 	}
 	if v.Union && len(v.MemberTypes) > 0 {
-		if _, ok := gen.StructAST[v.Name]; !ok {
+		if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
 			content := "struct {\n"
 			for _, member := range toSortedPairs(v.MemberTypes) {
 				memberName := member.key
 				memberType := member.value
-
-				if memberType == "" { // fix order issue
-					memberType = getBasefromSimpleType(memberName, gen.ProtoTree)
-				}
 				var plural, fieldType string
 				var ok bool
 				if fieldType, ok = innerArray(genCFieldType(memberType)); ok {
@@ -127,31 +134,31 @@ func (gen *CodeGenerator) CSimpleType(v *SimpleType) {
 				content += fmt.Sprintf("\t%s %s%s;\n", fieldType, genCFieldName(memberName, false), plural)
 			}
 			content += "}"
-			gen.StructAST[v.Name] = content
+			gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
 			fieldName := genCFieldName(v.Name, true)
-			gen.Field += fmt.Sprintf("%stypedef %s %s;\n", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name], fieldName)
+			gen.Field += fmt.Sprintf("%stypedef %s %s;\n", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//")+genConstraintComment(v.Restriction.Assertions, nil, "//"), gen.StructAST[toQName(v.TargetNamespace, v.Name)], fieldName)
 		}
 		return
 	}
-	if _, ok := gen.StructAST[v.Name]; !ok {
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
 		var plural, fieldType string
 		var ok bool
-		if fieldType, ok = innerArray(genCFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))); ok {
+		if fieldType, ok = innerArray(genCFieldType(getBasefromSimpleType(toQName(v.BaseNamespace, v.Base), gen.ProtoTree))); ok {
 			plural = "[]"
 		}
-		gen.StructAST[v.Name] = fmt.Sprintf("%s %s%s", fieldType, genCFieldName(v.Name, false), plural)
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = fmt.Sprintf("%s %s%s", fieldType, genCFieldName(v.Name, false), plural)
 		fieldName := genCFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%stypedef %s;\n", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name])
+		gen.Field += fmt.Sprintf("%stypedef %s;\n", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//")+genConstraintComment(v.Restriction.Assertions, nil, "//"), gen.StructAST[toQName(v.TargetNamespace, v.Name)])
 	}
 }
 
 // CComplexType generates code for complex type XML schema in C language
 // syntax.
 func (gen *CodeGenerator) CComplexType(v *ComplexType) {
-	if _, ok := gen.StructAST[v.Name]; !ok {
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
 		content := "struct {\n"
 		for _, attrGroup := range v.AttributeGroup {
-			fieldType := getBasefromSimpleType(trimNSPrefix(attrGroup.Ref), gen.ProtoTree)
+			fieldType := getBasefromSimpleType(toQName(attrGroup.RefNamespace, attrGroup.Ref), gen.ProtoTree)
 			content += fmt.Sprintf("\t%s %s;\n", genCFieldType(fieldType), genCFieldName(attrGroup.Name, false))
 		}
 
@@ -162,68 +169,92 @@ func (gen *CodeGenerator) CComplexType(v *ComplexType) {
 			}
 			var plural, fieldType string
 			var ok bool
-			if fieldType, ok = innerArray(genCFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree))); ok {
+			if fieldType, ok = innerArray(genCFieldType(getBasefromSimpleType(toQName(attribute.TypeNamespace, attribute.Type), gen.ProtoTree))); ok {
 				plural = "[]"
 			}
 			content += fmt.Sprintf("\t%s %sAttr%s; // attr%s\n", fieldType, genCFieldName(attribute.Name, false), plural, optional)
 		}
-
-		for _, group := range v.Groups {
-			var plural string
-			if group.Plural {
-				plural = "[]"
-			}
-			content += fmt.Sprintf("\t%s %s%s;\n", genCFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree)), genCFieldName(group.Name, false), plural)
+		if v.AnyAttribute != nil {
+			content += cAnyAttribute
+		}
+		if hasOpenContent(v) {
+			content += cAnyElement
 		}
 
-		for _, element := range v.Elements {
-			var plural, fieldType string
-			var ok bool
-			if fieldType, ok = innerArray(genCFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree))); ok || element.Plural {
-				plural = "[]"
+		for _, item := range orderedContent(v.Particle, v.Groups, v.Elements, nil) {
+			switch item := item.(type) {
+			case *Group:
+				content += gen.genCGroupField(item)
+			case *Element:
+				if item.Wildcard != nil || gen.substitutionGroup(item) != nil {
+					content += gen.genCElementField(item)
+					continue
+				}
+				var plural, fieldType string
+				var ok bool
+				if fieldType, ok = innerArray(genCFieldType(getBasefromSimpleType(toQName(item.TypeNamespace, item.Type), gen.ProtoTree))); ok || item.Plural {
+					plural = "[]"
+				}
+				content += fmt.Sprintf("\t%s %s%s;%s\n", fieldType, genCFieldName(item.Name, false), plural, genCOptional(item.Optional, item.Plural))
 			}
-			content += fmt.Sprintf("\t%s %s%s;\n", fieldType, genCFieldName(element.Name, false), plural)
 		}
 		// TODO: Implement handling of v.Base for the cases of the type being a built-in one and
 		// the case of inheritance/embedding
 		content += "}"
-		gen.StructAST[v.Name] = content
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
 		fieldName := genCFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%stypedef %s %s;\n", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name], fieldName)
+		gen.Field += fmt.Sprintf("%stypedef %s %s;\n", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//")+genConstraintComment(v.Assertions, nil, "//"), gen.StructAST[toQName(v.TargetNamespace, v.Name)], fieldName)
 	}
 }
 
 // CGroup generates code for group XML schema in C language syntax.
 func (gen *CodeGenerator) CGroup(v *Group) {
-	if _, ok := gen.StructAST[v.Name]; !ok {
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
 		content := "struct {\n"
-		for _, element := range v.Elements {
-			var plural string
-			if element.Plural {
-				plural = "[]"
-			}
-			content += fmt.Sprintf("\t%s %s%s;\n", genCFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree)), genCFieldName(element.Name, false), plural)
-		}
-
-		for _, group := range v.Groups {
-			var plural string
-			if group.Plural {
-				plural = "[]"
+		for _, item := range orderedContent(v.Particle, v.Groups, v.Elements, nil) {
+			switch item := item.(type) {
+			case *Group:
+				content += gen.genCGroupField(item)
+			case *Element:
+				content += gen.genCElementField(item)
 			}
-			content += fmt.Sprintf("\t%s %s%s;\n", genCFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree)), genCFieldName(group.Name, false), plural)
 		}
 
 		content += "}"
-		gen.StructAST[v.Name] = content
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
 		fieldName := genCFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%stypedef %s %s;\n", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name], fieldName)
+		gen.Field += fmt.Sprintf("%stypedef %s %s;\n", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//"), gen.StructAST[toQName(v.TargetNamespace, v.Name)], fieldName)
 	}
 }
 
+// genCGroupField returns the field of the group reference.
+func (gen *CodeGenerator) genCGroupField(group *Group) string {
+	var plural string
+	if group.Plural {
+		plural = "[]"
+	}
+	return fmt.Sprintf("\t%s %s%s;%s\n", genCFieldType(getBasefromSimpleType(toQName(group.RefNamespace, group.Ref), gen.ProtoTree)), genCFieldName(group.Name, false), plural, genCOptional(group.Optional, group.Plural))
+}
+
+// genCElementField returns the field of the element in a model group.
+func (gen *CodeGenerator) genCElementField(element *Element) string {
+	if element.Wildcard != nil {
+		return cAnyElement
+	}
+	var plural string
+	if element.Plural {
+		plural = "[]"
+	}
+	if gen.substitutionGroup(element) != nil {
+		return fmt.Sprintf("\t%sSubstitution %s%s;%s\n", genCFieldName(element.Ref, false), genCFieldName(element.Name, false), plural, genCOptional(element.Optional, element.Plural))
+	}
+	return fmt.Sprintf("\t%s %s%s;%s\n", genCFieldType(getBasefromSimpleType(toQName(element.TypeNamespace, element.Type), gen.ProtoTree)), genCFieldName(element.Name, false), plural, genCOptional(element.Optional, element.Plural))
+}
+
 // CAttributeGroup generates code for attribute group XML schema in C language
 // syntax.
 func (gen *CodeGenerator) CAttributeGroup(v *AttributeGroup) {
-	if _, ok := gen.StructAST[v.Name]; !ok {
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
 		content := "struct {\n"
 		for _, attribute := range v.Attributes {
 			var optional, plural, fieldType string
@@ -231,41 +262,61 @@ func (gen *CodeGenerator) CAttributeGroup(v *AttributeGroup) {
 			if attribute.Optional {
 				optional = `, optional`
 			}
-			if fieldType, ok = innerArray(genCFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree))); ok {
+			if fieldType, ok = innerArray(genCFieldType(getBasefromSimpleType(toQName(attribute.TypeNamespace, attribute.Type), gen.ProtoTree))); ok {
 				plural = "[]"
 			}
 			content += fmt.Sprintf("\t%s %sAttr%s; // attr%s\n", fieldType, genCFieldName(attribute.Name, false), plural, optional)
 		}
+		if v.AnyAttribute != nil {
+			content += cAnyAttribute
+		}
 		content += "}"
-		gen.StructAST[v.Name] = content
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
 		fieldName := genCFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%stypedef %s %s;\n", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name], fieldName)
+		gen.Field += fmt.Sprintf("%stypedef %s %s;\n", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//"), gen.StructAST[toQName(v.TargetNamespace, v.Name)], fieldName)
 	}
 }
 
 // CElement generates code for element XML schema in C language syntax.
 func (gen *CodeGenerator) CElement(v *Element) {
-	if _, ok := gen.StructAST[v.Name]; !ok {
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
 		var plural, fieldType string
 		var ok bool
-		if fieldType, ok = innerArray(genCFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree))); ok || v.Plural {
+		if fieldType, ok = innerArray(genCFieldType(getBasefromSimpleType(toQName(v.TypeNamespace, v.Type), gen.ProtoTree))); ok || v.Plural {
 			plural = "[]"
 		}
-		gen.StructAST[v.Name] = fmt.Sprintf("%s %s%s", fieldType, genCFieldName(v.Name, false), plural)
-		gen.Field += fmt.Sprintf("\ntypedef %s;\n", gen.StructAST[v.Name])
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = fmt.Sprintf("%s %s%s", fieldType, genCFieldName(v.Name, false), plural)
+		gen.Field += fmt.Sprintf("\ntypedef %s;\n", gen.StructAST[toQName(v.TargetNamespace, v.Name)])
 	}
 }
 
 // CAttribute generates code for attribute XML schema in C language syntax.
 func (gen *CodeGenerator) CAttribute(v *Attribute) {
-	if _, ok := gen.StructAST[v.Name]; !ok {
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
 		var plural, fieldType string
 		var ok bool
-		if fieldType, ok = innerArray(genCFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree))); ok || v.Plural {
+		if fieldType, ok = innerArray(genCFieldType(getBasefromSimpleType(toQName(v.TypeNamespace, v.Type), gen.ProtoTree))); ok || v.Plural {
 			plural = "[]"
 		}
-		gen.StructAST[v.Name] = fmt.Sprintf("%s %s%s", fieldType, genCFieldName(v.Name, false), plural)
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = fmt.Sprintf("%s %s%s", fieldType, genCFieldName(v.Name, false), plural)
 		fieldName := genCFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%stypedef %s;\n", genFieldComment(fieldName, v.Doc, "//"), gen.StructAST[v.Name])
+		gen.Field += fmt.Sprintf("%stypedef %s;\n", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//"), gen.StructAST[toQName(v.TargetNamespace, v.Name)])
+	}
+}
+
+// CSubstitutionGroup generates code for substitution group XML schema in C
+// language syntax.
+func (gen *CodeGenerator) CSubstitutionGroup(v *SubstitutionGroup) {
+	content := "union {\n"
+	for _, e := range v.Elements {
+		var plural, fieldType string
+		var ok bool
+		if fieldType, ok = innerArray(genCFieldType(getBasefromSimpleType(toQName(e.TypeNamespace, e.Type), gen.ProtoTree))); ok {
+			plural = "[]"
+		}
+		content += fmt.Sprintf("\t%s %s%s;\n", fieldType, genCFieldName(e.Name, false), plural)
 	}
+	content += "}"
+	typeName := genCFieldName(v.Head.Name, false) + "Substitution"
+	gen.Field += fmt.Sprintf("%stypedef %s %s;\n", genFieldComment(typeName, fmt.Sprintf("the substitution group of the %s element.", v.Head.Name), "//"), content, typeName)
 }
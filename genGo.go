@@ -9,10 +9,13 @@
 package xgen
 
 import (
+	"encoding/xml"
 	"fmt"
 	"go/format"
+	"io/ioutil"
 	"os"
-	"reflect"
+	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -23,10 +26,24 @@ type CodeGenerator struct {
 	File              string
 	Field             string
 	Package           string
+	DocLanguage       string
 	ImportTime        bool // For Go language
 	ImportEncodingXML bool // For Go language
+	AnyElement        bool // For Go language
+	MixedContent      bool // For Go language
+	UnionType         bool // For Go language
+	DefaultValue      bool // For Go language
+	FixedValue        bool // For Go language
+	IntegrityCheck    bool // For Go language
 	ProtoTree         []interface{}
-	StructAST         map[string]string
+	StructAST         map[xml.Name]string
+
+	GlobalElements     []*Element
+	SubstitutionGroups map[xml.Name]*SubstitutionGroup
+	ComplexTypes       map[xml.Name]*ComplexType
+	IdentityAttributes map[string]bool
+	TypePrefixes       map[xml.Name]string
+	Groups             map[xml.Name]*Group
 }
 
 var goBuildinType = map[string]bool{
@@ -56,16 +73,120 @@ var goBuildinType = map[string]bool{
 	"uint64":        true,
 }
 
+// goIntegerBitSize is the bit size of the Go integer types.
+var goIntegerBitSize = map[string]int{
+	"byte":   8,
+	"int":    64,
+	"int8":   8,
+	"int16":  16,
+	"int32":  32,
+	"int64":  64,
+	"uint":   64,
+	"uint8":  8,
+	"uint16": 16,
+	"uint32": 32,
+	"uint64": 64,
+}
+
+// goAnyElement is the declaration of the type holding an element matched by
+// an element wildcard.
+const goAnyElement = `
+// AnyElement holds the raw XML of an element matched by an element wildcard.
+type AnyElement struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr ` + "`xml:\",any,attr\"`" + `
+	InnerXML string     ` + "`xml:\",innerxml\"`" + `
+}
+`
+
+// goMixedContent is the declarations of the types and functions holding the
+// character data and elements of mixed content in document order.
+const goMixedContent = `
+// MixedContent holds the character data and the elements of mixed content in
+// document order.
+type MixedContent []MixedItem
+
+// MixedItem is the character data of Text or the Element of mixed content.
+type MixedItem struct {
+	Text    string
+	Element *AnyElement
+}
+
+// MarshalXML encodes the character data and the elements in document order.
+func (c MixedContent) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	for _, item := range c {
+		if item.Element != nil {
+			if err := e.Encode(item.Element); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := e.EncodeToken(xml.CharData(item.Text)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeMixedContent decodes the attributes of the element into v, and
+// returns the character data and the elements of its content in document
+// order.
+func decodeMixedContent(d *xml.Decoder, start xml.StartElement, v interface{}) (MixedContent, error) {
+	if err := xml.NewTokenDecoder(&tokenReader{start, start.End()}).Decode(v); err != nil {
+		return nil, err
+	}
+	var content MixedContent
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch token := token.(type) {
+		case xml.CharData:
+			content = append(content, MixedItem{Text: string(token)})
+		case xml.StartElement:
+			element := new(AnyElement)
+			if err = d.DecodeElement(element, &token); err != nil {
+				return nil, err
+			}
+			content = append(content, MixedItem{Element: element})
+		case xml.EndElement:
+			return content, nil
+		}
+	}
+}
+
+// tokenReader reads the tokens in the slice.
+type tokenReader []xml.Token
+
+// Token returns the next token in the slice.
+func (r *tokenReader) Token() (xml.Token, error) {
+	if len(*r) == 0 {
+		return nil, io.EOF
+	}
+	token := (*r)[0]
+	*r = (*r)[1:]
+	return token, nil
+}
+`
+
 // GenGo generate Go programming language source code for XML schema
-// definition files.
+// definition files. The types and functions shared by the generated code of
+// the schema documents are written into the runtime file of the package.
 func (gen *CodeGenerator) GenGo() error {
 	fieldNameCount = make(map[string]int)
-	for _, ele := range gen.ProtoTree {
-		if ele == nil {
-			continue
+	if err := gen.genProtoTree(gen.GoSimpleType, gen.GoComplexType, gen.GoGroup, gen.GoAttributeGroup, gen.GoElement, gen.GoAttribute); err != nil {
+		return err
+	}
+	gen.genSubstitutionGroups(gen.GoSubstitutionGroup)
+	runtime := gen.AnyElement || gen.MixedContent || gen.UnionType || gen.DefaultValue || gen.FixedValue
+	if gen.IntegrityCheck && gen.genGoIdentityConstraints() {
+		runtime = true
+	}
+	if runtime {
+		if err := gen.genGoRuntime(); err != nil {
+			return err
 		}
-		funcName := fmt.Sprintf("Go%s", reflect.TypeOf(ele).String()[6:])
-		callFuncByName(gen, funcName, []reflect.Value{reflect.ValueOf(ele)})
 	}
 	f, err := os.Create(gen.File + ".go")
 	if err != nil {
@@ -79,22 +200,45 @@ func (gen *CodeGenerator) GenGo() error {
 	if gen.ImportEncodingXML {
 		packages += "\t\"encoding/xml\"\n"
 	}
+	if gen.FixedValue {
+		packages += "\t\"fmt\"\n"
+	}
 	if packages != "" {
 		importPackage = fmt.Sprintf("import (\n%s)", packages)
 	}
-	packageName := gen.Package
-	if packageName == "" {
-		packageName = "schema"
-	}
-	source, err := format.Source([]byte(fmt.Sprintf("%s\n\npackage %s\n%s%s", copyright, packageName, importPackage, gen.Field)))
+	source, err := format.Source([]byte(fmt.Sprintf("%s\n\npackage %s\n%s%s", copyright, gen.goPackageName(), importPackage, gen.Field)))
 	if err != nil {
-		f.WriteString(fmt.Sprintf("package %s\n%s%s", packageName, importPackage, gen.Field))
+		f.WriteString(fmt.Sprintf("package %s\n%s%s", gen.goPackageName(), importPackage, gen.Field))
 		return err
 	}
 	f.Write(source)
 	return err
 }
 
+// goPackageName returns the package name of the generated Go code.
+func (gen *CodeGenerator) goPackageName() string {
+	if gen.Package == "" {
+		return "schema"
+	}
+	return gen.Package
+}
+
+// goRuntimeFile is the name of the file holding the types and functions
+// shared by the generated Go code in an output directory.
+const goRuntimeFile = "xgen_runtime.go"
+
+// genGoRuntime writes the element wildcard, mixed content, union, default
+// value, fixed value and identity constraint runtime into the runtime file of
+// the package in the output directory, which is shared by the generated code
+// of the schema documents in the package, so that it's declared once.
+func (gen *CodeGenerator) genGoRuntime() error {
+	source, err := format.Source([]byte(fmt.Sprintf("%s\n\npackage %s\n\nimport (\n\t\"encoding\"\n\t\"encoding/xml\"\n\t\"fmt\"\n\t\"io\"\n\t\"reflect\"\n\t\"strings\"\n)\n%s%s%s%s%s%s", copyright, gen.goPackageName(), goAnyElement, goMixedContent, goUnionRuntime, goDefaultValueRuntime, goFixedValueRuntime, goIdentityConstraintRuntime)))
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(filepath.Dir(gen.File), goRuntimeFile), source, 0644)
+}
+
 func genGoFieldName(name string, unique bool) (fieldName string) {
 	for _, str := range strings.Split(name, ":") {
 		fieldName += MakeFirstUpperCase(str)
@@ -129,65 +273,120 @@ func genGoFieldType(name string) string {
 	return "interface{}"
 }
 
+// goTypeName returns the name of the Go type of the component by given
+// qualified name, which is prefixed when the local name is declared in more
+// than one namespace.
+func (gen *CodeGenerator) goTypeName(name xml.Name) string {
+	return gen.TypePrefixes[name] + name.Local
+}
+
+// goFieldType returns the Go type of the field with the type, element or
+// attribute by given qualified name.
+func (gen *CodeGenerator) goFieldType(name xml.Name) string {
+	return genGoFieldType(gen.goTypeName(getBaseQNamefromSimpleType(name, gen.ProtoTree)))
+}
+
 // GoSimpleType generates code for simple type XML schema in Go language
 // syntax.
 func (gen *CodeGenerator) GoSimpleType(v *SimpleType) {
 	if v.List {
-		if _, ok := gen.StructAST[v.Name]; !ok {
-			fieldType := genGoFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))
+		if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
+			fieldType := gen.goFieldType(toQName(v.BaseNamespace, v.Base))
 			if fieldType == "time.Time" {
 				gen.ImportTime = true
 			}
 			content := fmt.Sprintf(" []%s\n", genGoFieldType(fieldType))
-			gen.StructAST[v.Name] = content
-			fieldName := genGoFieldName(v.Name, true)
-			gen.Field += fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+			gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
+			fieldName := genGoFieldName(gen.goTypeName(toQName(v.TargetNamespace, v.Name)), true)
+			gen.Field += fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//")+genConstraintComment(v.Restriction.Assertions, nil, "//"), fieldName, gen.StructAST[toQName(v.TargetNamespace, v.Name)])
 			return
 		}
 	}
 	if v.Union && len(v.MemberTypes) > 0 {
-		if _, ok := gen.StructAST[v.Name]; !ok {
-			content := " struct {\n"
-			fieldName := genGoFieldName(v.Name, true)
-			if fieldName != v.Name {
-				gen.ImportEncodingXML = true
-				content += fmt.Sprintf("\tXMLName\txml.Name\t`xml:\"%s\"`\n", v.Name)
-			}
-			for _, member := range toSortedPairs(v.MemberTypes) {
-				memberName := member.key
-				memberType := member.value
-
-				if memberType == "" { // fix order issue
-					memberType = getBasefromSimpleType(memberName, gen.ProtoTree)
-				}
-				content += fmt.Sprintf("\t%s\t%s\n", genGoFieldName(memberName, false), genGoFieldType(memberType))
-			}
-			content += "}\n"
-			gen.StructAST[v.Name] = content
-			gen.Field += fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
+			gen.genGoUnionType(v)
 		}
 		return
 	}
-	if _, ok := gen.StructAST[v.Name]; !ok {
-		content := fmt.Sprintf(" %s\n", genGoFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree)))
-		gen.StructAST[v.Name] = content
-		fieldName := genGoFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
+		content := fmt.Sprintf(" %s\n", gen.goFieldType(toQName(v.BaseNamespace, v.Base)))
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
+		fieldName := genGoFieldName(gen.goTypeName(toQName(v.TargetNamespace, v.Name)), true)
+		gen.Field += fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//")+genConstraintComment(v.Restriction.Assertions, nil, "//"), fieldName, gen.StructAST[toQName(v.TargetNamespace, v.Name)])
 	}
 }
 
+// genGoUnionType generates the struct of the union simple type, which holds
+// the value of the first member type accepting the value in declaration
+// order, and the methods decoding and encoding the union as an element or
+// attribute. A member type with enumeration accepts the enumerated values
+// only.
+func (gen *CodeGenerator) genGoUnionType(v *SimpleType) {
+	gen.ImportEncodingXML, gen.UnionType = true, true
+	fieldName := genGoFieldName(gen.goTypeName(toQName(v.TargetNamespace, v.Name)), true)
+	members := v.Members
+	if len(members) != len(v.MemberTypes) {
+		members = nil
+		for _, member := range toSortedPairs(v.MemberTypes) {
+			members = append(members, member.key)
+		}
+	}
+	content := " struct {\n"
+	var fields, values, enumerations string
+	var enumerated bool
+	for _, member := range members {
+		fieldType := genGoFieldType(gen.goTypeName(xml.Name{Space: v.MemberTypeNamespaces[member], Local: v.MemberTypes[member]}))
+		if fieldType == "time.Time" {
+			gen.ImportTime = true
+		}
+		if !strings.HasPrefix(fieldType, "*") {
+			fieldType = "*" + fieldType
+		}
+		content += fmt.Sprintf("\t%s\t%s\n", genGoFieldName(member, false), fieldType)
+		fields += fmt.Sprintf(", &v.%s", genGoFieldName(member, false))
+		values += fmt.Sprintf(", v.%s", genGoFieldName(member, false))
+		enumeration := "nil"
+		if enum := gen.simpleTypeEnum(member); len(enum) > 0 {
+			enumeration, enumerated = fmt.Sprintf("%#v", enum), true
+		}
+		enumerations += ", " + strings.TrimPrefix(enumeration, "[]string")
+	}
+	if enumerations = fmt.Sprintf("[][]string{%s}", strings.TrimPrefix(enumerations, ", ")); !enumerated {
+		enumerations = "nil"
+	}
+	content += "}\n"
+	gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
+	gen.Field += fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//")+genConstraintComment(v.Restriction.Assertions, nil, "//"), fieldName, content)
+	gen.Field += fmt.Sprintf("\n// UnmarshalXML decodes the value of the element into the first member type\n// of the union accepting it.\nfunc (v *%s) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {\n\tvar value string\n\tif err := d.DecodeElement(&value, &start); err != nil {\n\t\treturn err\n\t}\n\treturn v.UnmarshalXMLAttr(xml.Attr{Name: start.Name, Value: value})\n}\n", fieldName)
+	gen.Field += fmt.Sprintf("\n// UnmarshalXMLAttr decodes the value of the attribute into the first member\n// type of the union accepting it.\nfunc (v *%s) UnmarshalXMLAttr(attr xml.Attr) error {\n\t*v = %s{}\n\treturn decodeUnion(attr.Value, []interface{}{%s}, %s)\n}\n", fieldName, fieldName, strings.TrimPrefix(fields, ", "), enumerations)
+	gen.Field += fmt.Sprintf("\n// MarshalXML encodes the value of the member type of the union as the\n// element.\nfunc (v %s) MarshalXML(e *xml.Encoder, start xml.StartElement) error {\n\tvalue, _, err := encodeUnion(%s)\n\tif err != nil {\n\t\treturn err\n\t}\n\treturn e.EncodeElement(value, start)\n}\n", fieldName, strings.TrimPrefix(values, ", "))
+	gen.Field += fmt.Sprintf("\n// MarshalXMLAttr encodes the value of the member type of the union as the\n// attribute, the union without value is absent.\nfunc (v %s) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {\n\tvalue, ok, err := encodeUnion(%s)\n\tif !ok || err != nil {\n\t\treturn xml.Attr{}, err\n\t}\n\treturn xml.Attr{Name: name, Value: value}, nil\n}\n", fieldName, strings.TrimPrefix(values, ", "))
+}
+
+// simpleTypeEnum returns the enumeration values of the simple type by given
+// name in the proto tree.
+func (gen *CodeGenerator) simpleTypeEnum(name string) []string {
+	for _, ele := range gen.ProtoTree {
+		if simpleType, ok := ele.(*SimpleType); ok && simpleType.Name == name {
+			return simpleType.Restriction.Enum
+		}
+	}
+	return nil
+}
+
 // GoComplexType generates code for complex type XML schema in Go language
 // syntax.
 func (gen *CodeGenerator) GoComplexType(v *ComplexType) {
-	if _, ok := gen.StructAST[v.Name]; !ok {
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
 		content := " struct {\n"
-		fieldName := genGoFieldName(v.Name, true)
-		if fieldName != v.Name {
+		fieldName := genGoFieldName(gen.goTypeName(toQName(v.TargetNamespace, v.Name)), true)
+		if fieldName != gen.goTypeName(toQName(v.TargetNamespace, v.Name)) {
 			gen.ImportEncodingXML = true
 			content += fmt.Sprintf("\tXMLName\txml.Name\t`xml:\"%s\"`\n", v.Name)
 		}
+		var initializers, checks string
 		for _, attrGroup := range v.AttributeGroup {
-			fieldType := getBasefromSimpleType(trimNSPrefix(attrGroup.Ref), gen.ProtoTree)
+			fieldType := gen.goTypeName(getBaseQNamefromSimpleType(toQName(attrGroup.RefNamespace, attrGroup.Ref), gen.ProtoTree))
 			if fieldType == "time.Time" {
 				gen.ImportTime = true
 			}
@@ -199,45 +398,408 @@ func (gen *CodeGenerator) GoComplexType(v *ComplexType) {
 			if attribute.Optional {
 				optional = `,omitempty`
 			}
-			fieldType := genGoFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree))
+			fieldType := gen.goFieldType(toQName(attribute.TypeNamespace, attribute.Type))
 			if fieldType == "time.Time" {
 				gen.ImportTime = true
 			}
 			content += fmt.Sprintf("\t%sAttr\t%s\t`xml:\"%s,attr%s\"`\n", genGoFieldName(attribute.Name, false), fieldType, attribute.Name, optional)
+			initializer, check, _ := genGoValueConstraint(genGoFieldName(attribute.Name, false)+"Attr", fieldType, attribute.Name, attribute.Default, attribute.Fixed)
+			initializers, checks = initializers+initializer, checks+check
+		}
+		if v.AnyAttribute != nil {
+			content += gen.genGoAnyAttribute()
+		}
+		if hasOpenContent(v) && !v.Mixed {
+			content += gen.genGoAnyElement()
+		}
+		base, baseNamespace := contentBase(v)
+		if len(base) > 0 && !isGoBuiltInType(base) {
+			// If it's not built-in one, embed the base type in the struct for the child type
+			// to effectively inherit all of the base type's fields, the content of the base
+			// type precedes the content of the extension
+			content += fmt.Sprintf("\t%s\n", genGoFieldType(gen.goTypeName(toQName(baseNamespace, base))))
+		}
+		var union string
+		items := orderedContent(v.Particle, v.Groups, v.Elements, gen.complexTypeUnion(v))
+		if v.Mixed {
+			// The character data and elements of mixed content are held by
+			// the Content in document order
+			items = nil
+			content += gen.genGoMixedContent()
+		}
+		captures := gen.elementCaptures(items)
+		if hasOpenContent(v) && !v.Mixed {
+			captures++
 		}
-		for _, group := range v.Groups {
-			var plural string
-			if group.Plural {
-				plural = "[]"
+		named := substitutionNames(captures)
+		for _, item := range items {
+			switch item := item.(type) {
+			case *Choice:
+				var field string
+				field, union = gen.genGoUnion(fieldName, item)
+				content += field
+			case *Group:
+				content += gen.genGoGroupRef(item)
+			case *Element:
+				if item.Wildcard != nil {
+					content += gen.genGoAnyElement()
+					continue
+				}
+				if gen.substitutionGroup(item) != nil {
+					content += gen.genGoSubstitutionField(*item, named)
+					continue
+				}
+				var plural, optional string
+				if item.Plural {
+					plural = "[]"
+				} else if item.Optional {
+					optional = `,omitempty`
+				}
+				fieldType := gen.goFieldType(toQName(item.TypeNamespace, item.Type))
+				if fieldType == "time.Time" {
+					gen.ImportTime = true
+				}
+				content += fmt.Sprintf("\t%s\t%s%s\t`xml:\"%s%s\"`\n", genGoFieldName(item.Name, false), plural, fieldType, genGoElementTag(item), optional)
+				if !item.Plural {
+					initializer, check, _ := genGoValueConstraint(genGoFieldName(item.Name, false), fieldType, item.Name, item.Default, item.Fixed)
+					initializers, checks = initializers+initializer, checks+check
+				}
 			}
-			content += fmt.Sprintf("\t%s\t%s%s\n", genGoFieldName(group.Name, false), plural, genGoFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree)))
 		}
+		if len(base) > 0 && isGoBuiltInType(base) && !v.Mixed {
+			// If the type is a built-in type, generate a Value field as chardata.
+			content += fmt.Sprintf("\tValue\t%s\t`xml:\",chardata\"`\n", genGoFieldType(base))
+		}
+		values := gen.genGoDefaultValues(v)
+		if values.shadows != "" || values.tracked {
+			content += "\tabsent\tmap[string]bool\n"
+		}
+		content += "}\n"
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
+		gen.Field += fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//")+genConstraintComment(v.Assertions, nil, "//"), fieldName, gen.StructAST[toQName(v.TargetNamespace, v.Name)])
+		gen.Field += union
+		if initializers != "" {
+			gen.Field += fmt.Sprintf("\n// New%s returns a new %s initialized with the default and\n// fixed values of its attributes and elements.\nfunc New%s() *%s {\n\treturn &%s{\n%s\t}\n}\n", fieldName, fieldName, fieldName, fieldName, fieldName, initializers)
+		}
+		if checks != "" && gen.IntegrityCheck {
+			gen.FixedValue = true
+			gen.Field += fmt.Sprintf("\n// checkFixed checks the values of the attributes and elements of the %s\n// with a fixed value.\nfunc (v *%s) checkFixed() error {\n%s\treturn nil\n}\n", fieldName, fieldName, checks)
+		}
+		defaults := values.defaults
+		if values.shadows != "" || values.tracked {
+			defaults = "\tv.absent = make(map[string]bool)\n" + defaults
+		}
+		if values.tracked {
+			gen.Field += fmt.Sprintf("\n// absentAttrs returns the attributes absent from the decoded element by\n// their names.\nfunc (v *%s) absentAttrs() map[string]bool {\n\treturn v.absent\n}\n", fieldName)
+		}
+		if v.Mixed {
+			gen.Field += fmt.Sprintf("\n// UnmarshalXML decodes the attributes and the mixed content of the element.\nfunc (v *%s) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {\n%s\tv.Content, err = decodeMixedContent(d, start, &struct {\n\t\t*%s\n\t\tUnmarshalXML struct{} `xml:\"-\"`\n\t}{%s: v})\n%s\treturn\n}\n", fieldName, values.allocations, fieldName, fieldName, defaults)
+		} else if defaults != "" {
+			gen.ImportEncodingXML, gen.DefaultValue = true, true
+			empty := "_"
+			if values.empty {
+				empty = "empty"
+			}
+			gen.Field += fmt.Sprintf("\n// UnmarshalXML decodes the element with the default and fixed values of the\n// absent attributes and the empty elements.\nfunc (v *%s) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {\n%s\t%s, err := decodeElement(d, start, &struct {\n\t\t*%s\n\t\tUnmarshalXML struct{} `xml:\"-\"`\n\t}{%s: v})\n\tif err != nil {\n\t\treturn err\n\t}\n%s\treturn nil\n}\n", fieldName, values.allocations, empty, fieldName, fieldName, defaults)
+		}
+		if values.shadows != "" {
+			gen.DefaultValue = true
+			presents := values.presents
+			if values.zeros != "" {
+				// The optional attributes with a zero value of the element
+				// which isn't decoded are absent
+				presents = fmt.Sprintf("\tif v.absent == nil {\n\t\tv.absent = map[string]bool{\n%s\t\t}\n\t}\n", values.zeros) + presents
+			}
+			gen.Field += fmt.Sprintf("\n// MarshalXML encodes the element without the default and fixed values of the\n// attributes absent from the decoded element, the optional attributes with a\n// zero value are absent from the element which isn't decoded.\nfunc (v %s) MarshalXML(e *xml.Encoder, start xml.StartElement) error {\n\tvalue := struct {\n\t\t*%s\n\t\tMarshalXML struct{} `xml:\"-\"`\n%s\t}{%s: &v}\n%s\treturn e.EncodeElement(value, start)\n}\n", fieldName, fieldName, values.shadows, fieldName, presents)
+		}
+	}
+}
+
+// goDefaultValues holds the code decoding the default and fixed values of
+// the attributes and elements of a complex type, and the code encoding the
+// attributes with such values which are present. The tracked reports whether
+// the presence of the optional attributes selected by the identity
+// constraints is recorded.
+type goDefaultValues struct {
+	allocations, defaults, shadows, presents, zeros string
+	empty, tracked                                  bool
+}
 
-		for _, element := range v.Elements {
-			var plural string
-			if element.Plural {
-				plural = "[]"
+// genGoDefaultValues returns the code of the default and fixed values of the
+// attributes and elements of the complex type and the complex types it
+// extends, so that the decoding and encoding of the struct covers the
+// embedded structs of its base types. The embedded structs are allocated
+// before decoding, and the attributes of a nil embedded struct are absent on
+// encoding. The attributes and elements of a base type redeclared by the
+// derived type are declared once.
+func (gen *CodeGenerator) genGoDefaultValues(v *ComplexType) (values goDefaultValues) {
+	var allocations, guard, nilGuard string
+	declared, visited := make(map[string]bool), make(map[*ComplexType]bool)
+	for complexType := v; complexType != nil && !visited[complexType]; {
+		visited[complexType] = true
+		var constrained bool
+		for _, attribute := range complexType.Attributes {
+			fieldType := gen.goFieldType(toQName(attribute.TypeNamespace, attribute.Type))
+			field := genGoFieldName(attribute.Name, false) + "Attr"
+			_, _, literal := genGoValueConstraint(field, fieldType, attribute.Name, attribute.Default, attribute.Fixed)
+			if declared["@"+attribute.Name] {
+				continue
 			}
-			fieldType := genGoFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree))
-			if fieldType == "time.Time" {
-				gen.ImportTime = true
+			declared["@"+attribute.Name] = true
+			if literal == "" {
+				if attribute.Optional && gen.IdentityAttributes[attribute.Name] {
+					// The presence of the optional attribute selected by the
+					// field of an identity constraint is recorded
+					values.tracked = true
+					values.defaults += fmt.Sprintf("\tv.absent[%q] = !hasAttr(start, %q)\n", attribute.Name, attribute.Name)
+				}
+				continue
+			}
+			// The absent attribute takes the default or fixed value, which is
+			// not encoded
+			constrained = true
+			values.defaults += fmt.Sprintf("\tif !hasAttr(start, %q) {\n\t\tv.%s, v.absent[%q] = %s, true\n\t}\n", attribute.Name, field, attribute.Name, literal)
+			values.shadows += fmt.Sprintf("\t\t%s\t*%s\t`xml:\"%s,attr\"`\n", field, fieldType, attribute.Name)
+			values.presents += fmt.Sprintf("\tif %s!v.absent[%q] {\n\t\tvalue.%s = &v.%s\n\t}\n", guard, attribute.Name, field, field)
+			if attribute.Optional {
+				_, zero, _ := genGoLiteral(fieldType, "")
+				values.zeros += fmt.Sprintf("\t\t\t%q: %sv.%s == %s,\n", attribute.Name, nilGuard, field, zero)
 			}
-			content += fmt.Sprintf("\t%s\t%s%s\t`xml:\"%s\"`\n", genGoFieldName(element.Name, false), plural, fieldType, element.Name)
 		}
-		if len(v.Base) > 0 {
-			// If the type is a built-in type, generate a Value field as chardata.
-			// If it's not built-in one, embed the base type in the struct for the child type
-			// to effectively inherit all of the base type's fields
-			if isGoBuiltInType(v.Base) {
-				content += fmt.Sprintf("\tValue\t%s\t`xml:\",chardata\"`\n", genGoFieldType(v.Base))
-			} else {
-				content += fmt.Sprintf("\t%s\n", genGoFieldType(v.Base))
+		if !v.Mixed {
+			for _, item := range orderedContent(complexType.Particle, complexType.Groups, complexType.Elements, gen.complexTypeUnion(complexType)) {
+				element, ok := item.(*Element)
+				if !ok || element.Plural || element.Wildcard != nil || gen.substitutionGroup(element) != nil || declared[element.Name] {
+					continue
+				}
+				declared[element.Name] = true
+				fieldType := gen.goFieldType(toQName(element.TypeNamespace, element.Type))
+				field := genGoFieldName(element.Name, false)
+				if _, _, literal := genGoValueConstraint(field, fieldType, element.Name, element.Default, element.Fixed); literal != "" {
+					// The empty element takes the default or fixed value
+					constrained, values.empty = true, true
+					values.defaults += fmt.Sprintf("\tif empty[%q] {\n\t\tv.%s = %s\n\t}\n", element.Name, field, literal)
+				}
 			}
 		}
-		content += "}\n"
-		gen.StructAST[v.Name] = content
-		gen.Field += fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		if constrained {
+			values.allocations = allocations
+		}
+		base, baseNamespace := contentBase(complexType)
+		if len(base) == 0 || isGoBuiltInType(base) {
+			break
+		}
+		embedded := strings.TrimPrefix(genGoFieldType(gen.goTypeName(toQName(baseNamespace, base))), "*")
+		allocations += fmt.Sprintf("\tif v.%s == nil {\n\t\tv.%s = new(%s)\n\t}\n", embedded, embedded, embedded)
+		guard += fmt.Sprintf("v.%s != nil && ", embedded)
+		nilGuard += fmt.Sprintf("v.%s == nil || ", embedded)
+		complexType = gen.ComplexTypes[toQName(complexType.BaseNamespace, complexType.Base)]
+	}
+	return
+}
+
+// genGoValueConstraint returns the initializer of the field of an element or
+// attribute with the default or fixed value in the constructor, the check of
+// the fixed value and the literal of the value. The value of a field with a
+// zero value is absent, so it is not checked.
+func genGoValueConstraint(field, fieldType, name, defaultValue, fixed string) (initializer, check, literal string) {
+	if defaultValue == "" && fixed == "" {
+		return
+	}
+	literal, _, ok := genGoLiteral(fieldType, valueConstraint(defaultValue, fixed))
+	if !ok {
+		return "", "", ""
+	}
+	initializer = fmt.Sprintf("\t\t%s: %s,\n", field, literal)
+	if fixed == "" {
+		return
+	}
+	if literal, zero, _ := genGoLiteral(fieldType, fixed); literal != zero {
+		check = fmt.Sprintf("\tif v.%s != %s && v.%s != %s {\n\t\treturn fmt.Errorf(%q, v.%s, %s)\n\t}\n", field, zero, field, literal, "fixed "+name+": value %v doesn't match %v", field, literal)
+	}
+	return
+}
+
+// genGoLiteral returns the Go literal of the value for the built-in field
+// type, and the literal of the zero value of the type.
+func genGoLiteral(fieldType, value string) (literal, zero string, ok bool) {
+	switch fieldType {
+	case "string":
+		return strconv.Quote(value), `""`, true
+	case "bool":
+		literal, ok = genBooleanLiteral(value)
+		return literal, "false", ok
+	case "float32", "float64":
+		literal, ok = genFloatLiteral(value)
+		return literal, "0", ok
+	}
+	if bitSize, integer := goIntegerBitSize[fieldType]; integer {
+		literal, ok = genIntegerLiteral(value, bitSize, fieldType == "byte" || strings.HasPrefix(fieldType, "u"))
+		return literal, "0", ok
+	}
+	return
+}
+
+// genGoMixedContent returns the field holding the character data and the
+// elements of mixed content in document order.
+func (gen *CodeGenerator) genGoMixedContent() string {
+	gen.ImportEncodingXML, gen.AnyElement, gen.MixedContent = true, true, true
+	return "\tContent\tMixedContent\t`xml:\",any\"`\n"
+}
+
+// genGoUnion returns the field holding the alternatives of the choice, and
+// the declarations of the union of the alternatives. The value of the union
+// is one of the alternatives, which implement the sealed interface of the
+// union, and the union is decoded and encoded as the element of the
+// alternative. The union without value is absent, the elements which are not
+// the alternatives of the union are skipped, and the list of the unions of a
+// plural choice keeps only the unions with a value.
+func (gen *CodeGenerator) genGoUnion(owner string, choice *Choice) (field, declarations string) {
+	gen.ImportEncodingXML = true
+	typeName := owner + genGoFieldName(choice.Name, false)
+	plural := choice.Plural
+	var alternatives, unmarshalCases, marshalCases string
+	for _, e := range choice.Elements {
+		plural = plural || e.Plural
+		fieldType := gen.goFieldType(toQName(e.TypeNamespace, e.Type))
+		if fieldType == "time.Time" {
+			gen.ImportTime = true
+		}
+		alternative := typeName + genGoFieldName(e.Name, false)
+		alternatives += fmt.Sprintf("%stype %s struct {\n\tValue\t%s\n}\n\nfunc (%s) is%s() {}\n", genFieldComment(alternative, fmt.Sprintf("the %s alternative of the %s.", e.Name, typeName), "//"), alternative, fieldType, alternative, typeName)
+		unmarshalCases += fmt.Sprintf("\tcase \"%s\":\n\t\tvar value %s\n\t\terr := d.DecodeElement(&value.Value, &start)\n\t\tv.Value = value\n\t\treturn err\n", e.Name, alternative)
+		marshalCases += fmt.Sprintf("\tcase %s:\n\t\treturn e.EncodeElement(value.Value, xml.StartElement{Name: xml.Name{Local: \"%s\"}})\n", alternative, e.Name)
+	}
+	fieldType := typeName
+	if plural {
+		fieldType += "List"
+	}
+	field = fmt.Sprintf("\t%s\t%s\t`xml:\",any\"`\n", genGoFieldName(choice.Name, false), fieldType)
+	declarations += fmt.Sprintf("%stype %s struct {\n\tValue\t%sValue\n}\n", genFieldComment(typeName, fmt.Sprintf("the choice of the %s element.", choiceAlternatives(choice)), "//"), typeName, typeName)
+	declarations += fmt.Sprintf("%stype %sValue interface {\n\tis%s()\n}\n", genFieldComment(typeName+"Value", fmt.Sprintf("implemented by the alternatives of the %s.", typeName), "//"), typeName, typeName)
+	declarations += alternatives
+	declarations += fmt.Sprintf("\n// UnmarshalXML decodes the alternative of the choice by the name of the element.\nfunc (v *%s) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {\n\tswitch start.Name.Local {\n%s\t}\n\treturn d.Skip()\n}\n", typeName, unmarshalCases)
+	declarations += fmt.Sprintf("\n// MarshalXML encodes the alternative of the choice.\nfunc (v %s) MarshalXML(e *xml.Encoder, start xml.StartElement) error {\n\tswitch value := v.Value.(type) {\n%s\t}\n\treturn nil\n}\n", typeName, marshalCases)
+	if plural {
+		declarations += fmt.Sprintf("%stype %sList []%s\n", genFieldComment(typeName+"List", fmt.Sprintf("the list of the %s.", typeName), "//"), typeName, typeName)
+		declarations += fmt.Sprintf("\n// UnmarshalXML decodes the alternative of the choice, the elements which\n// are not the alternatives are skipped.\nfunc (v *%sList) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {\n\tvar union %s\n\tif err := union.UnmarshalXML(d, start); err != nil || union.Value == nil {\n\t\treturn err\n\t}\n\t*v = append(*v, union)\n\treturn nil\n}\n", typeName, typeName)
+	}
+	return
+}
+
+// genGoAnyElement returns the field capturing the raw XML of the elements
+// matched by an element wildcard.
+func (gen *CodeGenerator) genGoAnyElement() string {
+	gen.AnyElement = true
+	return "\tAny\t[]AnyElement\t`xml:\",any\"`\n"
+}
+
+// genGoAnyAttribute returns the field capturing the attributes matched by an
+// attribute wildcard.
+func (gen *CodeGenerator) genGoAnyAttribute() string {
+	gen.ImportEncodingXML = true
+	return "\tAnyAttr\t[]xml.Attr\t`xml:\",any,attr\"`\n"
+}
+
+// genGoSubstitutionField returns the field holding the elements of the
+// substitution group headed by the element declaration referenced by given
+// element. The field captures the elements by their names, so when the
+// elements of the content are captured by more than one field, the members
+// of the group are held by the fields named after them in place of it. The
+// named holds the names of such fields declared by the content, the members
+// declared by other substitution groups of the content are skipped.
+func (gen *CodeGenerator) genGoSubstitutionField(element Element, named map[string]bool) (content string) {
+	typeName := genGoFieldName(gen.goTypeName(toQName(element.RefNamespace, element.Ref)), false) + "Substitution"
+	if named == nil {
+		if element.Plural {
+			return fmt.Sprintf("\t%s\t%sList\t`xml:\",any\"`\n", genGoFieldName(element.Name, false), typeName)
+		}
+		return fmt.Sprintf("\t%s\t*%s\t`xml:\",any\"`\n", genGoFieldName(element.Name, false), typeName)
+	}
+	for _, member := range gen.substitutionGroup(&element).Elements {
+		if named[member.Name] {
+			continue
+		}
+		named[member.Name] = true
+		tag := member.Name
+		if member.TargetNamespace != "" {
+			tag = member.TargetNamespace + " " + member.Name
+		}
+		fieldType := gen.goFieldType(toQName(member.TypeNamespace, member.Type))
+		if element.Plural {
+			content += fmt.Sprintf("\t%s\t[]%s\t`xml:\"%s\"`\n", genGoFieldName(member.Name, false), fieldType, tag)
+			continue
+		}
+		content += fmt.Sprintf("\t%s\t%s\t`xml:\"%s,omitempty\"`\n", genGoFieldName(member.Name, false), fieldType, tag)
+	}
+	return
+}
+
+// elementCaptures returns the number of the fields capturing the elements of
+// given content by their names, which are the field of the element wildcard
+// and the fields of the substitution groups.
+func (gen *CodeGenerator) elementCaptures(items []interface{}) (count int) {
+	for _, item := range items {
+		if e, ok := item.(*Element); ok && (e.Wildcard != nil || gen.substitutionGroup(e) != nil) {
+			count++
+		}
+	}
+	return
+}
+
+// substitutionNames returns the names of the member fields of the
+// substitution groups of a content with given number of the fields capturing
+// the elements, or nil if the substitution groups are held by their fields.
+func substitutionNames(captures int) map[string]bool {
+	if captures > 1 {
+		return make(map[string]bool)
+	}
+	return nil
+}
+
+// genGoGroupRef returns the fields of the model group referenced by given
+// group reference. The struct of the group is embedded, so that the elements
+// of the group are decoded from the content of the referencing element. The
+// elements of a group which may occur more than once are held by lists in
+// place of the struct.
+func (gen *CodeGenerator) genGoGroupRef(ref *Group) (content string) {
+	group, ok := gen.Groups[toQName(ref.RefNamespace, ref.Ref)]
+	if !ref.Plural || !ok {
+		return fmt.Sprintf("\t%s\n", gen.goFieldType(toQName(ref.RefNamespace, ref.Ref)))
+	}
+	items := orderedContent(group.Particle, group.Groups, group.Elements, nil)
+	named := substitutionNames(gen.elementCaptures(items))
+	for _, item := range items {
+		switch item := item.(type) {
+		case *Group:
+			nested := *item
+			nested.Plural = true
+			content += gen.genGoGroupRef(&nested)
+		case *Element:
+			if item.Wildcard != nil {
+				content += gen.genGoAnyElement()
+				continue
+			}
+			element := *item
+			element.Plural = true
+			if gen.substitutionGroup(&element) != nil {
+				content += gen.genGoSubstitutionField(element, named)
+				continue
+			}
+			content += fmt.Sprintf("\t%s\t[]%s\t`xml:\"%s\"`\n", genGoFieldName(element.Name, false), gen.goFieldType(toQName(element.TypeNamespace, element.Type)), genGoElementTag(&element))
+		}
 	}
+	return
+}
+
+// genGoElementTag returns the name of the element in the struct tag of its
+// field, which is qualified by the namespace of the referenced element
+// declaration.
+func genGoElementTag(element *Element) string {
+	if element.RefNamespace != "" {
+		return element.RefNamespace + " " + element.Name
+	}
+	return element.Name
 }
 
 func isGoBuiltInType(typeName string) bool {
@@ -247,42 +809,57 @@ func isGoBuiltInType(typeName string) bool {
 
 // GoGroup generates code for group XML schema in Go language syntax.
 func (gen *CodeGenerator) GoGroup(v *Group) {
-	if _, ok := gen.StructAST[v.Name]; !ok {
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
 		content := " struct {\n"
-		fieldName := genGoFieldName(v.Name, true)
-		if fieldName != v.Name {
+		fieldName := genGoFieldName(gen.goTypeName(toQName(v.TargetNamespace, v.Name)), true)
+		if fieldName != gen.goTypeName(toQName(v.TargetNamespace, v.Name)) {
 			gen.ImportEncodingXML = true
 			content += fmt.Sprintf("\tXMLName\txml.Name\t`xml:\"%s\"`\n", v.Name)
 		}
-		for _, element := range v.Elements {
-			var plural string
-			if element.Plural {
-				plural = "[]"
-			}
-			content += fmt.Sprintf("\t%s\t%s%s\n", genGoFieldName(element.Name, false), plural, genGoFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree)))
-		}
-
-		for _, group := range v.Groups {
-			var plural string
-			if group.Plural {
-				plural = "[]"
+		var union string
+		items := orderedContent(v.Particle, v.Groups, v.Elements, gen.unionChoice(v.Particle, v.Elements, v.Choice))
+		named := substitutionNames(gen.elementCaptures(items))
+		for _, item := range items {
+			switch item := item.(type) {
+			case *Choice:
+				var field string
+				field, union = gen.genGoUnion(fieldName, item)
+				content += field
+			case *Group:
+				content += gen.genGoGroupRef(item)
+			case *Element:
+				if item.Wildcard != nil {
+					content += gen.genGoAnyElement()
+					continue
+				}
+				if gen.substitutionGroup(item) != nil {
+					content += gen.genGoSubstitutionField(*item, named)
+					continue
+				}
+				var plural, optional string
+				if item.Plural {
+					plural = "[]"
+				} else if item.Optional {
+					optional = `,omitempty`
+				}
+				content += fmt.Sprintf("\t%s\t%s%s\t`xml:\"%s%s\"`\n", genGoFieldName(item.Name, false), plural, gen.goFieldType(toQName(item.TypeNamespace, item.Type)), genGoElementTag(item), optional)
 			}
-			content += fmt.Sprintf("\t%s\t%s%s\n", genGoFieldName(group.Name, false), plural, genGoFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree)))
 		}
 
 		content += "}\n"
-		gen.StructAST[v.Name] = content
-		gen.Field += fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
+		gen.Field += fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//"), fieldName, gen.StructAST[toQName(v.TargetNamespace, v.Name)])
+		gen.Field += union
 	}
 }
 
 // GoAttributeGroup generates code for attribute group XML schema in Go language
 // syntax.
 func (gen *CodeGenerator) GoAttributeGroup(v *AttributeGroup) {
-	if _, ok := gen.StructAST[v.Name]; !ok {
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
 		content := " struct {\n"
-		fieldName := genGoFieldName(v.Name, true)
-		if fieldName != v.Name {
+		fieldName := genGoFieldName(gen.goTypeName(toQName(v.TargetNamespace, v.Name)), true)
+		if fieldName != gen.goTypeName(toQName(v.TargetNamespace, v.Name)) {
 			gen.ImportEncodingXML = true
 			content += fmt.Sprintf("\tXMLName\txml.Name\t`xml:\"%s\"`\n", v.Name)
 		}
@@ -291,38 +868,450 @@ func (gen *CodeGenerator) GoAttributeGroup(v *AttributeGroup) {
 			if attribute.Optional {
 				optional = `,omitempty`
 			}
-			content += fmt.Sprintf("\t%sAttr\t%s\t`xml:\"%s,attr%s\"`\n", genGoFieldName(attribute.Name, false), genGoFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree)), attribute.Name, optional)
+			content += fmt.Sprintf("\t%sAttr\t%s\t`xml:\"%s,attr%s\"`\n", genGoFieldName(attribute.Name, false), gen.goFieldType(toQName(attribute.TypeNamespace, attribute.Type)), attribute.Name, optional)
+		}
+		if v.AnyAttribute != nil {
+			content += gen.genGoAnyAttribute()
 		}
 		content += "}\n"
-		gen.StructAST[v.Name] = content
-		gen.Field += fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
+		gen.Field += fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//"), fieldName, gen.StructAST[toQName(v.TargetNamespace, v.Name)])
 	}
 }
 
 // GoElement generates code for element XML schema in Go language syntax.
 func (gen *CodeGenerator) GoElement(v *Element) {
-	if _, ok := gen.StructAST[v.Name]; !ok {
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
 		var plural string
 		if v.Plural {
 			plural = "[]"
 		}
-		content := fmt.Sprintf("\t%s%s\n", plural, genGoFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree)))
-		gen.StructAST[v.Name] = content
-		fieldName := genGoFieldName(v.Name, false)
-		gen.Field += fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		content := fmt.Sprintf("\t%s%s\n", plural, gen.goFieldType(toQName(v.TypeNamespace, v.Type)))
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
+		fieldName := genGoFieldName(gen.goTypeName(toQName(v.TargetNamespace, v.Name)), false)
+		gen.Field += fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//")+genConstraintComment(nil, v.Alternatives, "//"), fieldName, gen.StructAST[toQName(v.TargetNamespace, v.Name)])
 	}
 }
 
 // GoAttribute generates code for attribute XML schema in Go language syntax.
 func (gen *CodeGenerator) GoAttribute(v *Attribute) {
-	if _, ok := gen.StructAST[v.Name]; !ok {
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
 		var plural string
 		if v.Plural {
 			plural = "[]"
 		}
-		content := fmt.Sprintf("\t%s%s\n", plural, genGoFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree)))
-		gen.StructAST[v.Name] = content
-		fieldName := genGoFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		content := fmt.Sprintf("\t%s%s\n", plural, gen.goFieldType(toQName(v.TypeNamespace, v.Type)))
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
+		fieldName := genGoFieldName(gen.goTypeName(toQName(v.TargetNamespace, v.Name)), true)
+		gen.Field += fmt.Sprintf("%stype %s%s", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//"), fieldName, gen.StructAST[toQName(v.TargetNamespace, v.Name)])
+	}
+}
+
+// GoSubstitutionGroup generates code for substitution group XML schema in Go
+// language syntax. The generated type decodes the element of the group by its
+// name into the type of the element declaration.
+func (gen *CodeGenerator) GoSubstitutionGroup(v *SubstitutionGroup) {
+	gen.ImportEncodingXML = true
+	var cases string
+	for _, e := range v.Elements {
+		fieldType := gen.goFieldType(toQName(e.TypeNamespace, e.Type))
+		if fieldType == "time.Time" {
+			gen.ImportTime = true
+		}
+		cases += fmt.Sprintf("\tcase \"%s\":\n\t\tvalue = new(%s)\n", e.Name, strings.TrimPrefix(fieldType, "*"))
+	}
+	typeName := genGoFieldName(gen.goTypeName(toQName(v.Head.TargetNamespace, v.Head.Name)), false) + "Substitution"
+	gen.Field += fmt.Sprintf("%stype %s struct {\n\tXMLName\txml.Name\n\tValue\tinterface{}\n}\n", genFieldComment(typeName, fmt.Sprintf("the substitution group of the %s element.", v.Head.Name), "//"), typeName)
+	gen.Field += fmt.Sprintf("\n// UnmarshalXML decodes the element of the substitution group by its name, the\n// elements which are not in the group are skipped.\nfunc (v *%s) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {\n\tvar value interface{}\n\tswitch start.Name.Local {\n%s\tdefault:\n\t\treturn d.Skip()\n\t}\n\tv.XMLName, v.Value = start.Name, value\n\treturn d.DecodeElement(v.Value, &start)\n}\n", typeName, cases)
+	gen.Field += fmt.Sprintf("\n// MarshalXML encodes the element of the substitution group, the substitution\n// without value is absent.\nfunc (v %s) MarshalXML(e *xml.Encoder, start xml.StartElement) error {\n\tif v.Value == nil {\n\t\treturn nil\n\t}\n\tstart.Name = v.XMLName\n\treturn e.EncodeElement(v.Value, start)\n}\n", typeName)
+	gen.Field += fmt.Sprintf("%stype %sList []%s\n", genFieldComment(typeName+"List", fmt.Sprintf("the list of the %s.", typeName), "//"), typeName, typeName)
+	gen.Field += fmt.Sprintf("\n// UnmarshalXML decodes the element of the substitution group, the elements\n// which are not in the group are skipped.\nfunc (v *%sList) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {\n\tvar substitution %s\n\tif err := substitution.UnmarshalXML(d, start); err != nil || substitution.Value == nil {\n\t\treturn err\n\t}\n\t*v = append(*v, substitution)\n\treturn nil\n}\n", typeName, typeName)
+}
+
+// genGoIdentityConstraints generates the functions which check the identity
+// constraints declared by the global and local elements on a decoded
+// document, and reports whether any function was generated. The function of
+// a local element declaration is named by the complex type or model group
+// declaring the element, and checks the constraints on a decoded value of
+// the element.
+func (gen *CodeGenerator) genGoIdentityConstraints() bool {
+	type declaration struct {
+		name, description string
+		element           *Element
+	}
+	var declarations []declaration
+	for _, e := range gen.GlobalElements {
+		if len(e.IdentityConstraints) > 0 {
+			declarations = append(declarations, declaration{e.Name, e.Name + "\n// element", e})
+		}
+	}
+	for _, ele := range gen.ProtoTree {
+		var owner string
+		switch v := ele.(type) {
+		case *ComplexType:
+			owner = v.Name
+		case *Group:
+			owner = v.Name
+		default:
+			continue
+		}
+		seen := map[string]bool{}
+		walkComponent(ele, func(component interface{}) {
+			if e, ok := component.(*Element); ok && len(e.IdentityConstraints) > 0 && !seen[e.Name] {
+				seen[e.Name] = true
+				declarations = append(declarations, declaration{owner + "." + e.Name, "\n// " + e.Name + " element of the " + owner, e})
+			}
+		})
+	}
+	for _, d := range declarations {
+		var checks string
+		// key and unique constraints are checked first, so that the keyref
+		// constraints can be resolved against their values.
+		for _, keyref := range []bool{false, true} {
+			for _, c := range d.element.IdentityConstraints {
+				if (c.Category == "keyref") != keyref {
+					continue
+				}
+				var fields []string
+				for _, field := range c.Fields {
+					fields = append(fields, fmt.Sprintf("%q", field))
+				}
+				checks += fmt.Sprintf("\tif err := checkIdentityConstraint(v, %q, %q, %q, []string{%s}, %q, values); err != nil {\n\t\treturn err\n\t}\n", c.Category, c.Name, c.Selector, strings.Join(fields, ", "), c.Refer)
+			}
+		}
+		funcName := "Check" + genGoFieldName(d.name, false) + "Constraints"
+		gen.Field += fmt.Sprintf("\n// %s checks the identity constraints of the %s on the decoded document.\nfunc %s(v interface{}) error {\n\tvalues := map[string]map[string]bool{}\n%s\treturn nil\n}\n", funcName, d.description, funcName, checks)
+	}
+	return len(declarations) > 0
+}
+
+// goUnionRuntime defines the functions used by the generated decoding and
+// encoding of the union simple types.
+const goUnionRuntime = `
+// decodeUnion decodes the value into the first member type of a union
+// accepting it, the members are the pointers to the fields of the member
+// types in declaration order. A member type with enumeration values accepts
+// the enumerated values only.
+func decodeUnion(value string, members []interface{}, enumerations [][]string) error {
+	var text strings.Builder
+	if err := xml.EscapeText(&text, []byte(value)); err != nil {
+		return err
+	}
+	for i, member := range members {
+		if i < len(enumerations) && len(enumerations[i]) > 0 && !enumerated(strings.TrimSpace(value), enumerations[i]) {
+			continue
+		}
+		field := reflect.ValueOf(member).Elem()
+		v := reflect.New(field.Type().Elem())
+		if err := xml.Unmarshal([]byte("<v>"+text.String()+"</v>"), v.Interface()); err == nil {
+			field.Set(v)
+			return nil
+		}
+	}
+	return fmt.Errorf("value %q doesn't match the member types of the union", value)
+}
+
+// enumerated returns whether the value is one of the enumeration values.
+func enumerated(value string, enumeration []string) bool {
+	for _, enum := range enumeration {
+		if value == enum {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeUnion returns the value of the first member type of a union with a
+// value, and whether there is such a member type.
+func encodeUnion(members ...interface{}) (string, bool, error) {
+	for _, member := range members {
+		if reflect.ValueOf(member).IsNil() {
+			continue
+		}
+		switch member := member.(type) {
+		case xml.MarshalerAttr:
+			attr, err := member.MarshalXMLAttr(xml.Name{Local: "v"})
+			return attr.Value, true, err
+		case encoding.TextMarshaler:
+			text, err := member.MarshalText()
+			return string(text), true, err
+		}
+		return fmt.Sprint(reflect.ValueOf(member).Elem().Interface()), true, nil
+	}
+	return "", false, nil
+}
+`
+
+// goDefaultValueRuntime defines the functions used by the generated decoding
+// of the default and fixed values.
+const goDefaultValueRuntime = `
+// decodeElement decodes the element into v, and returns whether the child
+// elements present in the element are empty by their local names.
+func decodeElement(d *xml.Decoder, start xml.StartElement, v interface{}) (map[string]bool, error) {
+	tokens, empty := tokenReader{start}, make(map[string]bool)
+	var child string
+	for depth := 0; depth >= 0; {
+		token, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				child = token.Name.Local
+			}
+			empty[child] = depth == 0
+			depth++
+		case xml.CharData:
+			if depth == 1 {
+				empty[child] = false
+			}
+		case xml.EndElement:
+			depth--
+		}
+		tokens = append(tokens, xml.CopyToken(token))
+	}
+	return empty, xml.NewTokenDecoder(&tokens).Decode(v)
+}
+
+// hasAttr returns whether the element has the attribute by its local name.
+func hasAttr(start xml.StartElement, name string) bool {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == name {
+			return true
+		}
+	}
+	return false
+}
+`
+
+// goFixedValueRuntime defines the function checking the fixed values on a
+// decoded document.
+const goFixedValueRuntime = `
+// CheckFixedValues checks the values of the attributes and elements with a
+// fixed value on the decoded document.
+func CheckFixedValues(v interface{}) error {
+	return checkFixedValues(reflect.ValueOf(v))
+}
+
+// checkFixedValues checks the fixed values of the value and the values of
+// its exported fields, elements and referenced values.
+func checkFixedValues(value reflect.Value) error {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		return checkFixedValues(value.Elem())
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := checkFixedValues(value.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		if !value.CanAddr() {
+			addressable := reflect.New(value.Type()).Elem()
+			addressable.Set(value)
+			value = addressable
+		}
+		if checker, ok := value.Addr().Interface().(interface{ checkFixed() error }); ok {
+			if err := checker.checkFixed(); err != nil {
+				return err
+			}
+		}
+		for i := 0; i < value.NumField(); i++ {
+			if value.Type().Field(i).PkgPath != "" {
+				continue
+			}
+			if err := checkFixedValues(value.Field(i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+`
+
+// goIdentityConstraintRuntime defines the functions used by the generated
+// identity constraint checks. The selector and field XPath expressions are
+// evaluated on the decoded values by the XML names of the struct fields.
+const goIdentityConstraintRuntime = `
+// checkIdentityConstraint checks the key, keyref or unique identity
+// constraint on the decoded value of the element declaring the constraint.
+// The values of the key and unique constraints are recorded in values by the
+// constraint name, to resolve the keyref constraints referring them.
+func checkIdentityConstraint(v interface{}, category, name, selector string, fields []string, refer string, values map[string]map[string]bool) error {
+	seen := map[string]bool{}
+	for _, node := range identitySelect([]reflect.Value{reflect.ValueOf(v)}, selector) {
+		var tuple []string
+		for _, field := range fields {
+			selected := identitySelect([]reflect.Value{node}, field)
+			if len(selected) > 1 {
+				return fmt.Errorf("%s %s: field %s selects more than one value", category, name, field)
+			}
+			if len(selected) == 1 {
+				if text, ok := identityText(selected[0]); ok {
+					tuple = append(tuple, text)
+				}
+			}
+		}
+		if len(tuple) < len(fields) {
+			if category == "key" {
+				return fmt.Errorf("key %s: missing field value", name)
+			}
+			continue
+		}
+		value := strings.Join(tuple, "\x00")
+		if category == "keyref" {
+			if !values[refer][value] {
+				return fmt.Errorf("keyref %s: no %s value matches %s", name, refer, strings.Join(tuple, ", "))
+			}
+			continue
+		}
+		if seen[value] {
+			return fmt.Errorf("%s %s: duplicate value %s", category, name, strings.Join(tuple, ", "))
+		}
+		seen[value] = true
+	}
+	if category != "keyref" {
+		values[name] = seen
+	}
+	return nil
+}
+
+// identitySelect returns the values selected by the restricted XPath
+// expression of the identity constraint from the given values.
+func identitySelect(nodes []reflect.Value, expr string) (selected []reflect.Value) {
+	for _, path := range strings.Split(expr, "|") {
+		current := nodes
+		path = strings.TrimSpace(path)
+		if strings.HasPrefix(path, ".//") {
+			current = identityDescendants(current)
+			path = strings.TrimPrefix(path, ".//")
+		}
+		for _, step := range strings.Split(path, "/") {
+			step = strings.TrimSpace(step)
+			if step == "" || step == "." {
+				continue
+			}
+			attr := strings.HasPrefix(step, "@") || strings.HasPrefix(step, "attribute::")
+			step = strings.TrimPrefix(strings.TrimPrefix(strings.TrimPrefix(step, "@"), "attribute::"), "child::")
+			if i := strings.Index(step, ":"); i != -1 {
+				step = step[i+1:]
+			}
+			var next []reflect.Value
+			for _, node := range current {
+				next = append(next, identityChildren(node, step, attr, nil)...)
+			}
+			current = next
+		}
+		selected = append(selected, current...)
+	}
+	return
+}
+
+// identityDescendants returns the given values and all of their descendant
+// element values.
+func identityDescendants(nodes []reflect.Value) (descendants []reflect.Value) {
+	for len(nodes) > 0 {
+		descendants = append(descendants, nodes...)
+		var next []reflect.Value
+		for _, node := range nodes {
+			next = append(next, identityChildren(node, "*", false, nil)...)
+		}
+		nodes = next
+	}
+	return
+}
+
+// identityChildren returns the child element or attribute values with given
+// local name of the value, the name "*" matches any name. The attributes
+// absent from the decoded element are recorded by the value, or by the value
+// embedding it given by absent, and they are skipped.
+func identityChildren(node reflect.Value, name string, attr bool, absent map[string]bool) (children []reflect.Value) {
+	for node.Kind() == reflect.Ptr || node.Kind() == reflect.Interface {
+		if node.IsNil() {
+			return
+		}
+		node = node.Elem()
+	}
+	if node.Kind() != reflect.Struct {
+		return
+	}
+	if absent == nil && node.CanAddr() {
+		if recorder, ok := node.Addr().Interface().(interface{ absentAttrs() map[string]bool }); ok {
+			absent = recorder.absentAttrs()
+		}
+	}
+	for i := 0; i < node.NumField(); i++ {
+		field := node.Type().Field(i)
+		tag := strings.Split(field.Tag.Get("xml"), ",")
+		if field.PkgPath != "" || field.Name == "XMLName" || tag[0] == "-" {
+			continue
+		}
+		if field.Anonymous && tag[0] == "" {
+			children = append(children, identityChildren(node.Field(i), name, attr, absent)...)
+			continue
+		}
+		isAttr, isElement := false, true
+		for _, option := range tag[1:] {
+			isAttr = isAttr || option == "attr"
+			isElement = isElement && option != "chardata" && option != "innerxml" && option != "comment" && option != "any"
+		}
+		if !isElement || isAttr != attr {
+			continue
+		}
+		fieldName := field.Name
+		if tag[0] != "" {
+			parts := strings.Fields(tag[0])
+			fieldName = parts[len(parts)-1]
+		}
+		if i := strings.LastIndex(fieldName, ">"); i != -1 {
+			fieldName = fieldName[i+1:]
+		}
+		if name != "*" && name != fieldName {
+			continue
+		}
+		value := node.Field(i)
+		if value.Kind() == reflect.Slice && value.Type().Elem().Kind() != reflect.Uint8 {
+			for j := 0; j < value.Len(); j++ {
+				if !identityNil(value.Index(j)) {
+					children = append(children, value.Index(j))
+				}
+			}
+			continue
+		}
+		if identityNil(value) || attr && absent[fieldName] && value.IsZero() {
+			continue
+		}
+		children = append(children, value)
+	}
+	return
+}
+
+// identityNil reports whether the value is a nil pointer or interface, which
+// holds an absent element or attribute.
+func identityNil(value reflect.Value) bool {
+	return (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) && value.IsNil()
+}
+
+// identityText returns the text content of the element or attribute value,
+// and whether the value is present.
+func identityText(value reflect.Value) (string, bool) {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return "", false
+		}
+		value = value.Elem()
+	}
+	if value.Kind() == reflect.Struct {
+		for i := 0; i < value.NumField(); i++ {
+			if strings.Contains(value.Type().Field(i).Tag.Get("xml"), ",chardata") {
+				return identityText(value.Field(i))
+			}
+		}
 	}
+	return fmt.Sprint(value.Interface()), true
 }
+`
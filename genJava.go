@@ -11,7 +11,7 @@ package xgen
 import (
 	"fmt"
 	"os"
-	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -29,16 +29,20 @@ var javaBuildInType = map[string]bool{
 	"Long":         true,
 }
 
+// Fields capturing the content matched by element and attribute wildcards,
+// and the mixed content for Java language.
+const (
+	javaAnyElement   = "\t@XmlAnyElement(lax = true)\n\tprotected List<Object> any;\n"
+	javaAnyAttribute = "\t@XmlAnyAttribute\n\tprotected Map<QName, String> otherAttributes;\n"
+	javaMixedContent = "\t@XmlMixed\n\t@XmlAnyElement(lax = true)\n\tprotected List<Object> content;\n"
+)
+
 // GenJava generate Java programming language source code for XML schema
 // definition files.
 func (gen *CodeGenerator) GenJava() error {
 	fieldNameCount = make(map[string]int)
-	for _, ele := range gen.ProtoTree {
-		if ele == nil {
-			continue
-		}
-		funcName := fmt.Sprintf("Java%s", reflect.TypeOf(ele).String()[6:])
-		callFuncByName(gen, funcName, []reflect.Value{reflect.ValueOf(ele)})
+	if err := gen.genProtoTree(gen.JavaSimpleType, gen.JavaComplexType, gen.JavaGroup, gen.JavaAttributeGroup, gen.JavaElement, gen.JavaAttribute); err != nil {
+		return err
 	}
 	f, err := os.Create(gen.File + ".java")
 	if err != nil {
@@ -51,13 +55,21 @@ func (gen *CodeGenerator) GenJava() error {
 	}
 	var importPackage = `import java.util.ArrayList;
 import java.util.List;
+import java.util.Map;
+import javax.xml.bind.JAXBElement;
 import javax.xml.bind.annotation.XmlAccessType;
 import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAnyAttribute;
+import javax.xml.bind.annotation.XmlAnyElement;
 import javax.xml.bind.annotation.XmlAttribute;
 import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlElementRef;
+import javax.xml.bind.annotation.XmlElements;
+import javax.xml.bind.annotation.XmlMixed;
 import javax.xml.bind.annotation.XmlSchemaType;
 import javax.xml.bind.annotation.XmlType;
-import javax.xml.bind.annotation.XmlValue;`
+import javax.xml.bind.annotation.XmlValue;
+import javax.xml.namespace.QName;`
 
 	f.Write([]byte(fmt.Sprintf("%s\n\npackage %s;\n\n%s\n%s", copyright, packageName, importPackage, gen.Field)))
 	return err
@@ -97,54 +109,91 @@ func genJavaFieldType(name string) string {
 	return "void"
 }
 
+// genJavaRequired returns the required property of the XmlElement annotation
+// for the element.
+func genJavaRequired(optional bool) string {
+	if optional {
+		return ""
+	}
+	return "required = true, "
+}
+
+// genJavaInitializer returns the initializer of the field of an element or
+// attribute with the default or fixed value.
+func genJavaInitializer(fieldType, defaultValue, fixed string) string {
+	if defaultValue == "" && fixed == "" {
+		return ""
+	}
+	value, ok := valueConstraint(defaultValue, fixed), false
+	var literal string
+	switch fieldType {
+	case "String":
+		literal, ok = strconv.Quote(value), true
+	case "Boolean":
+		literal, ok = genBooleanLiteral(value)
+	case "Byte":
+		literal, ok = genIntegerLiteral(value, 8, false)
+	case "Short":
+		literal, ok = genIntegerLiteral(value, 16, false)
+	case "Integer":
+		literal, ok = genIntegerLiteral(value, 32, false)
+	case "Long":
+		literal, ok = genIntegerLiteral(value, 64, false)
+		literal += "L"
+	case "Float":
+		literal, ok = genFloatLiteral(value)
+		literal += "f"
+	}
+	if !ok {
+		return ""
+	}
+	return " = " + literal
+}
+
 // JavaSimpleType generates code for simple type XML schema in Java language
 // syntax.
 func (gen *CodeGenerator) JavaSimpleType(v *SimpleType) {
 	if v.List {
-		if _, ok := gen.StructAST[v.Name]; !ok {
-			fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))
+		if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
+			fieldType := genJavaFieldType(getBasefromSimpleType(toQName(v.BaseNamespace, v.Base), gen.ProtoTree))
 			content := fmt.Sprintf("\tprotected List<%s> %s;\n", fieldType, genJavaFieldName(v.Name, false))
-			gen.StructAST[v.Name] = content
-			gen.Field += fmt.Sprintf("\n@XmlAccessorType(XmlAccessType.FIELD)\n@XmlAttribute(required = true, name = \"%s\")\npublic class %s {\n%s}\n", v.Name, genJavaFieldName(v.Name, true), gen.StructAST[v.Name])
+			gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
+			gen.Field += fmt.Sprintf("\n@XmlAccessorType(XmlAccessType.FIELD)\n@XmlAttribute(required = true, name = \"%s\")\npublic class %s {\n%s}\n", v.Name, genJavaFieldName(v.Name, true), gen.StructAST[toQName(v.TargetNamespace, v.Name)])
 			return
 		}
 	}
 	if v.Union && len(v.MemberTypes) > 0 {
-		if _, ok := gen.StructAST[v.Name]; !ok {
+		if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
 			content := " {\n"
 			for _, member := range toSortedPairs(v.MemberTypes) {
 				memberName := member.key
 				memberType := member.value
-
-				if memberType == "" { // fix order issue
-					memberType = getBasefromSimpleType(memberName, gen.ProtoTree)
-				}
 				fieldType := genJavaFieldType(memberType)
 				content += fmt.Sprintf("\t@XmlElement(required = true)\n\tprotected %s %s;\n", fieldType, genJavaFieldName(memberName, false))
 			}
 			content += "}\n"
-			gen.StructAST[v.Name] = content
+			gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
 			fieldName := genJavaFieldName(v.Name, true)
-			gen.Field += fmt.Sprintf("%spublic class %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+			gen.Field += fmt.Sprintf("%spublic class %s%s", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//")+genConstraintComment(v.Restriction.Assertions, nil, "//"), fieldName, gen.StructAST[toQName(v.TargetNamespace, v.Name)])
 		}
 		return
 	}
-	if _, ok := gen.StructAST[v.Name]; !ok {
-		fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
+		fieldType := genJavaFieldType(getBasefromSimpleType(toQName(v.BaseNamespace, v.Base), gen.ProtoTree))
 		content := fmt.Sprintf("\tprotected %s %s;\n", fieldType, genJavaFieldName(v.Name, false))
-		gen.StructAST[v.Name] = content
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
 		fieldName := genJavaFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%s@XmlAccessorType(XmlAccessType.FIELD)\n@XmlAttribute(required = true, name = \"%s\")\npublic class %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), v.Name, fieldName, gen.StructAST[v.Name])
+		gen.Field += fmt.Sprintf("%s@XmlAccessorType(XmlAccessType.FIELD)\n@XmlAttribute(required = true, name = \"%s\")\npublic class %s {\n%s}\n", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//")+genConstraintComment(v.Restriction.Assertions, nil, "//"), v.Name, fieldName, gen.StructAST[toQName(v.TargetNamespace, v.Name)])
 	}
 }
 
 // JavaComplexType generates code for complex type XML schema in Java language
 // syntax.
 func (gen *CodeGenerator) JavaComplexType(v *ComplexType) {
-	if _, ok := gen.StructAST[v.Name]; !ok {
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
 		content := " {\n"
 		for _, attrGroup := range v.AttributeGroup {
-			fieldType := getBasefromSimpleType(trimNSPrefix(attrGroup.Ref), gen.ProtoTree)
+			fieldType := getBasefromSimpleType(toQName(attrGroup.RefNamespace, attrGroup.Ref), gen.ProtoTree)
 			content += fmt.Sprintf("\t@XmlElement(required = true)\n\tprotected %s %s;\n", genJavaFieldType(fieldType), genJavaFieldName(attrGroup.Name, false))
 		}
 
@@ -153,42 +202,93 @@ func (gen *CodeGenerator) JavaComplexType(v *ComplexType) {
 			if attribute.Optional {
 				required = ""
 			}
-			fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree))
-			content += fmt.Sprintf("\t@XmlAttribute(name = \"%s\"%s)\n\tprotected %s %sAttr;\n", attribute.Name, required, fieldType, genJavaFieldName(attribute.Name, false))
+			fieldType := genJavaFieldType(getBasefromSimpleType(toQName(attribute.TypeNamespace, attribute.Type), gen.ProtoTree))
+			content += fmt.Sprintf("\t@XmlAttribute(name = \"%s\"%s)\n\tprotected %s %sAttr%s;\n", attribute.Name, required, fieldType, genJavaFieldName(attribute.Name, false), genJavaInitializer(fieldType, attribute.Default, attribute.Fixed))
 		}
-		for _, group := range v.Groups {
-			var fieldType = genJavaFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree))
-			if group.Plural {
-				fieldType = fmt.Sprintf("List<%s>", fieldType)
-			}
-			content += fmt.Sprintf("\tprotected %s %s;\n", fieldType, genJavaFieldName(group.Name, false))
+		if v.AnyAttribute != nil {
+			content += javaAnyAttribute
 		}
-
-		for _, element := range v.Elements {
-			fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree))
-			if element.Plural {
-				fieldType = fmt.Sprintf("List<%s>", fieldType)
+		if v.Mixed {
+			// The character data and elements of mixed content are held by
+			// the content in document order, which is inherited from a mixed
+			// base type
+			if !gen.mixedBase(v) {
+				content += javaMixedContent
+			}
+		} else {
+			if hasOpenContent(v) {
+				content += javaAnyElement
 			}
-			content += fmt.Sprintf("\t@XmlElement(required = true, name = \"%s\")\n\tprotected %s %s;\n", element.Name, fieldType, genJavaFieldName(element.Name, false))
+			content += gen.genJavaContent(v.Particle, v.Groups, v.Elements, gen.complexTypeUnion(v))
 		}
 
-		if len(v.Base) > 0 && isBuiltInJavaType(v.Base) {
-			fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))
+		base, baseNamespace := contentBase(v)
+		if len(base) > 0 && isBuiltInJavaType(base) && !v.Mixed {
+			fieldType := genJavaFieldType(getBasefromSimpleType(toQName(baseNamespace, base), gen.ProtoTree))
 			content += fmt.Sprintf("\t@XmlValue\n\tprotected %s value;\n", fieldType)
 		}
 
 		content += "}\n"
-		gen.StructAST[v.Name] = content
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
 		fieldName := genJavaFieldName(v.Name, true)
 
 		typeExtension := ""
-		if len(v.Base) > 0 && !isBuiltInJavaType(v.Base) {
-			fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))
+		if len(base) > 0 && !isBuiltInJavaType(base) {
+			fieldType := genJavaFieldType(getBasefromSimpleType(toQName(baseNamespace, base), gen.ProtoTree))
 			typeExtension = fmt.Sprintf(" extends %s ", fieldType)
 		}
 
-		gen.Field += fmt.Sprintf("%spublic class %s%s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, typeExtension, gen.StructAST[v.Name])
+		gen.Field += fmt.Sprintf("%spublic class %s%s%s", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//")+genConstraintComment(v.Assertions, nil, "//"), fieldName, typeExtension, gen.StructAST[toQName(v.TargetNamespace, v.Name)])
+	}
+}
+
+// genJavaContent returns the fields of the group references, elements and
+// union in the order they're declared by the particle tree. The union holds
+// the object of the alternative present, which is mapped by the element name.
+func (gen *CodeGenerator) genJavaContent(particle *Particle, groups []Group, elements []Element, union *Choice) (content string) {
+	for _, item := range orderedContent(particle, groups, elements, union) {
+		switch item := item.(type) {
+		case *Choice:
+			fieldType := "Object"
+			var alternatives []string
+			for _, e := range item.Elements {
+				if item.Plural || e.Plural {
+					fieldType = "List<Object>"
+				}
+				alternative := genJavaFieldType(getBasefromSimpleType(toQName(e.TypeNamespace, e.Type), gen.ProtoTree))
+				alternatives = append(alternatives, fmt.Sprintf("\t\t@XmlElement(name = \"%s\", type = %s.class)", e.Name, strings.SplitN(alternative, "<", 2)[0]))
+			}
+			content += fmt.Sprintf("\t@XmlElements({\n%s\n\t})\n\tprotected %s %s;\n", strings.Join(alternatives, ",\n"), fieldType, genJavaFieldName(item.Name, false))
+		case *Group:
+			var fieldType = genJavaFieldType(getBasefromSimpleType(toQName(item.RefNamespace, item.Ref), gen.ProtoTree))
+			if item.Plural {
+				fieldType = fmt.Sprintf("List<%s>", fieldType)
+			}
+			content += fmt.Sprintf("\tprotected %s %s;\n", fieldType, genJavaFieldName(item.Name, false))
+		case *Element:
+			if item.Wildcard != nil {
+				content += javaAnyElement
+				continue
+			}
+			if gen.substitutionGroup(item) != nil {
+				fieldType := "JAXBElement<?>"
+				if item.Plural {
+					fieldType = fmt.Sprintf("List<%s>", fieldType)
+				}
+				content += fmt.Sprintf("\t@XmlElementRef(name = \"%s\", type = JAXBElement.class)\n\tprotected %s %s;\n", item.Ref, fieldType, genJavaFieldName(item.Name, false))
+				continue
+			}
+			fieldType := genJavaFieldType(getBasefromSimpleType(toQName(item.TypeNamespace, item.Type), gen.ProtoTree))
+			var initializer string
+			if item.Plural {
+				fieldType = fmt.Sprintf("List<%s>", fieldType)
+			} else {
+				initializer = genJavaInitializer(fieldType, item.Default, item.Fixed)
+			}
+			content += fmt.Sprintf("\t@XmlElement(%sname = \"%s\")\n\tprotected %s %s%s;\n", genJavaRequired(item.Optional), item.Name, fieldType, genJavaFieldName(item.Name, false), initializer)
+		}
 	}
+	return
 }
 
 func isBuiltInJavaType(typeName string) bool {
@@ -198,73 +298,61 @@ func isBuiltInJavaType(typeName string) bool {
 
 // JavaGroup generates code for group XML schema in Java language syntax.
 func (gen *CodeGenerator) JavaGroup(v *Group) {
-	if _, ok := gen.StructAST[v.Name]; !ok {
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
 		content := " {\n"
-		for _, element := range v.Elements {
-			var fieldType = genJavaFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree))
-			if element.Plural {
-				fieldType = fmt.Sprintf("List<%s>", fieldType)
-			}
-			content += fmt.Sprintf("\t@XmlElement(required = true, name = \"%s\")\n\tprotected %s %s;\n", element.Name, fieldType, genJavaFieldName(element.Name, false))
-		}
-
-		for _, group := range v.Groups {
-			var fieldType = genJavaFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree))
-			if group.Plural {
-				fieldType = fmt.Sprintf("List<%s>", fieldType)
-			}
-			content += fmt.Sprintf("\tprotected %s %s;\n", fieldType, genJavaFieldName(group.Name, false))
-		}
-
+		content += gen.genJavaContent(v.Particle, v.Groups, v.Elements, gen.unionChoice(v.Particle, v.Elements, v.Choice))
 		content += "}\n"
-		gen.StructAST[v.Name] = content
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
 		fieldName := genJavaFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%spublic class %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		gen.Field += fmt.Sprintf("%spublic class %s%s", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//"), fieldName, gen.StructAST[toQName(v.TargetNamespace, v.Name)])
 	}
 }
 
 // JavaAttributeGroup generates code for attribute group XML schema in Java language
 // syntax.
 func (gen *CodeGenerator) JavaAttributeGroup(v *AttributeGroup) {
-	if _, ok := gen.StructAST[v.Name]; !ok {
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
 		content := " {\n"
 		for _, attribute := range v.Attributes {
 			var required = ", required = true"
 			if attribute.Optional {
 				required = ""
 			}
-			fieldType := genJavaFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree))
-			content += fmt.Sprintf("\t@XmlAttribute(name = \"%s\"%s)\n\tprotected %sAttr %s;\n", attribute.Name, required, fieldType, genJavaFieldName(attribute.Name, false))
+			fieldType := genJavaFieldType(getBasefromSimpleType(toQName(attribute.TypeNamespace, attribute.Type), gen.ProtoTree))
+			content += fmt.Sprintf("\t@XmlAttribute(name = \"%s\"%s)\n\tprotected %sAttr %s%s;\n", attribute.Name, required, fieldType, genJavaFieldName(attribute.Name, false), genJavaInitializer(fieldType, attribute.Default, attribute.Fixed))
+		}
+		if v.AnyAttribute != nil {
+			content += javaAnyAttribute
 		}
 		content += "}\n"
-		gen.StructAST[v.Name] = content
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
 		fieldName := genJavaFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%spublic class %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		gen.Field += fmt.Sprintf("%spublic class %s%s", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//"), fieldName, gen.StructAST[toQName(v.TargetNamespace, v.Name)])
 	}
 }
 
 // JavaElement generates code for element XML schema in Java language syntax.
 func (gen *CodeGenerator) JavaElement(v *Element) {
-	if _, ok := gen.StructAST[v.Name]; !ok {
-		var fieldType = genJavaFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree))
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
+		var fieldType = genJavaFieldType(getBasefromSimpleType(toQName(v.TypeNamespace, v.Type), gen.ProtoTree))
 		if v.Plural {
 			fieldType = fmt.Sprintf("List<%s>", fieldType)
 		}
 		content := fmt.Sprintf("\tprotected %s %s;\n", fieldType, genJavaFieldName(v.Name, false))
-		gen.StructAST[v.Name] = content
-		gen.Field += fmt.Sprintf("\n@XmlAccessorType(XmlAccessType.FIELD)\n@XmlElement(required = true, name = \"%s\")\npublic class %s {\n%s}\n", v.Name, genJavaFieldName(v.Name, true), gen.StructAST[v.Name])
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
+		gen.Field += fmt.Sprintf("\n@XmlAccessorType(XmlAccessType.FIELD)\n@XmlElement(required = true, name = \"%s\")\npublic class %s {\n%s}\n", v.Name, genJavaFieldName(v.Name, true), gen.StructAST[toQName(v.TargetNamespace, v.Name)])
 	}
 }
 
 // JavaAttribute generates code for attribute XML schema in Java language syntax.
 func (gen *CodeGenerator) JavaAttribute(v *Attribute) {
-	if _, ok := gen.StructAST[v.Name]; !ok {
-		var fieldType = genJavaFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree))
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
+		var fieldType = genJavaFieldType(getBasefromSimpleType(toQName(v.TypeNamespace, v.Type), gen.ProtoTree))
 		if v.Plural {
 			fieldType = fmt.Sprintf("List<%s>", fieldType)
 		}
 		content := fmt.Sprintf("\tprotected %s %s;\n", fieldType, genJavaFieldName(v.Name, false))
-		gen.StructAST[v.Name] = content
-		gen.Field += fmt.Sprintf("\n@XmlAccessorType(XmlAccessType.FIELD)\n@XmlAttribute(required = true, name = \"%s\")\npublic class %s {\n%s}\n", v.Name, genJavaFieldName(v.Name, true), gen.StructAST[v.Name])
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
+		gen.Field += fmt.Sprintf("\n@XmlAccessorType(XmlAccessType.FIELD)\n@XmlAttribute(required = true, name = \"%s\")\npublic class %s {\n%s}\n", v.Name, genJavaFieldName(v.Name, true), gen.StructAST[toQName(v.TargetNamespace, v.Name)])
 	}
 }
@@ -11,7 +11,7 @@ package xgen
 import (
 	"fmt"
 	"os"
-	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -93,17 +93,22 @@ var (
 	}
 )
 
+// Fields capturing the content matched by element and attribute wildcards
+// for Rust language. The flattened maps receive the same unmatched entries,
+// so a type with both wildcards only has the field of the element wildcard.
+const (
+	rustAnyElement   = "\t#[serde(flatten)]\n\tpub any: std::collections::HashMap<String, String>,\n"
+	rustAnyAttribute = "\t#[serde(flatten)]\n\tpub any_attr: std::collections::HashMap<String, String>,\n"
+)
+
 // GenRust generate Go programming language source code for XML schema
 // definition files.
 func (gen *CodeGenerator) GenRust() error {
 	fieldNameCount = make(map[string]int)
-	for _, ele := range gen.ProtoTree {
-		if ele == nil {
-			continue
-		}
-		funcName := fmt.Sprintf("Rust%s", reflect.TypeOf(ele).String()[6:])
-		callFuncByName(gen, funcName, []reflect.Value{reflect.ValueOf(ele)})
+	if err := gen.genProtoTree(gen.RustSimpleType, gen.RustComplexType, gen.RustGroup, gen.RustAttributeGroup, gen.RustElement, gen.RustAttribute); err != nil {
+		return err
 	}
+	gen.genSubstitutionGroups(gen.RustSubstitutionGroup)
 	f, err := os.Create(gen.File + ".rs")
 	if err != nil {
 		return err
@@ -169,98 +174,208 @@ func genRustFieldType(name string) string {
 	return "char"
 }
 
+// genRustOccurs wraps the field type for the optional or repeated fields.
+func genRustOccurs(fieldType string, optional, plural bool) string {
+	if plural {
+		return fmt.Sprintf("Vec<%s>", fieldType)
+	}
+	if optional {
+		return fmt.Sprintf("Option<%s>", fieldType)
+	}
+	return fieldType
+}
+
 // RustSimpleType generates code for simple type XML schema in Rust language
 // syntax.
 func (gen *CodeGenerator) RustSimpleType(v *SimpleType) {
 	if v.List {
-		if _, ok := gen.StructAST[v.Name]; !ok {
-			fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))
+		if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
+			fieldType := genRustFieldType(getBasefromSimpleType(toQName(v.BaseNamespace, v.Base), gen.ProtoTree))
 			content := fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", v.Name, genRustFieldName(v.Name), fieldType)
-			gen.StructAST[v.Name] = content
+			gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
 			fieldName := genRustStructName(v.Name, true)
-			gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+			gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//")+genConstraintComment(v.Restriction.Assertions, nil, "//"), fieldName, gen.StructAST[toQName(v.TargetNamespace, v.Name)])
 			return
 		}
 	}
 	if v.Union && len(v.MemberTypes) > 0 {
-		if _, ok := gen.StructAST[v.Name]; !ok {
+		if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
 			var content string
 			for _, member := range toSortedPairs(v.MemberTypes) {
 				memberName := member.key
 				memberType := member.value
-
-				if memberType == "" { // fix order issue
-					memberType = getBasefromSimpleType(memberName, gen.ProtoTree)
-				}
 				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: %s,\n", v.Name, genRustFieldName(memberName), genRustFieldType(memberType))
 			}
-			gen.StructAST[v.Name] = content
-			gen.Field += fmt.Sprintf("\n#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genRustStructName(v.Name, true), gen.StructAST[v.Name])
+			gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
+			gen.Field += fmt.Sprintf("\n#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genRustStructName(v.Name, true), gen.StructAST[toQName(v.TargetNamespace, v.Name)])
 		}
 		return
 	}
-	if _, ok := gen.StructAST[v.Name]; !ok {
-		fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
+		fieldType := genRustFieldType(getBasefromSimpleType(toQName(v.BaseNamespace, v.Base), gen.ProtoTree))
 		content := fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: %s,\n", v.Name, genRustFieldName(v.Name), fieldType)
-		gen.StructAST[v.Name] = content
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
 		fieldName := genRustStructName(v.Name, true)
-		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//")+genConstraintComment(v.Restriction.Assertions, nil, "//"), fieldName, gen.StructAST[toQName(v.TargetNamespace, v.Name)])
 	}
 }
 
 // RustComplexType generates code for complex type XML schema in Rust language
 // syntax.
 func (gen *CodeGenerator) RustComplexType(v *ComplexType) {
-	if _, ok := gen.StructAST[v.Name]; !ok {
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
 		var content string
+		fieldName := genRustStructName(v.Name, true)
 		for _, attrGroup := range v.AttributeGroup {
-			fieldType := getBasefromSimpleType(trimNSPrefix(attrGroup.Ref), gen.ProtoTree)
+			fieldType := getBasefromSimpleType(toQName(attrGroup.RefNamespace, attrGroup.Ref), gen.ProtoTree)
 			content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", attrGroup.Name, genRustFieldName(attrGroup.Name), genRustFieldType(fieldType))
 		}
+		var defaults string
 		for _, attribute := range v.Attributes {
-			fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree))
-			if attribute.Optional {
+			fieldType := genRustFieldType(getBasefromSimpleType(toQName(attribute.TypeNamespace, attribute.Type), gen.ProtoTree))
+			defaultAttr, function := genRustDefault(fieldName, genRustFieldName(attribute.Name), fieldType, attribute.Default, attribute.Fixed)
+			defaults += function
+			if attribute.Optional && function == "" {
 				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Option<%s>,\n", attribute.Name, genRustFieldName(attribute.Name), fieldType)
 			} else {
-				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: %s,\n", attribute.Name, genRustFieldName(attribute.Name), fieldType)
+				content += fmt.Sprintf("\t#[serde(rename = \"%s\"%s)]\n\tpub %s: %s,\n", attribute.Name, defaultAttr, genRustFieldName(attribute.Name), fieldType)
 			}
 		}
-		for _, group := range v.Groups {
-			fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree))
-			fieldName := genRustFieldName(group.Name)
-			if group.Plural {
-				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", group.Name, fieldName, fieldType)
-			} else {
-				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: %s,\n", group.Name, fieldName, fieldType)
-			}
+		wildcard, _ := findWildcard(v.Elements)
+		anyElement := !v.Mixed && (hasOpenContent(v) || wildcard != nil)
+		if v.AnyAttribute != nil && !anyElement {
+			content += rustAnyAttribute
 		}
-		for _, element := range v.Elements {
-			fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree))
-			fieldName := genRustFieldName(element.Name)
-			if element.Plural {
-				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", element.Name, fieldName, fieldType)
-			} else {
-				if element.Optional {
-					content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Option<%s>,\n", element.Name, fieldName, fieldType)
-				} else {
-					content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: %s,\n", element.Name, fieldName, fieldType)
-				}
+		if hasOpenContent(v) && !v.Mixed {
+			content += rustAnyElement
+		}
+		base, baseNamespace := contentBase(v)
+		if len(base) > 0 && !isRustBuiltInType(base) {
+			fieldType := genRustFieldType(getBasefromSimpleType(toQName(baseNamespace, base), gen.ProtoTree))
+			// If the type is not a built-in one, add the base type as a nested field tagged with
+			// flatten, the content of the base type precedes the content of the extension
+			content += fmt.Sprintf("\t#[serde(flatten)]\n\tpub %s: %s,\n", genRustFieldName(fieldType), fieldType)
+		}
+		var union string
+		if v.Mixed {
+			// The character data and elements of mixed content are held by
+			// the content in document order, which is inherited from a mixed
+			// base type
+			if !gen.mixedBase(v) {
+				var field string
+				field, union = gen.genRustMixedContent(fieldName, v.Elements)
+				content += field
 			}
+		} else {
+			var fields string
+			fields, union = gen.genRustContent(fieldName, v.Particle, v.Groups, v.Elements, gen.complexTypeUnion(v))
+			content += fields
 		}
-		if len(v.Base) > 0 {
-			fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree))
-			if isRustBuiltInType(v.Base) {
-				content += fmt.Sprintf("\t#[serde(rename = \"$value\")]\n\tpub value: %s,\n", fieldType)
-			} else {
-				fieldName := genRustFieldName(fieldType)
-				// If the type is not a built-in one, add the base type as a nested field tagged with flatten
-				content += fmt.Sprintf("\t#[serde(flatten)]\n\tpub %s: %s,\n", fieldName, fieldType)
+		if len(base) > 0 && isRustBuiltInType(base) && !v.Mixed {
+			fieldType := genRustFieldType(getBasefromSimpleType(toQName(baseNamespace, base), gen.ProtoTree))
+			content += fmt.Sprintf("\t#[serde(rename = \"$value\")]\n\tpub value: %s,\n", fieldType)
+		}
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
+		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//")+genConstraintComment(v.Assertions, nil, "//"), fieldName, gen.StructAST[toQName(v.TargetNamespace, v.Name)])
+		gen.Field += defaults + union
+	}
+}
+
+// genRustContent returns the fields of the group references, elements and
+// union in the order they're declared by the particle tree, and the
+// declaration of the union. The alternatives of the union are the variants
+// of the enum tagged by the element name.
+func (gen *CodeGenerator) genRustContent(owner string, particle *Particle, groups []Group, elements []Element, union *Choice) (content, declaration string) {
+	for _, item := range orderedContent(particle, groups, elements, union) {
+		switch item := item.(type) {
+		case *Choice:
+			typeName, plural := owner+genRustStructName(item.Name, false), item.Plural
+			var variants string
+			for _, e := range item.Elements {
+				plural = plural || e.Plural
+				fieldType := genRustFieldType(getBasefromSimpleType(toQName(e.TypeNamespace, e.Type), gen.ProtoTree))
+				variants += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\t%s(%s),\n", e.Name, genRustStructName(e.Name, false), fieldType)
+			}
+			content += fmt.Sprintf("\t#[serde(rename = \"$value\")]\n\tpub %s: %s,\n", genRustFieldName(item.Name), genRustOccurs(typeName, item.Optional, plural))
+			declaration += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub enum %s {\n%s}\n", genFieldComment(typeName, fmt.Sprintf("the choice of the %s element.", choiceAlternatives(item)), "//"), typeName, variants)
+		case *Group:
+			fieldType := genRustFieldType(getBasefromSimpleType(toQName(item.RefNamespace, item.Ref), gen.ProtoTree))
+			content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: %s,\n", item.Name, genRustFieldName(item.Name), genRustOccurs(fieldType, item.Optional, item.Plural))
+		case *Element:
+			if item.Wildcard != nil {
+				content += rustAnyElement
+				continue
+			}
+			if gen.substitutionGroup(item) != nil {
+				fieldType := genRustStructName(item.Ref, false) + "Substitution"
+				content += fmt.Sprintf("\t#[serde(rename = \"$value\")]\n\tpub %s: %s,\n", genRustFieldName(item.Name), genRustOccurs(fieldType, item.Optional, item.Plural))
+				continue
+			}
+			fieldType := genRustFieldType(getBasefromSimpleType(toQName(item.TypeNamespace, item.Type), gen.ProtoTree))
+			var defaultAttr, function string
+			if !item.Plural {
+				defaultAttr, function = genRustDefault(owner, genRustFieldName(item.Name), fieldType, item.Default, item.Fixed)
+				declaration += function
 			}
+			content += fmt.Sprintf("\t#[serde(rename = \"%s\"%s)]\n\tpub %s: %s,\n", item.Name, defaultAttr, genRustFieldName(item.Name), genRustOccurs(fieldType, item.Optional && function == "", item.Plural))
 		}
-		gen.StructAST[v.Name] = content
-		fieldName := genRustStructName(v.Name, true)
-		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
 	}
+	return
+}
+
+// genRustDefault returns the serde default of the field of an element or
+// attribute with the default or fixed value, and the declaration of the
+// function returning the value. The field with a default is not optional.
+func genRustDefault(owner, field, fieldType, defaultValue, fixed string) (attribute, function string) {
+	if defaultValue == "" && fixed == "" {
+		return
+	}
+	literal, ok := genRustLiteral(fieldType, valueConstraint(defaultValue, fixed))
+	if !ok {
+		return
+	}
+	name := fmt.Sprintf("default_%s_%s", ToSnakeCase(owner), ToSnakeCase(field))
+	attribute = fmt.Sprintf(", default = \"%s\"", name)
+	function = fmt.Sprintf("\nfn %s() -> %s {\n\t%s\n}\n", name, fieldType, literal)
+	return
+}
+
+// genRustLiteral returns the Rust literal of the value for the built-in field
+// type.
+func genRustLiteral(fieldType, value string) (literal string, ok bool) {
+	switch fieldType {
+	case "String":
+		return strconv.Quote(value) + ".to_string()", true
+	case "bool":
+		return genBooleanLiteral(value)
+	case "f32", "f64":
+		if literal, ok = genFloatLiteral(value); ok && !strings.ContainsAny(literal, ".e") {
+			literal += ".0"
+		}
+		return
+	case "i8", "i16", "i32", "i64", "u8", "u16", "u32", "u64":
+		bitSize, _ := strconv.Atoi(fieldType[1:])
+		return genIntegerLiteral(value, bitSize, fieldType[0] == 'u')
+	}
+	return
+}
+
+// genRustMixedContent returns the field holding the character data and the
+// elements of mixed content in document order, and the declaration of the
+// enum of the character data and the elements tagged by the element name.
+func (gen *CodeGenerator) genRustMixedContent(owner string, elements []Element) (field, declaration string) {
+	typeName := owner + "Content"
+	variants := "\t#[serde(rename = \"$value\")]\n\tText(String),\n"
+	for _, e := range elements {
+		if e.Wildcard != nil {
+			continue
+		}
+		fieldType := genRustFieldType(getBasefromSimpleType(toQName(e.TypeNamespace, e.Type), gen.ProtoTree))
+		variants += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\t%s(%s),\n", e.Name, genRustStructName(e.Name, false), fieldType)
+	}
+	field = fmt.Sprintf("\t#[serde(rename = \"$value\")]\n\tpub content: Vec<%s>,\n", typeName)
+	declaration = fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub enum %s {\n%s}\n", genFieldComment(typeName, "the character data and the elements of mixed content.", "//"), typeName, variants)
+	return
 }
 
 func isRustBuiltInType(typeName string) bool {
@@ -270,74 +385,73 @@ func isRustBuiltInType(typeName string) bool {
 
 // RustGroup generates code for group XML schema in Rust language syntax.
 func (gen *CodeGenerator) RustGroup(v *Group) {
-	if _, ok := gen.StructAST[v.Name]; !ok {
-		var content string
-		for _, element := range v.Elements {
-			fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree))
-			fieldName := genRustFieldName(element.Name)
-			if v.Plural {
-				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", element.Name, fieldName, fieldType)
-			} else {
-				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: %s,\n", element.Name, fieldName, fieldType)
-			}
-		}
-		for _, group := range v.Groups {
-			fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree))
-			fieldName := genRustFieldName(group.Name)
-			if v.Plural {
-				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", group.Name, fieldName, fieldType)
-			} else {
-				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: %s,\n", group.Name, fieldName, fieldType)
-			}
-		}
-		gen.StructAST[v.Name] = content
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
 		fieldName := genRustStructName(v.Name, true)
-		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		content, union := gen.genRustContent(fieldName, v.Particle, v.Groups, v.Elements, gen.unionChoice(v.Particle, v.Elements, v.Choice))
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
+		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//"), fieldName, gen.StructAST[toQName(v.TargetNamespace, v.Name)])
+		gen.Field += union
 	}
 }
 
 // RustAttributeGroup generates code for attribute group XML schema in Rust language
 // syntax.
 func (gen *CodeGenerator) RustAttributeGroup(v *AttributeGroup) {
-	if _, ok := gen.StructAST[v.Name]; !ok {
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
 		var content string
 		for _, attribute := range v.Attributes {
 			if attribute.Optional {
-				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Option<%s>,\n", attribute.Name, genRustFieldName(attribute.Name), genRustFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree)))
+				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Option<%s>,\n", attribute.Name, genRustFieldName(attribute.Name), genRustFieldType(getBasefromSimpleType(toQName(attribute.TypeNamespace, attribute.Type), gen.ProtoTree)))
 			} else {
-				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", attribute.Name, genRustFieldName(attribute.Name), genRustFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree)))
+				content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", attribute.Name, genRustFieldName(attribute.Name), genRustFieldType(getBasefromSimpleType(toQName(attribute.TypeNamespace, attribute.Type), gen.ProtoTree)))
 			}
 		}
-		gen.StructAST[v.Name] = content
+		if v.AnyAttribute != nil {
+			content += rustAnyAttribute
+		}
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
 		fieldName := genRustStructName(v.Name, true)
-		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//"), fieldName, gen.StructAST[toQName(v.TargetNamespace, v.Name)])
 	}
 }
 
 // RustElement generates code for element XML schema in Rust language syntax.
 func (gen *CodeGenerator) RustElement(v *Element) {
-	if _, ok := gen.StructAST[v.Name]; !ok {
-		fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree))
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
+		fieldType := genRustFieldType(getBasefromSimpleType(toQName(v.TypeNamespace, v.Type), gen.ProtoTree))
 		fieldName := genRustFieldName(v.Name)
 		if v.Plural {
-			gen.StructAST[v.Name] = fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", v.Name, fieldName, fieldType)
+			gen.StructAST[toQName(v.TargetNamespace, v.Name)] = fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", v.Name, fieldName, fieldType)
 		} else {
-			gen.StructAST[v.Name] = fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: %s,\n", v.Name, fieldName, fieldType)
+			gen.StructAST[toQName(v.TargetNamespace, v.Name)] = fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: %s,\n", v.Name, fieldName, fieldType)
 		}
-		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//")+genConstraintComment(nil, v.Alternatives, "//"), fieldName, gen.StructAST[toQName(v.TargetNamespace, v.Name)])
 	}
 }
 
 // RustAttribute generates code for attribute XML schema in Rust language syntax.
 func (gen *CodeGenerator) RustAttribute(v *Attribute) {
-	if _, ok := gen.StructAST[v.Name]; !ok {
-		fieldType := genRustFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree))
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
+		fieldType := genRustFieldType(getBasefromSimpleType(toQName(v.TypeNamespace, v.Type), gen.ProtoTree))
 		fieldName := genRustFieldName(v.Name)
 		if v.Plural {
-			gen.StructAST[v.Name] = fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", v.Name, fieldName, fieldType)
+			gen.StructAST[toQName(v.TargetNamespace, v.Name)] = fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: Vec<%s>,\n", v.Name, fieldName, fieldType)
 		} else {
-			gen.StructAST[v.Name] = fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: %s,\n", v.Name, fieldName, fieldType)
+			gen.StructAST[toQName(v.TargetNamespace, v.Name)] = fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\tpub %s: %s,\n", v.Name, fieldName, fieldType)
 		}
-		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub struct %s {\n%s}\n", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//"), fieldName, gen.StructAST[toQName(v.TargetNamespace, v.Name)])
+	}
+}
+
+// RustSubstitutionGroup generates code for substitution group XML schema in
+// Rust language syntax. The elements of the group are the variants of the
+// enum tagged by the element name.
+func (gen *CodeGenerator) RustSubstitutionGroup(v *SubstitutionGroup) {
+	var content string
+	for _, e := range v.Elements {
+		fieldType := genRustFieldType(getBasefromSimpleType(toQName(e.TypeNamespace, e.Type), gen.ProtoTree))
+		content += fmt.Sprintf("\t#[serde(rename = \"%s\")]\n\t%s(%s),\n", e.Name, genRustStructName(e.Name, false), fieldType)
 	}
+	typeName := genRustStructName(v.Head.Name, false) + "Substitution"
+	gen.Field += fmt.Sprintf("\n%s#[derive(Debug, Deserialize, Serialize, PartialEq)]\npub enum %s {\n%s}\n", genFieldComment(typeName, fmt.Sprintf("the substitution group of the %s element.", v.Head.Name), "//"), typeName, content)
 }
@@ -11,7 +11,7 @@ package xgen
 import (
 	"fmt"
 	"os"
-	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -25,17 +25,21 @@ var typeScriptBuildInType = map[string]bool{
 	"Uint8Array": true,
 }
 
+// Fields capturing the content matched by element and attribute wildcards
+// for TypeScript language.
+const (
+	typeScriptAnyElement   = "\tAny: Array<string>;\n"
+	typeScriptAnyAttribute = "\tAnyAttr: Record<string, string>;\n"
+)
+
 // GenTypeScript generate TypeScript programming language source code for XML
 // schema definition files.
 func (gen *CodeGenerator) GenTypeScript() error {
 	fieldNameCount = make(map[string]int)
-	for _, ele := range gen.ProtoTree {
-		if ele == nil {
-			continue
-		}
-		funcName := fmt.Sprintf("TypeScript%s", reflect.TypeOf(ele).String()[6:])
-		callFuncByName(gen, funcName, []reflect.Value{reflect.ValueOf(ele)})
+	if err := gen.genProtoTree(gen.TypeScriptSimpleType, gen.TypeScriptComplexType, gen.TypeScriptGroup, gen.TypeScriptAttributeGroup, gen.TypeScriptElement, gen.TypeScriptAttribute); err != nil {
+		return err
 	}
+	gen.genSubstitutionGroups(gen.TypeScriptSubstitutionGroup)
 	f, err := os.Create(gen.File + ".ts")
 	if err != nil {
 		return err
@@ -84,41 +88,68 @@ func genTypeScriptFieldType(name string, plural bool) (fieldType string) {
 	return
 }
 
+// genTypeScriptOptional returns the type suffix for the optional and not
+// repeated fields.
+func genTypeScriptOptional(optional, plural bool) string {
+	if optional && !plural {
+		return " | null"
+	}
+	return ""
+}
+
+// genTypeScriptInitializer returns the initializer of the property of an
+// element or attribute with the default or fixed value.
+func genTypeScriptInitializer(fieldType, defaultValue, fixed string) string {
+	if defaultValue == "" && fixed == "" {
+		return ""
+	}
+	value, ok := valueConstraint(defaultValue, fixed), false
+	var literal string
+	switch fieldType {
+	case "string":
+		literal, ok = strconv.Quote(value), true
+	case "boolean":
+		literal, ok = genBooleanLiteral(value)
+	case "number":
+		literal, ok = genFloatLiteral(value)
+	}
+	if !ok {
+		return ""
+	}
+	return " = " + literal
+}
+
 // TypeScriptSimpleType generates code for simple type XML schema in TypeScript language
 // syntax.
 func (gen *CodeGenerator) TypeScriptSimpleType(v *SimpleType) {
 	if v.List {
-		if _, ok := gen.StructAST[v.Name]; !ok {
-			fieldType := genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree), true)
+		if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
+			fieldType := genTypeScriptFieldType(getBasefromSimpleType(toQName(v.BaseNamespace, v.Base), gen.ProtoTree), true)
 			content := fmt.Sprintf(" = %s;\n", fieldType)
-			gen.StructAST[v.Name] = content
+			gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
 			fieldName := genTypeScriptFieldName(v.Name, true)
-			gen.Field += fmt.Sprintf("%sexport type %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+			gen.Field += fmt.Sprintf("%sexport type %s%s", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//")+genConstraintComment(v.Restriction.Assertions, nil, "//"), fieldName, gen.StructAST[toQName(v.TargetNamespace, v.Name)])
 			return
 		}
 	}
 	if v.Union && len(v.MemberTypes) > 0 {
-		if _, ok := gen.StructAST[v.Name]; !ok {
+		if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
 			content := " {\n"
 			for _, member := range toSortedPairs(v.MemberTypes) {
 				memberName := member.key
 				memberType := member.value
-
-				if memberType == "" { // fix order issue
-					memberType = getBasefromSimpleType(memberName, gen.ProtoTree)
-				}
 				content += fmt.Sprintf("\t%s: %s;\n", genTypeScriptFieldName(memberName, false), genTypeScriptFieldType(memberType, false))
 			}
 			content += "}\n"
-			gen.StructAST[v.Name] = content
+			gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
 			fieldName := genTypeScriptFieldName(v.Name, true)
-			gen.Field += fmt.Sprintf("%sexport class %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+			gen.Field += fmt.Sprintf("%sexport class %s%s", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//")+genConstraintComment(v.Restriction.Assertions, nil, "//"), fieldName, gen.StructAST[toQName(v.TargetNamespace, v.Name)])
 		}
 		return
 	}
 	if len(v.Restriction.Enum) > 0 {
 		var content string
-		baseType := genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree), false)
+		baseType := genTypeScriptFieldType(getBasefromSimpleType(toQName(v.BaseNamespace, v.Base), gen.ProtoTree), false)
 		for _, enum := range v.Restriction.Enum {
 			switch baseType {
 			case "string":
@@ -130,24 +161,25 @@ func (gen *CodeGenerator) TypeScriptSimpleType(v *SimpleType) {
 			}
 		}
 		fieldName := genTypeScriptFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%sexport enum %s {\n%s}\n", genFieldComment(fieldName, v.Doc, "//"), fieldName, content)
+		gen.Field += fmt.Sprintf("%sexport enum %s {\n%s}\n", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//")+genConstraintComment(v.Restriction.Assertions, nil, "//"), fieldName, content)
 		return
 	}
-	if _, ok := gen.StructAST[v.Name]; !ok {
-		content := fmt.Sprintf(" %s;\n", genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree), false))
-		gen.StructAST[v.Name] = content
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
+		content := fmt.Sprintf(" %s;\n", genTypeScriptFieldType(getBasefromSimpleType(toQName(v.BaseNamespace, v.Base), gen.ProtoTree), false))
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
 		fieldName := genTypeScriptFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%sexport type %s =%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		gen.Field += fmt.Sprintf("%sexport type %s =%s", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//")+genConstraintComment(v.Restriction.Assertions, nil, "//"), fieldName, gen.StructAST[toQName(v.TargetNamespace, v.Name)])
 	}
 }
 
 // TypeScriptComplexType generates code for complex type XML schema in TypeScript language
 // syntax.
 func (gen *CodeGenerator) TypeScriptComplexType(v *ComplexType) {
-	if _, ok := gen.StructAST[v.Name]; !ok {
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
 		content := " {\n"
+		fieldName := genTypeScriptFieldName(v.Name, true)
 		for _, attrGroup := range v.AttributeGroup {
-			fieldType := getBasefromSimpleType(trimNSPrefix(attrGroup.Ref), gen.ProtoTree)
+			fieldType := getBasefromSimpleType(toQName(attrGroup.RefNamespace, attrGroup.Ref), gen.ProtoTree)
 			content += fmt.Sprintf("\t%s: %s;\n", genTypeScriptFieldName(attrGroup.Name, false), genTypeScriptFieldType(fieldType, false))
 		}
 
@@ -156,34 +188,95 @@ func (gen *CodeGenerator) TypeScriptComplexType(v *ComplexType) {
 			if attribute.Optional {
 				optional = ` | null`
 			}
-			fieldType := genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree), attribute.Plural)
-			content += fmt.Sprintf("\t%sAttr: %s%s;\n", genTypeScriptFieldName(attribute.Name, false), fieldType, optional)
+			fieldType := genTypeScriptFieldType(getBasefromSimpleType(toQName(attribute.TypeNamespace, attribute.Type), gen.ProtoTree), attribute.Plural)
+			content += fmt.Sprintf("\t%sAttr: %s%s%s;\n", genTypeScriptFieldName(attribute.Name, false), fieldType, optional, genTypeScriptInitializer(fieldType, attribute.Default, attribute.Fixed))
 		}
-		for _, group := range v.Groups {
-			content += fmt.Sprintf("\t%s: %s;\n", genTypeScriptFieldName(group.Name, false), genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree), group.Plural))
+		if v.AnyAttribute != nil {
+			content += typeScriptAnyAttribute
 		}
-
-		for _, element := range v.Elements {
-			fieldType := genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree), element.Plural)
-			content += fmt.Sprintf("\t%s: %s;\n", genTypeScriptFieldName(element.Name, false), fieldType)
+		var union string
+		if v.Mixed {
+			// The character data and elements of mixed content are held by
+			// the Content in document order, which is inherited from a mixed
+			// base type
+			if !gen.mixedBase(v) {
+				content += gen.genTypeScriptMixedContent(v.Elements)
+			}
+		} else {
+			if hasOpenContent(v) {
+				content += typeScriptAnyElement
+			}
+			var fields string
+			fields, union = gen.genTypeScriptContent(fieldName, v.Particle, v.Groups, v.Elements, gen.complexTypeUnion(v))
+			content += fields
 		}
 
-		if len(v.Base) > 0 && isBuiltInTypeScriptType(v.Base) {
-			fieldType := genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree), false)
+		base, baseNamespace := contentBase(v)
+		if len(base) > 0 && isBuiltInTypeScriptType(base) && !v.Mixed {
+			fieldType := genTypeScriptFieldType(getBasefromSimpleType(toQName(baseNamespace, base), gen.ProtoTree), false)
 			content += fmt.Sprintf("\tValue: %s;\n", fieldType)
 		}
 		content += "}\n"
-		gen.StructAST[v.Name] = content
-		fieldName := genTypeScriptFieldName(v.Name, true)
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
 		typeExtension := ""
-		if len(v.Base) > 0 && !isBuiltInTypeScriptType(v.Base) {
-			fieldType := genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(v.Base), gen.ProtoTree), false)
+		if len(base) > 0 && !isBuiltInTypeScriptType(base) {
+			fieldType := genTypeScriptFieldType(getBasefromSimpleType(toQName(baseNamespace, base), gen.ProtoTree), false)
 			content += fmt.Sprintf("\tValue: %s;\n", fieldType)
 			typeExtension = fmt.Sprintf(" extends %s ", fieldType)
 		}
 
-		gen.Field += fmt.Sprintf("%sexport class %s%s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, typeExtension, gen.StructAST[v.Name])
+		gen.Field += fmt.Sprintf("%sexport class %s%s%s", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//")+genConstraintComment(v.Assertions, nil, "//"), fieldName, typeExtension, gen.StructAST[toQName(v.TargetNamespace, v.Name)])
+		gen.Field += union
+	}
+}
+
+// genTypeScriptContent returns the fields of the group references, elements
+// and union in the order they're declared by the particle tree, and the
+// declaration of the union. The alternatives of the union are discriminated
+// by the element name.
+func (gen *CodeGenerator) genTypeScriptContent(owner string, particle *Particle, groups []Group, elements []Element, union *Choice) (content, declaration string) {
+	for _, item := range orderedContent(particle, groups, elements, union) {
+		switch item := item.(type) {
+		case *Choice:
+			typeName, plural := owner+genTypeScriptFieldName(item.Name, false), item.Plural
+			var members []string
+			for _, e := range item.Elements {
+				plural = plural || e.Plural
+				members = append(members, fmt.Sprintf("{ %s: %s }", e.Name, genTypeScriptFieldType(getBasefromSimpleType(toQName(e.TypeNamespace, e.Type), gen.ProtoTree), false)))
+			}
+			content += fmt.Sprintf("\t%s: %s%s;\n", genTypeScriptFieldName(item.Name, false), genTypeScriptFieldType(typeName, plural), genTypeScriptOptional(item.Optional, plural))
+			declaration = fmt.Sprintf("%sexport type %s = %s;\n", genFieldComment(typeName, fmt.Sprintf("the choice of the %s element.", choiceAlternatives(item)), "//"), typeName, strings.Join(members, " | "))
+		case *Group:
+			content += fmt.Sprintf("\t%s: %s%s;\n", genTypeScriptFieldName(item.Name, false), genTypeScriptFieldType(getBasefromSimpleType(toQName(item.RefNamespace, item.Ref), gen.ProtoTree), item.Plural), genTypeScriptOptional(item.Optional, item.Plural))
+		case *Element:
+			if item.Wildcard != nil {
+				content += typeScriptAnyElement
+				continue
+			}
+			if gen.substitutionGroup(item) != nil {
+				content += fmt.Sprintf("\t%s: %s%s;\n", genTypeScriptFieldName(item.Name, false), genTypeScriptFieldType(item.Ref+"Substitution", item.Plural), genTypeScriptOptional(item.Optional, item.Plural))
+				continue
+			}
+			fieldType := genTypeScriptFieldType(getBasefromSimpleType(toQName(item.TypeNamespace, item.Type), gen.ProtoTree), item.Plural)
+			content += fmt.Sprintf("\t%s: %s%s%s;\n", genTypeScriptFieldName(item.Name, false), fieldType, genTypeScriptOptional(item.Optional, item.Plural), genTypeScriptInitializer(fieldType, item.Default, item.Fixed))
+		}
+	}
+	return
+}
+
+// genTypeScriptMixedContent returns the field holding the character data and
+// the elements of mixed content in document order, the elements are
+// discriminated by the element name.
+func (gen *CodeGenerator) genTypeScriptMixedContent(elements []Element) string {
+	members := []string{"string"}
+	for _, e := range elements {
+		if e.Wildcard != nil {
+			members = append(members, "any")
+			continue
+		}
+		members = append(members, fmt.Sprintf("{ %s: %s }", e.Name, genTypeScriptFieldType(getBasefromSimpleType(toQName(e.TypeNamespace, e.Type), gen.ProtoTree), false)))
 	}
+	return fmt.Sprintf("\tContent: Array<%s>;\n", strings.Join(members, " | "))
 }
 
 func isBuiltInTypeScriptType(typeName string) bool {
@@ -193,56 +286,67 @@ func isBuiltInTypeScriptType(typeName string) bool {
 
 // TypeScriptGroup generates code for group XML schema in TypeScript language syntax.
 func (gen *CodeGenerator) TypeScriptGroup(v *Group) {
-	if _, ok := gen.StructAST[v.Name]; !ok {
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
 		content := " {\n"
-		for _, element := range v.Elements {
-			content += fmt.Sprintf("\t%s: %s;\n", genTypeScriptFieldName(element.Name, false), genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(element.Type), gen.ProtoTree), element.Plural))
-		}
-
-		for _, group := range v.Groups {
-			content += fmt.Sprintf("\t%s: %s;\n", genTypeScriptFieldName(group.Name, false), genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(group.Ref), gen.ProtoTree), group.Plural))
-		}
-
-		content += "}\n"
-		gen.StructAST[v.Name] = content
 		fieldName := genTypeScriptFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%sexport class %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		fields, union := gen.genTypeScriptContent(fieldName, v.Particle, v.Groups, v.Elements, gen.unionChoice(v.Particle, v.Elements, v.Choice))
+		content += fields
+		content += "}\n"
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
+		gen.Field += fmt.Sprintf("%sexport class %s%s", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//"), fieldName, gen.StructAST[toQName(v.TargetNamespace, v.Name)])
+		gen.Field += union
 	}
 }
 
 // TypeScriptAttributeGroup generates code for attribute group XML schema in TypeScript language
 // syntax.
 func (gen *CodeGenerator) TypeScriptAttributeGroup(v *AttributeGroup) {
-	if _, ok := gen.StructAST[v.Name]; !ok {
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
 		content := " {\n"
 		for _, attribute := range v.Attributes {
 			var optional string
 			if attribute.Optional {
 				optional = ` | null`
 			}
-			content += fmt.Sprintf("\t%sAttr: %s%s;\n", genTypeScriptFieldName(attribute.Name, false), genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(attribute.Type), gen.ProtoTree), attribute.Plural), optional)
+			fieldType := genTypeScriptFieldType(getBasefromSimpleType(toQName(attribute.TypeNamespace, attribute.Type), gen.ProtoTree), attribute.Plural)
+			content += fmt.Sprintf("\t%sAttr: %s%s%s;\n", genTypeScriptFieldName(attribute.Name, false), fieldType, optional, genTypeScriptInitializer(fieldType, attribute.Default, attribute.Fixed))
+		}
+		if v.AnyAttribute != nil {
+			content += typeScriptAnyAttribute
 		}
 		content += "}\n"
-		gen.StructAST[v.Name] = content
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = content
 		fieldName := genTypeScriptFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%sexport class %s%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		gen.Field += fmt.Sprintf("%sexport class %s%s", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//"), fieldName, gen.StructAST[toQName(v.TargetNamespace, v.Name)])
 	}
 }
 
 // TypeScriptElement generates code for element XML schema in TypeScript language syntax.
 func (gen *CodeGenerator) TypeScriptElement(v *Element) {
-	if _, ok := gen.StructAST[v.Name]; !ok {
-		gen.StructAST[v.Name] = fmt.Sprintf(" %s;\n", genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree), v.Plural))
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = fmt.Sprintf(" %s;\n", genTypeScriptFieldType(getBasefromSimpleType(toQName(v.TypeNamespace, v.Type), gen.ProtoTree), v.Plural))
 		fieldName := genTypeScriptFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%sexport type %s =%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		gen.Field += fmt.Sprintf("%sexport type %s =%s", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//")+genConstraintComment(nil, v.Alternatives, "//"), fieldName, gen.StructAST[toQName(v.TargetNamespace, v.Name)])
 	}
 }
 
 // TypeScriptAttribute generates code for attribute XML schema in TypeScript language syntax.
 func (gen *CodeGenerator) TypeScriptAttribute(v *Attribute) {
-	if _, ok := gen.StructAST[v.Name]; !ok {
-		gen.StructAST[v.Name] = fmt.Sprintf(" %s;\n", genTypeScriptFieldType(getBasefromSimpleType(trimNSPrefix(v.Type), gen.ProtoTree), v.Plural))
+	if _, ok := gen.StructAST[toQName(v.TargetNamespace, v.Name)]; !ok {
+		gen.StructAST[toQName(v.TargetNamespace, v.Name)] = fmt.Sprintf(" %s;\n", genTypeScriptFieldType(getBasefromSimpleType(toQName(v.TypeNamespace, v.Type), gen.ProtoTree), v.Plural))
 		fieldName := genTypeScriptFieldName(v.Name, true)
-		gen.Field += fmt.Sprintf("%sexport type %s =%s", genFieldComment(fieldName, v.Doc, "//"), fieldName, gen.StructAST[v.Name])
+		gen.Field += fmt.Sprintf("%sexport type %s =%s", genFieldComment(fieldName, gen.doc(v.Annotation, v.Doc), "//"), fieldName, gen.StructAST[toQName(v.TargetNamespace, v.Name)])
+	}
+}
+
+// TypeScriptSubstitutionGroup generates code for substitution group XML
+// schema in TypeScript language syntax. The elements of the group are
+// discriminated by the element name.
+func (gen *CodeGenerator) TypeScriptSubstitutionGroup(v *SubstitutionGroup) {
+	var members []string
+	for _, e := range v.Elements {
+		members = append(members, fmt.Sprintf("{ %s: %s }", e.Name, genTypeScriptFieldType(getBasefromSimpleType(toQName(e.TypeNamespace, e.Type), gen.ProtoTree), false)))
 	}
+	typeName := genTypeScriptFieldName(v.Head.Name, false) + "Substitution"
+	gen.Field += fmt.Sprintf("%sexport type %s = %s;\n", genFieldComment(typeName, fmt.Sprintf("the substitution group of the %s element.", v.Head.Name), "//"), typeName, strings.Join(members, " | "))
 }
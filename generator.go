@@ -0,0 +1,397 @@
+// Copyright 2020 - 2021 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// generators defines the code generator of each supported language.
+var generators = map[string]func(gen *CodeGenerator) error{
+	"Go":         (*CodeGenerator).GenGo,
+	"TypeScript": (*CodeGenerator).GenTypeScript,
+	"C":          (*CodeGenerator).GenC,
+	"Java":       (*CodeGenerator).GenJava,
+	"Rust":       (*CodeGenerator).GenRust,
+}
+
+// Generate generates code for every schema document in the schema set by
+// given language and package options. The code of each document will be
+// written into the output directory with the path of the document relative
+// to the input directory.
+func (opt *Options) Generate(set *SchemaSet) error {
+	generate, ok := generators[opt.Lang]
+	if !ok {
+		return fmt.Errorf("unsupported language %s", opt.Lang)
+	}
+	var attributes map[string]bool
+	if opt.IntegrityCheck {
+		attributes = identityAttributes(set.Schemas)
+	}
+	prefixes, groups := typePrefixes(set.Schemas), modelGroups(set.Schemas)
+	for _, schema := range set.Schemas {
+		path := opt.outputPath(schema)
+		if err := PrepareOutputDir(filepath.Dir(path)); err != nil {
+			return err
+		}
+		generator := &CodeGenerator{
+			Lang:      opt.Lang,
+			Package:   opt.Package,
+			File:      path,
+			ProtoTree: schema.ProtoTree,
+			StructAST: map[xml.Name]string{},
+
+			IntegrityCheck: opt.IntegrityCheck,
+			DocLanguage:    opt.DocLanguage,
+
+			GlobalElements:     schema.GlobalElements,
+			SubstitutionGroups: set.SubstitutionGroups,
+			ComplexTypes:       set.ComplexTypes,
+			IdentityAttributes: attributes,
+			TypePrefixes:       prefixes,
+			Groups:             groups,
+		}
+		if err := generate(generator); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// identityAttributes returns the local names of the attributes selected by
+// the fields of the identity constraints in given schema documents and their
+// chameleons.
+func identityAttributes(schemas []*Schema) map[string]bool {
+	names := make(map[string]bool)
+	visit := func(component interface{}) {
+		element, ok := component.(*Element)
+		if !ok {
+			return
+		}
+		for _, constraint := range element.IdentityConstraints {
+			for _, field := range constraint.Fields {
+				for _, path := range strings.Split(field, "|") {
+					steps := strings.Split(path, "/")
+					step := strings.TrimSpace(steps[len(steps)-1])
+					if !strings.HasPrefix(step, "@") && !strings.HasPrefix(step, "attribute::") {
+						continue
+					}
+					names[trimNSPrefix(strings.TrimPrefix(strings.TrimPrefix(step, "@"), "attribute::"))] = true
+				}
+			}
+		}
+	}
+	for _, schema := range schemas {
+		for _, ele := range schema.ProtoTree {
+			walkComponent(ele, visit)
+		}
+		for _, chameleon := range schema.Chameleons {
+			for k := range identityAttributes([]*Schema{chameleon}) {
+				names[k] = true
+			}
+		}
+	}
+	return names
+}
+
+// modelGroups returns the model group definitions in given schema documents
+// and their chameleons keyed by their qualified name.
+func modelGroups(schemas []*Schema) map[xml.Name]*Group {
+	groups := make(map[xml.Name]*Group)
+	for _, schema := range schemas {
+		for _, ele := range schema.ProtoTree {
+			if group, ok := ele.(*Group); ok {
+				groups[componentName(group)] = group
+			}
+		}
+		for _, chameleon := range schema.Chameleons {
+			for name, group := range modelGroups([]*Schema{chameleon}) {
+				groups[name] = group
+			}
+		}
+	}
+	return groups
+}
+
+// typePrefixes returns the prefixes of the type names of the global
+// components in given schema documents, which keep the components with the
+// same local name in different namespaces apart. The prefix is made of the
+// last segment of the namespace name, the components without namespace
+// aren't prefixed. The chameleons share the types of their document, so
+// they're not prefixed.
+func typePrefixes(schemas []*Schema) map[xml.Name]string {
+	namespaces := make(map[string]map[string]bool)
+	for _, schema := range schemas {
+		for _, ele := range schema.ProtoTree {
+			name := componentName(ele)
+			if name.Local == "" {
+				continue
+			}
+			if namespaces[name.Local] == nil {
+				namespaces[name.Local] = make(map[string]bool)
+			}
+			namespaces[name.Local][name.Space] = true
+		}
+	}
+	prefixes := make(map[xml.Name]string)
+	for local, spaces := range namespaces {
+		if len(spaces) < 2 {
+			continue
+		}
+		var sorted []string
+		for space := range spaces {
+			if space != "" {
+				sorted = append(sorted, space)
+			}
+		}
+		sort.Strings(sorted)
+		used := make(map[string]bool)
+		for _, space := range sorted {
+			prefix := namespacePrefix(space)
+			for i := 2; used[prefix]; i++ {
+				prefix = fmt.Sprintf("%s%d", namespacePrefix(space), i)
+			}
+			used[prefix] = true
+			prefixes[xml.Name{Space: space, Local: local}] = prefix
+		}
+	}
+	return prefixes
+}
+
+// namespacePrefix returns the last segment of the namespace name made of
+// letters and digits, such as "Orders" for "http://example.com/orders".
+func namespacePrefix(namespace string) string {
+	segments := strings.FieldsFunc(namespace, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(segments) == 0 {
+		return "NS"
+	}
+	return MakeFirstUpperCase(segments[len(segments)-1])
+}
+
+// outputPath returns the path of the generated code for the schema document
+// without the file extension. The path of the code of an input file is the
+// output path, and the documents loaded by it, such as the imported
+// documents, are generated under the output path with the path relative to
+// the directory of the input file. The remote documents are generated with
+// the path of the host and path of their URL.
+func (opt *Options) outputPath(schema *Schema) string {
+	if u, err := url.Parse(schema.URL); err == nil && u.IsAbs() {
+		return filepath.Join(opt.OutputDir, u.Host, filepath.FromSlash(u.Path))
+	}
+	rel := strings.TrimPrefix(schema.FilePath, opt.InputDir)
+	if rel != schema.FilePath {
+		return filepath.Join(opt.OutputDir, rel)
+	}
+	if fi, err := os.Stat(opt.InputDir); err == nil && !fi.IsDir() {
+		if rel, err := filepath.Rel(filepath.Dir(opt.InputDir), schema.FilePath); err == nil && !strings.HasPrefix(rel, "..") {
+			return filepath.Join(opt.OutputDir, rel)
+		}
+	}
+	return filepath.Join(opt.OutputDir, rel)
+}
+
+// genProtoTree calls the generate function of the language for every
+// component in the proto tree by the type of the component.
+func (gen *CodeGenerator) genProtoTree(simpleType func(*SimpleType), complexType func(*ComplexType), group func(*Group), attributeGroup func(*AttributeGroup), element func(*Element), attribute func(*Attribute)) error {
+	for _, ele := range gen.ProtoTree {
+		switch v := ele.(type) {
+		case nil:
+		case *SimpleType:
+			simpleType(v)
+		case *ComplexType:
+			complexType(v)
+		case *Group:
+			group(v)
+		case *AttributeGroup:
+			attributeGroup(v)
+		case *Element:
+			element(v)
+		case *Attribute:
+			attribute(v)
+		default:
+			return fmt.Errorf("unsupported schema component %T", ele)
+		}
+	}
+	return nil
+}
+
+// genSubstitutionGroups calls the generate function of the language for every
+// substitution group headed by a global element declaration of the schema
+// document.
+func (gen *CodeGenerator) genSubstitutionGroups(substitutionGroup func(*SubstitutionGroup)) {
+	for _, e := range gen.GlobalElements {
+		if group, ok := gen.SubstitutionGroups[toQName(e.TargetNamespace, e.Name)]; ok && group.Head == e {
+			substitutionGroup(group)
+		}
+	}
+}
+
+// substitutionGroup returns the substitution group headed by the element
+// declaration referenced by given element, or nil if the element declaration
+// is not the head of a substitution group.
+func (gen *CodeGenerator) substitutionGroup(e *Element) *SubstitutionGroup {
+	if e.Ref == "" {
+		return nil
+	}
+	if group, ok := gen.SubstitutionGroups[toQName(e.RefNamespace, e.Ref)]; ok && group.Head != nil {
+		return group
+	}
+	return nil
+}
+
+// mixedBase reports whether the complex type extends a mixed complex type of
+// the schema document, which holds the mixed content of the type.
+func (gen *CodeGenerator) mixedBase(v *ComplexType) bool {
+	if v.Derivation != DerivationExtension {
+		return false
+	}
+	for _, ele := range gen.ProtoTree {
+		if base, ok := ele.(*ComplexType); ok && base.Mixed && toQName(base.TargetNamespace, base.Name) == toQName(v.BaseNamespace, v.Base) {
+			return true
+		}
+	}
+	return false
+}
+
+// doc returns the documentation of a component for the doc comment in the
+// preferred language of the generator.
+func (gen *CodeGenerator) doc(annotation *Annotation, doc string) string {
+	if annotation == nil || gen.DocLanguage == "" {
+		return doc
+	}
+	return annotation.Doc(gen.DocLanguage)
+}
+
+// complexTypeUnion returns the choice of the complex type generated as a
+// union of its alternatives, or nil if there is none. The elements of a
+// complex type with open content are captured by its wildcard.
+func (gen *CodeGenerator) complexTypeUnion(v *ComplexType) *Choice {
+	if hasOpenContent(v) {
+		return nil
+	}
+	return gen.unionChoice(v.Particle, v.Elements, v.Choice)
+}
+
+// unionChoice returns the choice of the content model generated as a union
+// of its alternatives, or nil if there is none. A choice is generated as a
+// union when its alternatives are at least two distinct element declarations
+// not declared elsewhere in the content model, the alternatives of a nested
+// choice are the alternatives of the union in place of the nested choice.
+// The elements of a union are captured in the same way as the elements
+// matched by an element wildcard or a substitution group, so only the first
+// of such choices of a content model without them is generated as a union.
+func (gen *CodeGenerator) unionChoice(particle *Particle, elements []Element, choices []Choice) *Choice {
+	for i := range elements {
+		if elements[i].Wildcard != nil || gen.substitutionGroup(&elements[i]) != nil {
+			return nil
+		}
+	}
+	declared := make(map[string]int)
+	var count func(particle *Particle)
+	count = func(particle *Particle) {
+		if particle == nil {
+			return
+		}
+		if particle.Kind == ParticleElement {
+			declared[particle.Name]++
+		}
+		for _, child := range particle.Particles {
+			count(child)
+		}
+	}
+	count(particle)
+	var find func(particle *Particle) *Choice
+	find = func(particle *Particle) *Choice {
+		if particle == nil {
+			return nil
+		}
+		if choice := findChoice(particle.Name, choices); particle.Kind == ParticleChoice && choice != nil {
+			alternatives, ok := choiceParticleElements(particle)
+			union := *choice
+			union.Elements, union.Choice = nil, nil
+			for _, alternative := range alternatives {
+				e := findChoiceElement(alternative.Name, choice)
+				if e == nil || declared[alternative.Name] != 1 {
+					ok = false
+					break
+				}
+				union.Elements = append(union.Elements, *e)
+			}
+			if ok && len(union.Elements) > 1 {
+				return &union
+			}
+		}
+		for _, child := range particle.Particles {
+			if choice := find(child); choice != nil {
+				return choice
+			}
+		}
+		return nil
+	}
+	return find(particle)
+}
+
+// choiceParticleElements returns the element particles of the alternatives
+// of the choice particle, with the alternatives of the nested choices in
+// place of them, and reports whether every alternative is an element or a
+// nested choice.
+func choiceParticleElements(particle *Particle) (elements []*Particle, ok bool) {
+	ok = true
+	for _, alternative := range particle.Particles {
+		switch alternative.Kind {
+		case ParticleElement:
+			elements = append(elements, alternative)
+		case ParticleChoice:
+			nested, nestedOK := choiceParticleElements(alternative)
+			elements, ok = append(elements, nested...), ok && nestedOK
+		default:
+			ok = false
+		}
+	}
+	return
+}
+
+// findChoiceElement returns the element alternative with given name of the
+// choice or the choices nested in it, or nil if not found.
+func findChoiceElement(name string, choice *Choice) *Element {
+	for i := range choice.Elements {
+		if choice.Elements[i].Name == name {
+			return &choice.Elements[i]
+		}
+	}
+	for i := range choice.Choice {
+		if e := findChoiceElement(name, &choice.Choice[i]); e != nil {
+			return e
+		}
+	}
+	return nil
+}
+
+// findChoice returns the choice with given name in the choices or the choices
+// nested in them, or nil if not found.
+func findChoice(name string, choices []Choice) *Choice {
+	for i := range choices {
+		if choices[i].Name == name {
+			return &choices[i]
+		}
+		if choice := findChoice(name, choices[i].Choice); choice != nil {
+			return choice
+		}
+	}
+	return nil
+}
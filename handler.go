@@ -0,0 +1,100 @@
+// Copyright 2020 - 2021 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"encoding/xml"
+	"errors"
+)
+
+// SkipElement is used as a return value from the start handler of an element
+// to indicate that the element and its children are to be skipped by the
+// parser. It is not returned as an error by the parser.
+var SkipElement = errors.New("skip this element")
+
+// errElementDecoded is used as a return value from the start handler of an
+// element which decodes the element and its children by itself. The end
+// handler of the element isn't called by the parser.
+var errElementDecoded = errors.New("element decoded")
+
+// ElementHandler holds the functions called by the parser on the start and
+// end elements of an XSD element. Either of them can be nil.
+type ElementHandler struct {
+	Start func(opt *Options, ele xml.StartElement, protoTree []interface{}) error
+	End   func(opt *Options, ele xml.EndElement, protoTree []interface{}) error
+}
+
+// DefaultHandlers returns the built-in element handlers of the parser keyed
+// by the qualified name of XSD elements. Elements that carry no data by
+// themselves are registered with an empty handler.
+func DefaultHandlers() map[xml.Name]ElementHandler {
+	handlers := map[string]ElementHandler{
+		"all":                {Start: (*Options).OnAll, End: (*Options).EndAll},
+		"alternative":        {Start: (*Options).OnAlternative},
+		"annotation":         {Start: (*Options).OnAnnotation, End: (*Options).EndAnnotation},
+		"any":                {Start: (*Options).OnAny},
+		"anyAttribute":       {Start: (*Options).OnAnyAttribute},
+		"appinfo":            {Start: (*Options).OnAppInfo},
+		"assert":             {Start: (*Options).OnAssert},
+		"assertion":          {Start: (*Options).OnAssertion},
+		"attribute":          {Start: (*Options).OnAttribute, End: (*Options).EndAttribute},
+		"attributeGroup":     {Start: (*Options).OnAttributeGroup, End: (*Options).EndAttributeGroup},
+		"choice":             {Start: (*Options).OnChoice, End: (*Options).EndChoice},
+		"complexContent":     {Start: (*Options).OnComplexContent},
+		"complexType":        {Start: (*Options).OnComplexType, End: (*Options).EndComplexType},
+		"defaultOpenContent": {Start: (*Options).OnDefaultOpenContent, End: (*Options).EndDefaultOpenContent},
+		"documentation":      {Start: (*Options).OnDocumentation},
+		"element":            {Start: (*Options).OnElement, End: (*Options).EndElement},
+		"enumeration":        {Start: (*Options).OnEnumeration, End: (*Options).EndEnumeration},
+		"extension":          {Start: (*Options).OnExtension, End: (*Options).EndExtension},
+		"field":              {Start: (*Options).OnField},
+		"fractionDigits":     {Start: (*Options).OnFractionDigits},
+		"group":              {Start: (*Options).OnGroup, End: (*Options).EndGroup},
+		"import":             {Start: (*Options).OnImport},
+		"include":            {Start: (*Options).OnInclude},
+		"key":                {Start: (*Options).OnKey, End: (*Options).EndKey},
+		"keyref":             {Start: (*Options).OnKeyref, End: (*Options).EndKeyref},
+		"length":             {Start: (*Options).OnLength},
+		"list":               {Start: (*Options).OnList},
+		"maxExclusive":       {Start: (*Options).OnMaxExclusive},
+		"maxInclusive":       {Start: (*Options).OnMaxInclusive},
+		"maxLength":          {Start: (*Options).OnMaxLength},
+		"minExclusive":       {Start: (*Options).OnMinExclusive},
+		"minInclusive":       {Start: (*Options).OnMinInclusive},
+		"minLength":          {Start: (*Options).OnMinLength},
+		"openContent":        {Start: (*Options).OnOpenContent, End: (*Options).EndOpenContent},
+		"override":           {Start: (*Options).OnOverride, End: (*Options).EndOverride},
+		"pattern":            {Start: (*Options).OnPattern},
+		"redefine":           {Start: (*Options).OnRedefine, End: (*Options).EndRedefine},
+		"restriction":        {Start: (*Options).OnRestriction, End: (*Options).EndRestriction},
+		"schema":             {Start: (*Options).OnSchema},
+		"selector":           {Start: (*Options).OnSelector},
+		"sequence":           {Start: (*Options).OnSequence, End: (*Options).EndSequence},
+		"simpleContent":      {},
+		"simpleType":         {Start: (*Options).OnSimpleType, End: (*Options).EndSimpleType},
+		"totalDigits":        {Start: (*Options).OnTotalDigits},
+		"union":              {Start: (*Options).OnUnion, End: (*Options).EndUnion},
+		"unique":             {Start: (*Options).OnUnique, End: (*Options).EndUnique},
+		"whiteSpace":         {Start: (*Options).OnWhiteSpace},
+	}
+	defaultHandlers := make(map[xml.Name]ElementHandler, len(handlers))
+	for local, handler := range handlers {
+		defaultHandlers[xml.Name{Space: xsdNamespace, Local: local}] = handler
+	}
+	return defaultHandlers
+}
+
+// RegisterHandler registers the handler for the XSD element by given
+// qualified name, it replaces the built-in handler of the element if exists.
+func (opt *Options) RegisterHandler(name xml.Name, handler ElementHandler) {
+	if opt.Handlers == nil {
+		opt.Handlers = DefaultHandlers()
+	}
+	opt.Handlers[name] = handler
+}
@@ -10,32 +10,53 @@ package xgen
 
 import (
 	"encoding/xml"
-	"fmt"
+	"io"
 	"os"
 	"path/filepath"
-	"reflect"
-	"strings"
 
 	"golang.org/x/net/html/charset"
 )
 
+// Naming strategies of anonymous types. An anonymous type is named by the
+// name of the declaration which defines it, by the names of the declarations
+// enclosing it from the top-level component, such as OrderLineItem, or by the
+// name of the declaration with the AnonymousTypeSuffix of the options, which
+// defaults to "Type".
+const (
+	AnonymousNamingElement = "element"
+	AnonymousNamingPath    = "path"
+	AnonymousNamingSuffix  = "suffix"
+)
+
 // Options holds user-defined overrides and runtime data that are used when
 // parsing from an XSD document.
 type Options struct {
-	FilePath            string
-	FileDir             string
-	InputDir            string
-	OutputDir           string
-	Extract             bool
-	Lang                string
-	Package             string
-	IncludeMap          map[string]bool
-	LocalNameNSMap      map[string]string
-	NSSchemaLocationMap map[string]string
-	ParseFileList       map[string]bool
-	ParseFileMap        map[string][]interface{}
-	ProtoTree           []interface{}
-	RemoteSchema        map[string][]byte
+	FilePath           string
+	FileDir            string
+	InputDir           string
+	OutputDir          string
+	Lang               string
+	Package            string
+	XSDVersion         string
+	IntegrityCheck     bool
+	Handlers           map[xml.Name]ElementHandler
+	Diagnostics        *Diagnostics
+	ProtoTree          []interface{}
+	GlobalElements     []*Element
+	OpenContent        *OpenContent
+	DefaultOpenContent *OpenContent
+	Redefine           *Redefine
+	Redefines          []*Redefine
+	Annotation         *Annotation
+	Annotations        []*Annotation
+	References         []*SchemaReference
+	Cache              *SchemaCache
+	Catalog            *Catalog
+	TargetNamespace    string
+
+	AnonymousTypeNaming string
+	AnonymousTypeSuffix string
+	DocLanguage         string
 
 	InElement        string
 	CurrentEle       string
@@ -50,6 +71,17 @@ type Options struct {
 	Group          *Stack
 	AttributeGroup *Stack
 	Choice         *Stack
+	Particle       *Stack
+	NSScope        *Stack
+
+	ElementDecl        *Stack
+	IdentityConstraint *Stack
+
+	ancestors      []xml.StartElement
+	anonymousTypes map[string]string
+	pos            Position
+	decoder        *xml.Decoder
+	loader         *schemaLoader
 }
 
 // NewParser creates a new parser options for the Parse. Useful for XML schema
@@ -59,8 +91,11 @@ func NewParser(options *Options) *Options {
 }
 
 // Parse reads XML documents and return proto tree for every element in the
-// documents by given options. If value of the property extract is false,
-// parse will fetch schema used in <import> or <include> statements.
+// documents by given options. The schema documents referenced by <import>,
+// <include>, <redefine> or <override> statements are parsed when the
+// statements are read, and each document is parsed once by the parser. The
+// malformed XML or invalid schema stopping the parsing is returned as a
+// *Diagnostic with error severity, which is added to the diagnostics too.
 func (opt *Options) Parse() (err error) {
 	opt.FileDir = filepath.Dir(opt.FilePath)
 	var fi os.FileInfo
@@ -77,10 +112,13 @@ func (opt *Options) Parse() (err error) {
 		return
 	}
 	defer xmlFile.Close()
-	if !opt.Extract {
-		opt.ParseFileList[opt.FilePath] = true
-		opt.ParseFileMap[opt.FilePath] = opt.ProtoTree
+	if opt.loader == nil {
+		opt.loader = newSchemaLoader(opt)
 	}
+	opt.loader.loading = append(opt.loader.loading, opt.FilePath)
+	defer func() {
+		opt.loader.loading = opt.loader.loading[:len(opt.loader.loading)-1]
+	}()
 	opt.ProtoTree = make([]interface{}, 0)
 
 	opt.InElement = ""
@@ -96,152 +134,142 @@ func (opt *Options) Parse() (err error) {
 	opt.Group = NewStack()
 	opt.AttributeGroup = NewStack()
 	opt.Choice = NewStack()
+	opt.Particle = NewStack()
+	opt.NSScope = NewStack()
+	opt.ancestors = nil
+	opt.ElementDecl = NewStack()
+	opt.IdentityConstraint = NewStack()
+	opt.Annotation, opt.Annotations = nil, nil
+	if opt.Handlers == nil {
+		opt.Handlers = DefaultHandlers()
+	}
+	if opt.Diagnostics == nil {
+		opt.Diagnostics = opt.loader.diagnostics
+	}
 
 	decoder := xml.NewDecoder(xmlFile)
 	decoder.CharsetReader = charset.NewReaderLabel
+	opt.decoder = decoder
+	defer func() {
+		if err != nil {
+			err = opt.fail(err)
+		}
+	}()
 	for {
-		token, _ := decoder.Token()
-		if token == nil {
+		line, column := decoder.InputPos()
+		var token xml.Token
+		if token, err = decoder.Token(); err == io.EOF {
+			err = nil
 			break
 		}
+		if err != nil {
+			return
+		}
 
 		switch element := token.(type) {
 		case xml.StartElement:
-
+			opt.pos = Position{File: opt.FilePath, Line: line, Column: column}
+			opt.pushNSScope(element)
+			if opt.conditionallyExcluded(element) {
+				opt.NSScope.Pop()
+				if err = decoder.Skip(); err != nil {
+					return
+				}
+				break
+			}
 			opt.InElement = element.Name.Local
-			funcName := fmt.Sprintf("On%s", MakeFirstUpperCase(opt.InElement))
-			if err = callFuncByName(opt, funcName, []reflect.Value{reflect.ValueOf(element), reflect.ValueOf(opt.ProtoTree)}); err != nil {
-				return
+			opt.ancestors = append(opt.ancestors, element)
+			handler, ok := opt.Handlers[element.Name]
+			if !ok {
+				if element.Name.Space == xsdNamespace {
+					opt.report(DiagnosticUnknownElement, "unknown XSD element <%s>", element.Name.Local)
+				}
+				break
+			}
+			if handler.Start != nil {
+				if err = handler.Start(opt, element, opt.ProtoTree); err == SkipElement || err == errElementDecoded {
+					opt.NSScope.Pop()
+					opt.ancestors = opt.ancestors[:len(opt.ancestors)-1]
+					if err == errElementDecoded {
+						err = nil
+						break
+					}
+					if err = decoder.Skip(); err != nil {
+						return
+					}
+					break
+				}
+				if err != nil {
+					return
+				}
 			}
 
 		case xml.EndElement:
-			funcName := fmt.Sprintf("End%s", MakeFirstUpperCase(element.Name.Local))
-			if err = callFuncByName(opt, funcName, []reflect.Value{reflect.ValueOf(element), reflect.ValueOf(opt.ProtoTree)}); err != nil {
-				return
-			}
-		case xml.CharData:
-			if err = opt.OnCharData(string(element), opt.ProtoTree); err != nil {
-				return
+			if handler := opt.Handlers[element.Name]; handler.End != nil {
+				if err = handler.End(opt, element, opt.ProtoTree); err != nil {
+					return
+				}
 			}
+			opt.NSScope.Pop()
+			opt.ancestors = opt.ancestors[:len(opt.ancestors)-1]
 		default:
 		}
 
 	}
 
-	if !opt.Extract {
-		opt.ParseFileList[opt.FilePath] = true
-		opt.ParseFileMap[opt.FilePath] = opt.ProtoTree
-		path := filepath.Join(opt.OutputDir, strings.TrimPrefix(opt.FilePath, opt.InputDir))
-		if err := PrepareOutputDir(filepath.Dir(path)); err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
-		generator := &CodeGenerator{
-			Lang:      opt.Lang,
-			Package:   opt.Package,
-			File:      path,
-			ProtoTree: opt.ProtoTree,
-			StructAST: map[string]string{},
-		}
-		funcName := fmt.Sprintf("Gen%s", MakeFirstUpperCase(opt.Lang))
-		if err = callFuncByName(generator, funcName, []reflect.Value{}); err != nil {
-			return
-		}
+	schema := &Schema{
+		FilePath:        opt.FilePath,
+		TargetNamespace: opt.TargetNamespace,
+		ProtoTree:       opt.ProtoTree,
+		GlobalElements:  opt.GlobalElements,
+		Redefines:       opt.Redefines,
+		References:      opt.References,
+		Annotations:     opt.Annotations,
 	}
+	if opt.Cache != nil {
+		schema.URL = opt.Cache.URL(opt.FilePath)
+	}
+	opt.loader.add(schema)
 	return
 }
 
 // GetValueType convert XSD schema value type to the build-in type for the
-// given value and proto tree.
+// given value. References to type definitions are resolved by Load once
+// every schema document has been parsed, the XSDSchema is not used.
 func (opt *Options) GetValueType(value string, XSDSchema []interface{}) (valueType string, err error) {
-	if buildType, ok := getBuildInTypeByLang(trimNSPrefix(value), opt.Lang); ok {
+	valueType, _, err = opt.getValueType(value)
+	return
+}
+
+// getValueType resolves the QName value through the namespace declarations
+// in scope and returns the value type with the namespace of it.
+func (opt *Options) getValueType(value string) (valueType, ns string, err error) {
+	return opt.lookupValueType(opt.resolveQName(value))
+}
+
+// lookupValueType convert the qualified name of a XSD schema type to the
+// build-in type. The namespace of the value type is empty for the build-in
+// types. Other names are kept as is, they are linked to the definitions by
+// the resolution phase once every schema document has been parsed.
+func (opt *Options) lookupValueType(name xml.Name) (valueType, ns string, err error) {
+	if buildType, ok := opt.getBuildInType(name); ok {
 		valueType = buildType
 		return
 	}
-	valueType = getBasefromSimpleType(trimNSPrefix(value), XSDSchema)
-	if valueType != trimNSPrefix(value) && valueType != "" {
-		return
-	}
-	if opt.Extract {
-		return
-	}
-	schemaLocation := opt.NSSchemaLocationMap[opt.parseNS(value)]
-	if isValidURL(schemaLocation) {
-		return
-	}
-	xsdFile := filepath.Join(opt.FileDir, schemaLocation)
-	var fi os.FileInfo
-	fi, err = os.Stat(xsdFile)
-	if err != nil {
-		return
-	}
-	if fi.IsDir() {
-		// extract type of value from include schema.
-		valueType = ""
-		for include := range opt.IncludeMap {
-			parser := NewParser(&Options{
-				FilePath:            filepath.Join(opt.FileDir, include),
-				OutputDir:           opt.OutputDir,
-				Extract:             true,
-				Lang:                opt.Lang,
-				IncludeMap:          opt.IncludeMap,
-				LocalNameNSMap:      opt.LocalNameNSMap,
-				NSSchemaLocationMap: opt.NSSchemaLocationMap,
-				ParseFileList:       opt.ParseFileList,
-				ParseFileMap:        opt.ParseFileMap,
-				ProtoTree:           make([]interface{}, 0),
-			})
-			if parser.Parse() != nil {
-				return
-			}
-			if vt := getBasefromSimpleType(trimNSPrefix(value), parser.ProtoTree); vt != trimNSPrefix(value) {
-				valueType = vt
-			}
-		}
-		if valueType == "" {
-			valueType = trimNSPrefix(value)
-		}
-		return
-	}
+	valueType, ns = name.Local, name.Space
+	return
+}
 
-	depXSDSchema, ok := opt.ParseFileMap[xsdFile]
-	if !ok {
-		parser := NewParser(&Options{
-			FilePath:            xsdFile,
-			OutputDir:           opt.OutputDir,
-			Extract:             false,
-			Lang:                opt.Lang,
-			IncludeMap:          opt.IncludeMap,
-			LocalNameNSMap:      opt.LocalNameNSMap,
-			NSSchemaLocationMap: opt.NSSchemaLocationMap,
-			ParseFileList:       opt.ParseFileList,
-			ParseFileMap:        opt.ParseFileMap,
-			ProtoTree:           make([]interface{}, 0),
-		})
-		if parser.Parse() != nil {
-			return
-		}
-		depXSDSchema = parser.ProtoTree
+// getBuildInType returns the build-in type of the given qualified name. The
+// names in the XML schema namespace or without namespace are the build-in
+// data types, names in other namespaces may refer to type definitions, which
+// are left to the resolution phase.
+func (opt *Options) getBuildInType(name xml.Name) (buildType string, ok bool) {
+	if name.Space == xmlNamespace {
+		return getBuildInTypeByLang("xml:"+name.Local, opt.Lang)
 	}
-	valueType = getBasefromSimpleType(trimNSPrefix(value), depXSDSchema)
-	if valueType != trimNSPrefix(value) && valueType != "" {
+	if name.Space != xsdNamespace && name.Space != "" {
 		return
 	}
-	parser := NewParser(&Options{
-		FilePath:            xsdFile,
-		OutputDir:           opt.OutputDir,
-		Extract:             true,
-		Lang:                opt.Lang,
-		IncludeMap:          opt.IncludeMap,
-		LocalNameNSMap:      opt.LocalNameNSMap,
-		NSSchemaLocationMap: opt.NSSchemaLocationMap,
-		ParseFileList:       opt.ParseFileList,
-		ParseFileMap:        opt.ParseFileMap,
-		ProtoTree:           make([]interface{}, 0),
-	})
-	if parser.Parse() != nil {
-		return
-	}
-	valueType = getBasefromSimpleType(trimNSPrefix(value), parser.ProtoTree)
-	return
+	return getBuildInTypeByLang(name.Local, opt.Lang)
 }
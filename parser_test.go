@@ -9,10 +9,14 @@
 package xgen
 
 import (
+	"encoding/xml"
+	"errors"
 	"fmt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"strings"
@@ -73,18 +77,14 @@ func testParseForSource(t *testing.T, lang string, fileExt string, langDirName s
 
 			t.Run(xsdName, func(t *testing.T) {
 				parser := NewParser(&Options{
-					FilePath:            file,
-					InputDir:            inputDir,
-					OutputDir:           outputDir,
-					Lang:                lang,
-					IncludeMap:          make(map[string]bool),
-					LocalNameNSMap:      make(map[string]string),
-					NSSchemaLocationMap: make(map[string]string),
-					ParseFileList:       make(map[string]bool),
-					ParseFileMap:        make(map[string][]interface{}),
-					ProtoTree:           make([]interface{}, 0),
+					InputDir:       inputDir,
+					OutputDir:      outputDir,
+					Lang:           lang,
+					IntegrityCheck: true,
 				})
-				err = parser.Parse()
+				set, err := parser.Load(file)
+				assert.NoError(t, err, file)
+				err = parser.Generate(set)
 				assert.NoError(t, err, file)
 				generatedFileName := strings.TrimPrefix(file, inputDir) + "." + fileExt
 				actualFilename := filepath.Join(outputDir, generatedFileName)
@@ -100,6 +100,14 @@ func testParseForSource(t *testing.T, lang string, fileExt string, langDirName s
 			})
 		}
 	}
+	// the runtime shared by the generated Go code of the schema documents
+	if _, err := os.Stat(filepath.Join(codeDir, goRuntimeFile)); err == nil {
+		actualGenerated, err := ioutil.ReadFile(filepath.Join(outputDir, goRuntimeFile))
+		assert.NoError(t, err)
+		expectedGenerated, err := ioutil.ReadFile(filepath.Join(codeDir, goRuntimeFile))
+		assert.NoError(t, err)
+		assert.Equal(t, string(expectedGenerated), string(actualGenerated), "error in generated runtime code")
+	}
 }
 
 func TestParseTypeScript(t *testing.T) {
@@ -133,3 +141,1647 @@ func TestParseRust(t *testing.T) {
 func TestParseRustExternal(t *testing.T) {
 	testParseForSource(t, "Rust", "rs", "rs", externalFixtureDir, true)
 }
+
+// parseSchemaString parses the given XML schema definition content without
+// generating code and returns the proto tree of the schema.
+func parseSchemaString(t *testing.T, content string) []interface{} {
+	dir, err := ioutil.TempDir("", "xgen-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "schema.xsd")
+	require.NoError(t, ioutil.WriteFile(file, []byte(content), 0644))
+	parser := NewParser(&Options{
+		FilePath:  file,
+		Lang:      "Go",
+		ProtoTree: make([]interface{}, 0),
+	})
+	require.NoError(t, parser.Parse())
+	return parser.ProtoTree
+}
+
+func TestParseRestrictionFacets(t *testing.T) {
+	protoTree := parseSchemaString(t, `<schema xmlns="http://www.w3.org/2001/XMLSchema">
+  <simpleType name="percent">
+    <restriction base="decimal">
+      <minInclusive value="0"/>
+      <maxExclusive value="100.5"/>
+      <totalDigits value="5"/>
+      <fractionDigits value="2"/>
+    </restriction>
+  </simpleType>
+  <simpleType name="code">
+    <restriction base="string">
+      <minLength value="2"/>
+      <maxLength value="4"/>
+      <pattern value="[A-Z]+"/>
+      <pattern value="[0-9]+"/>
+      <whiteSpace value="collapse"/>
+    </restriction>
+  </simpleType>
+  <simpleType name="since">
+    <restriction base="date">
+      <minExclusive value="2000-01-01"/>
+      <maxInclusive value="2099-12-31"/>
+    </restriction>
+  </simpleType>
+  <simpleType name="fixed">
+    <restriction base="string">
+      <length value="3"/>
+    </restriction>
+  </simpleType>
+</schema>`)
+	require.Len(t, protoTree, 4)
+
+	percent := protoTree[0].(*SimpleType).Restriction
+	assert.Equal(t, "0", percent.MinValue)
+	assert.Equal(t, 0.0, percent.Min)
+	assert.False(t, percent.MinExclusive)
+	assert.Equal(t, "100.5", percent.MaxValue)
+	assert.Equal(t, 100.5, percent.Max)
+	assert.True(t, percent.MaxExclusive)
+	assert.Equal(t, 5, *percent.TotalDigits)
+	assert.Equal(t, 2, *percent.Precision)
+
+	code := protoTree[1].(*SimpleType).Restriction
+	assert.Equal(t, 2, *code.MinLength)
+	assert.Equal(t, 4, *code.MaxLength)
+	assert.Equal(t, []string{"[A-Z]+", "[0-9]+"}, code.Patterns)
+	require.NotNil(t, code.Pattern)
+	assert.True(t, code.Pattern.MatchString("AB"))
+	assert.True(t, code.Pattern.MatchString("12"))
+	assert.False(t, code.Pattern.MatchString("A1"))
+	assert.Equal(t, "collapse", code.WhiteSpace)
+
+	since := protoTree[2].(*SimpleType).Restriction
+	assert.Equal(t, "2000-01-01", since.MinValue)
+	assert.True(t, since.MinExclusive)
+	assert.Equal(t, "2099-12-31", since.MaxValue)
+	assert.False(t, since.MaxExclusive)
+
+	assert.Equal(t, 3, *protoTree[3].(*SimpleType).Restriction.Length)
+	assert.Nil(t, protoTree[3].(*SimpleType).Restriction.MaxLength)
+}
+
+func TestLoadFacetValues(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xgen-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "base.xsd"), []byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:lib">
+  <simpleType name="code">
+    <restriction base="string">
+      <minLength value=" 1 "/>
+      <maxLength value="10"/>
+    </restriction>
+  </simpleType>
+</schema>`), 0644))
+	file := filepath.Join(dir, "redefine.xsd")
+	require.NoError(t, ioutil.WriteFile(file, []byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema" xmlns:lib="urn:lib" targetNamespace="urn:lib">
+  <redefine schemaLocation="base.xsd">
+    <simpleType name="code">
+      <restriction base="lib:code">
+        <maxLength value="0"/>
+      </restriction>
+    </simpleType>
+  </redefine>
+  <simpleType name="digits">
+    <restriction base="decimal">
+      <totalDigits value="five"/>
+      <fractionDigits value="-1"/>
+    </restriction>
+  </simpleType>
+</schema>`), 0644))
+
+	set, err := NewParser(&Options{Lang: "Go"}).Load(file)
+	require.NoError(t, err)
+	require.Len(t, set.Schemas, 2)
+	code := set.Schemas[0].ProtoTree[0].(*SimpleType).Restriction
+	assert.Equal(t, 1, *code.MinLength)
+	assert.Equal(t, 0, *code.MaxLength)
+	digits := set.Schemas[1].ProtoTree[0].(*SimpleType).Restriction
+	assert.Nil(t, digits.TotalDigits)
+	assert.Nil(t, digits.Precision)
+	require.Len(t, set.Diagnostics, 2)
+	assert.Equal(t, SeverityWarning, set.Diagnostics[0].Severity)
+	assert.Equal(t, DiagnosticInvalid, set.Diagnostics[0].Code)
+	assert.Equal(t, file+":11:7: invalid totalDigits facet value \"five\"", set.Diagnostics[0].Error())
+	assert.Equal(t, `invalid fractionDigits facet value "-1"`, set.Diagnostics[1].Message)
+}
+
+func TestLoadAnonymousTypeFacets(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xgen-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "facets.xsd")
+	require.NoError(t, ioutil.WriteFile(file, []byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema">
+  <complexType name="itemType">
+    <sequence>
+      <element name="sku">
+        <simpleType>
+          <restriction base="string">
+            <maxLength value="8"/>
+            <pattern value="[A-Z]+"/>
+          </restriction>
+        </simpleType>
+      </element>
+      <element name="released">
+        <simpleType>
+          <restriction base="date">
+            <minInclusive value=" 2000-01-01 "/>
+          </restriction>
+        </simpleType>
+      </element>
+    </sequence>
+    <attribute name="quantity">
+      <simpleType>
+        <restriction base="int">
+          <minInclusive value=" 1 "/>
+          <maxExclusive value="many"/>
+        </restriction>
+      </simpleType>
+    </attribute>
+  </complexType>
+</schema>`), 0644))
+
+	set, err := NewParser(&Options{Lang: "Go"}).Load(file)
+	require.NoError(t, err)
+	itemType := set.Schemas[0].ProtoTree[0].(*ComplexType)
+	require.Len(t, itemType.Elements, 2)
+	sku := itemType.Elements[0]
+	assert.Equal(t, "string", sku.Type)
+	require.NotNil(t, sku.Restriction)
+	assert.Equal(t, 8, *sku.Restriction.MaxLength)
+	assert.Equal(t, []string{"[A-Z]+"}, sku.Restriction.Patterns)
+	require.NotNil(t, itemType.Elements[1].Restriction)
+	assert.Equal(t, "2000-01-01", itemType.Elements[1].Restriction.MinValue)
+	require.Len(t, itemType.Attributes, 1)
+	quantity := itemType.Attributes[0]
+	assert.Equal(t, "int", quantity.Type)
+	require.NotNil(t, quantity.Restriction)
+	assert.Equal(t, "1", quantity.Restriction.MinValue)
+	assert.Equal(t, 1.0, quantity.Restriction.Min)
+	assert.Equal(t, "", quantity.Restriction.MaxValue)
+	require.Len(t, set.Diagnostics, 1)
+	assert.Equal(t, DiagnosticInvalid, set.Diagnostics[0].Code)
+	assert.Equal(t, file+":24:11: invalid maxExclusive facet value \"many\"", set.Diagnostics[0].Error())
+}
+
+func TestParseOccurrence(t *testing.T) {
+	protoTree := parseSchemaString(t, `<schema xmlns="http://www.w3.org/2001/XMLSchema">
+  <group name="extra">
+    <sequence>
+      <element name="note" type="string"/>
+    </sequence>
+  </group>
+  <complexType name="order">
+    <sequence>
+      <element name="id" type="string"/>
+      <element name="comment" type="string" minOccurs="0"/>
+      <element name="line" type="string" maxOccurs="unbounded"/>
+      <element name="tag" type="string" minOccurs="0" maxOccurs="3"/>
+      <element name="single" type="string" maxOccurs="1"/>
+      <group ref="extra" maxOccurs="1"/>
+      <choice minOccurs="0" maxOccurs="2">
+        <element name="phone" type="string"/>
+      </choice>
+    </sequence>
+  </complexType>
+</schema>`)
+	require.Len(t, protoTree, 2)
+	order := protoTree[1].(*ComplexType)
+	require.Len(t, order.Elements, 6)
+
+	for _, c := range []struct {
+		name                 string
+		minOccurs, maxOccurs int
+		optional, plural     bool
+	}{
+		{"id", 1, 1, false, false},
+		{"comment", 0, 1, true, false},
+		{"line", 1, Unbounded, false, true},
+		{"tag", 0, 3, true, true},
+		{"single", 1, 1, false, false},
+		{"phone", 0, 2, true, true},
+	} {
+		element, _ := findElement(&Element{Name: c.name}, order.Elements)
+		require.NotNil(t, element, c.name)
+		assert.Equal(t, c.minOccurs, element.MinOccurs, c.name)
+		assert.Equal(t, c.maxOccurs, element.MaxOccurs, c.name)
+		assert.Equal(t, c.optional, element.Optional, c.name)
+		assert.Equal(t, c.plural, element.Plural, c.name)
+	}
+
+	require.Len(t, order.Groups, 1)
+	assert.Equal(t, 1, order.Groups[0].MaxOccurs)
+	assert.False(t, order.Groups[0].Plural)
+	assert.False(t, order.Groups[0].Optional)
+}
+
+func TestParseCompositorOccurrence(t *testing.T) {
+	protoTree := parseSchemaString(t, `<schema xmlns="http://www.w3.org/2001/XMLSchema">
+  <complexType name="manifest">
+    <sequence maxOccurs="unbounded">
+      <element name="code" type="string"/>
+      <sequence minOccurs="0">
+        <element name="note" type="string"/>
+      </sequence>
+      <choice>
+        <sequence>
+          <element name="weight" type="int"/>
+        </sequence>
+        <element name="volume" type="int"/>
+      </choice>
+      <group ref="extra"/>
+    </sequence>
+  </complexType>
+</schema>`)
+	require.Len(t, protoTree, 1)
+	manifest := protoTree[0].(*ComplexType)
+	for _, c := range []struct {
+		name                 string
+		minOccurs, maxOccurs int
+		optional, plural     bool
+	}{
+		{"code", 1, Unbounded, false, true},
+		{"note", 0, Unbounded, true, true},
+		{"weight", 0, Unbounded, true, true},
+		{"volume", 0, Unbounded, true, true},
+	} {
+		element, _ := findElement(&Element{Name: c.name}, manifest.Elements)
+		require.NotNil(t, element, c.name)
+		assert.Equal(t, c.minOccurs, element.MinOccurs, c.name)
+		assert.Equal(t, c.maxOccurs, element.MaxOccurs, c.name)
+		assert.Equal(t, c.optional, element.Optional, c.name)
+		assert.Equal(t, c.plural, element.Plural, c.name)
+	}
+	require.Len(t, manifest.Groups, 1)
+	assert.Equal(t, "extra", manifest.Groups[0].Name)
+	assert.Equal(t, Unbounded, manifest.Groups[0].MaxOccurs)
+	assert.True(t, manifest.Groups[0].Plural)
+	assert.False(t, manifest.Groups[0].Optional)
+}
+
+func TestParseQualifiedNames(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xgen-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "a.xsd"), []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:a="urn:a" targetNamespace="urn:a">
+  <xs:import namespace="urn:b" schemaLocation="b.xsd"/>
+  <xs:simpleType name="AddressType">
+    <xs:restriction base="xs:string"/>
+  </xs:simpleType>
+  <xs:complexType name="Customer" xmlns:remote="urn:b">
+    <xs:sequence>
+      <xs:element name="local" type="a:AddressType"/>
+      <xs:element name="remote" type="remote:AddressType"/>
+    </xs:sequence>
+  </xs:complexType>
+</xs:schema>`), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "b.xsd"), []byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:b">
+  <complexType name="AddressType">
+    <sequence>
+      <element name="street" type="string"/>
+    </sequence>
+  </complexType>
+</schema>`), 0644))
+
+	set, err := NewParser(&Options{Lang: "Go"}).Load(filepath.Join(dir, "a.xsd"))
+	require.NoError(t, err)
+	protoTree := set.Schema(filepath.Join(dir, "a.xsd")).ProtoTree
+	require.Len(t, protoTree, 2)
+
+	addressType := protoTree[0].(*SimpleType)
+	assert.Equal(t, "urn:a", addressType.TargetNamespace)
+	customer := protoTree[1].(*ComplexType)
+	assert.Equal(t, "urn:a", customer.TargetNamespace)
+	require.Len(t, customer.Elements, 2)
+	assert.Equal(t, "string", customer.Elements[0].Type)
+	assert.Equal(t, "", customer.Elements[0].TypeNamespace)
+	assert.Equal(t, "AddressType", customer.Elements[1].Type)
+	assert.Equal(t, "urn:b", customer.Elements[1].TypeNamespace)
+}
+
+func TestGenerateQualifiedNames(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xgen-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "a.xsd"), []byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema" xmlns:a="urn:a" xmlns:b="urn:b" targetNamespace="urn:a">
+  <import namespace="urn:b" schemaLocation="b.xsd"/>
+  <complexType name="AddressType">
+    <sequence>
+      <element name="City" type="string"/>
+    </sequence>
+  </complexType>
+  <complexType name="Customer">
+    <sequence>
+      <element name="Home" type="a:AddressType"/>
+      <element name="Office" type="b:AddressType"/>
+      <element ref="b:note"/>
+    </sequence>
+  </complexType>
+</schema>`), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "b.xsd"), []byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:b">
+  <complexType name="AddressType">
+    <sequence>
+      <element name="Street" type="string"/>
+    </sequence>
+  </complexType>
+  <element name="note" type="string"/>
+</schema>`), 0644))
+
+	outputDir := filepath.Join(dir, "output")
+	parser := NewParser(&Options{
+		InputDir:  dir,
+		OutputDir: outputDir,
+		Lang:      "Go",
+		Package:   "schema",
+	})
+	set, err := parser.Load(filepath.Join(dir, "a.xsd"))
+	require.NoError(t, err)
+	require.NoError(t, parser.Generate(set))
+
+	a, err := ioutil.ReadFile(filepath.Join(outputDir, "a.xsd.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(a), "type AAddressType struct {")
+	assert.Contains(t, string(a), "Home   *AAddressType `xml:\"Home\"`")
+	assert.Contains(t, string(a), "Office *BAddressType `xml:\"Office\"`")
+	assert.Contains(t, string(a), "Note   string        `xml:\"urn:b note\"`")
+	b, err := ioutil.ReadFile(filepath.Join(outputDir, "b.xsd.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(b), "type BAddressType struct {")
+	assert.Contains(t, string(b), "type Note string")
+}
+
+func TestLoad(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xgen-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "a.xsd"), []byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema" xmlns:b="urn:b" targetNamespace="urn:a">
+  <import namespace="urn:b" schemaLocation="b.xsd"/>
+  <element name="root" type="b:rootType"/>
+</schema>`), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "b.xsd"), []byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:b">
+  <complexType name="rootType">
+    <attribute name="id" type="string"/>
+  </complexType>
+</schema>`), 0644))
+
+	outputDir := filepath.Join(dir, "output")
+	parser := NewParser(&Options{
+		InputDir:  dir,
+		OutputDir: outputDir,
+		Lang:      "Go",
+		Package:   "schema",
+	})
+	set, err := parser.Load(filepath.Join(dir, "a.xsd"))
+	require.NoError(t, err)
+	require.Len(t, set.Schemas, 2)
+	assert.Equal(t, filepath.Join(dir, "a.xsd"), set.Schemas[0].FilePath)
+	assert.Equal(t, "urn:a", set.Schemas[0].TargetNamespace)
+	assert.Len(t, set.Schemas[0].ProtoTree, 1)
+	assert.Equal(t, filepath.Join(dir, "b.xsd"), set.Schemas[1].FilePath)
+	assert.Equal(t, "urn:b", set.Schemas[1].TargetNamespace)
+	assert.Len(t, set.Schemas[1].ProtoTree, 1)
+	_, err = os.Stat(outputDir)
+	assert.True(t, os.IsNotExist(err))
+
+	require.NoError(t, parser.Generate(set))
+	for _, name := range []string{"a.xsd.go", "b.xsd.go"} {
+		_, err = os.Stat(filepath.Join(outputDir, name))
+		assert.NoError(t, err, name)
+	}
+
+	_, err = parser.Load(filepath.Join(dir, "missing.xsd"))
+	assert.Error(t, err)
+}
+
+func TestGenerateSingleFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xgen-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "xsd", "common"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "xsd", "a.xsd"), []byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema" xmlns:b="urn:b" targetNamespace="urn:a">
+  <import namespace="urn:b" schemaLocation="common/b.xsd"/>
+  <element name="root" type="b:rootType"/>
+</schema>`), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "xsd", "common", "b.xsd"), []byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:b">
+  <complexType name="rootType">
+    <attribute name="id" type="string"/>
+  </complexType>
+</schema>`), 0644))
+
+	input, output := filepath.Join(dir, "xsd", "a.xsd"), filepath.Join(dir, "out", "a")
+	parser := NewParser(&Options{InputDir: input, OutputDir: output, Lang: "Go"})
+	set, err := parser.Load(input)
+	require.NoError(t, err)
+	require.NoError(t, parser.Generate(set))
+	for _, name := range []string{"a.go", filepath.Join("a", "common", "b.xsd.go")} {
+		_, err = os.Stat(filepath.Join(dir, "out", name))
+		assert.NoError(t, err, name)
+	}
+	_, err = os.Stat(filepath.Join(dir, "out", "common"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestLoadSchemaGraph(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xgen-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"a.xsd": `<schema xmlns="http://www.w3.org/2001/XMLSchema" xmlns:a="urn:a" xmlns:c="urn:c" targetNamespace="urn:a">
+  <include schemaLocation="b.xsd"/>
+  <import namespace="urn:c" schemaLocation="c.xsd"/>
+  <import namespace="urn:d"/>
+  <element name="root">
+    <complexType>
+      <attribute name="code" type="a:code"/>
+      <attribute name="name" type="c:name"/>
+    </complexType>
+  </element>
+</schema>`,
+		"b.xsd": `<schema xmlns="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:a">
+  <include schemaLocation="a.xsd"/>
+  <simpleType name="code">
+    <restriction base="int"/>
+  </simpleType>
+</schema>`,
+		"c.xsd": `<schema xmlns="http://www.w3.org/2001/XMLSchema" xmlns:a="urn:a" targetNamespace="urn:c">
+  <import namespace="urn:a" schemaLocation="a.xsd"/>
+  <simpleType name="name">
+    <restriction base="string"/>
+  </simpleType>
+</schema>`,
+	}
+	for name, content := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	parses := map[string]int{}
+	parser := NewParser(&Options{InputDir: dir, Lang: "Go"})
+	parser.RegisterHandler(xml.Name{Space: xsdNamespace, Local: "schema"}, ElementHandler{
+		Start: func(opt *Options, ele xml.StartElement, protoTree []interface{}) error {
+			parses[opt.FilePath]++
+			return opt.OnSchema(ele, protoTree)
+		},
+	})
+	set, err := parser.Load(dir)
+	require.NoError(t, err)
+	assert.Empty(t, set.Diagnostics)
+	a, b, c := filepath.Join(dir, "a.xsd"), filepath.Join(dir, "b.xsd"), filepath.Join(dir, "c.xsd")
+	assert.Equal(t, map[string]int{a: 1, b: 1, c: 1}, parses)
+	assert.Equal(t, [][]string{{a, b, a}, {a, c, a}}, set.Cycles)
+
+	require.Len(t, set.Schemas, 3)
+	assert.Equal(t, []*SchemaReference{
+		{Kind: "include", SchemaLocation: "b.xsd", FilePath: b},
+		{Kind: "import", Namespace: "urn:c", SchemaLocation: "c.xsd", FilePath: c},
+		{Kind: "import", Namespace: "urn:d"},
+	}, set.Schema(a).References)
+	assert.Equal(t, []*SchemaReference{
+		{Kind: "include", SchemaLocation: "a.xsd", FilePath: a},
+	}, set.Schema(b).References)
+	assert.Nil(t, set.Schema(filepath.Join(dir, "missing.xsd")))
+
+	root := set.Schema(a).ProtoTree[0].(*ComplexType)
+	require.Len(t, root.Attributes, 2)
+	assert.Equal(t, "int", root.Attributes[0].Type)
+	assert.Equal(t, "string", root.Attributes[1].Type)
+}
+
+func TestLoadChameleonInclude(t *testing.T) {
+	dir := filepath.Join(testFixtureDir, "xsd")
+	set, err := NewParser(&Options{Lang: "Go"}).Load(filepath.Join(dir, "order.xsd"), filepath.Join(dir, "invoice.xsd"))
+	require.NoError(t, err)
+	assert.Empty(t, set.Diagnostics)
+	require.Len(t, set.Schemas, 3)
+
+	common := set.Schema(filepath.Join(dir, "chameleon.xsd"))
+	require.NotNil(t, common)
+	assert.Equal(t, "", common.TargetNamespace)
+	assert.Equal(t, "", common.ProtoTree[0].(*SimpleType).TargetNamespace)
+	require.Len(t, common.Chameleons, 2)
+	for _, ns := range []string{"urn:order", "urn:invoice"} {
+		chameleon := common.Chameleons[ns]
+		require.NotNil(t, chameleon, ns)
+		assert.Equal(t, ns, chameleon.TargetNamespace)
+		require.Len(t, chameleon.ProtoTree, 3)
+		assert.Equal(t, ns, chameleon.ProtoTree[0].(*SimpleType).TargetNamespace)
+		assert.Equal(t, ns, chameleon.ProtoTree[2].(*ComplexType).TargetNamespace)
+	}
+
+	order := set.Schema(filepath.Join(dir, "order.xsd"))
+	assert.Equal(t, []*SchemaReference{{Kind: "include", Namespace: "urn:order", SchemaLocation: "chameleon.xsd", FilePath: common.FilePath}}, order.References)
+	orderType := order.ProtoTree[0].(*ComplexType)
+	assert.Equal(t, "int", orderType.Attributes[0].Type)
+	assert.Equal(t, "AddressType", orderType.Elements[0].Type)
+	assert.Equal(t, "urn:order", orderType.Elements[0].TypeNamespace)
+
+	invoiceType := set.Schema(filepath.Join(dir, "invoice.xsd")).ProtoTree[0].(*ComplexType)
+	assert.Equal(t, "float64", invoiceType.Attributes[0].Type)
+	assert.Equal(t, "urn:invoice", invoiceType.Elements[0].TypeNamespace)
+}
+
+func TestLoadChameleonParsedOnce(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xgen-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"a.xsd": `<schema xmlns="http://www.w3.org/2001/XMLSchema" xmlns:a="urn:a" targetNamespace="urn:a">
+  <include schemaLocation="common.xsd"/>
+  <element name="a" type="a:code"/>
+</schema>`,
+		"b.xsd": `<schema xmlns="http://www.w3.org/2001/XMLSchema" xmlns:b="urn:b" targetNamespace="urn:b">
+  <include schemaLocation="common.xsd"/>
+  <element name="b" type="b:name"/>
+</schema>`,
+		"common.xsd": `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:ext="urn:ext">
+  <xs:include schemaLocation="name.xsd"/>
+  <ext:marker/>
+  <xs:simpleType name="code">
+    <xs:restriction base="name"/>
+  </xs:simpleType>
+</xs:schema>`,
+		"name.xsd": `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+  <xs:simpleType name="name">
+    <xs:restriction base="xs:string"/>
+  </xs:simpleType>
+</xs:schema>`,
+	}
+	for name, content := range files {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	var parsed int
+	parser := NewParser(&Options{Lang: "Go"})
+	parser.RegisterHandler(xml.Name{Space: "urn:ext", Local: "marker"}, ElementHandler{
+		Start: func(opt *Options, ele xml.StartElement, protoTree []interface{}) error {
+			parsed++
+			return nil
+		},
+	})
+	set, err := parser.Load(dir)
+	require.NoError(t, err)
+	assert.Empty(t, set.Diagnostics)
+	assert.Equal(t, 1, parsed)
+
+	common, name := set.Schema(filepath.Join(dir, "common.xsd")), set.Schema(filepath.Join(dir, "name.xsd"))
+	require.Len(t, common.Chameleons, 2)
+	require.Len(t, name.Chameleons, 2)
+	for _, ns := range []string{"urn:a", "urn:b"} {
+		code := common.Chameleons[ns].ProtoTree[0].(*SimpleType)
+		assert.Equal(t, ns, code.TargetNamespace)
+		assert.Equal(t, "string", code.Base)
+		assert.Empty(t, code.BaseNamespace)
+		assert.Equal(t, ns, common.Chameleons[ns].References[0].Namespace)
+		assert.Equal(t, ns, name.Chameleons[ns].ProtoTree[0].(*SimpleType).TargetNamespace)
+	}
+	assert.Empty(t, common.ProtoTree[0].(*SimpleType).TargetNamespace)
+	assert.Equal(t, "string", set.Schema(filepath.Join(dir, "a.xsd")).ProtoTree[0].(*Element).Type)
+}
+
+func TestLoadComplexTypeDerivation(t *testing.T) {
+	set, err := NewParser(&Options{Lang: "Go"}).Load(filepath.Join(testFixtureDir, "xsd", "restriction.xsd"))
+	require.NoError(t, err)
+	require.Len(t, set.Schemas, 1)
+	complexTypes := map[string]*ComplexType{}
+	for _, ele := range set.Schemas[0].ProtoTree {
+		if complexType, ok := ele.(*ComplexType); ok {
+			complexTypes[complexType.Name] = complexType
+		}
+	}
+
+	contact := complexTypes["RequiredContactType"]
+	assert.Equal(t, DerivationRestriction, contact.Derivation)
+	assert.Equal(t, "ContactType", contact.Base)
+	assert.Equal(t, "", contact.ValueType)
+	require.Len(t, contact.Attributes, 2)
+	assert.Equal(t, "id", contact.Attributes[0].Name)
+	assert.False(t, contact.Attributes[0].Optional)
+	assert.Equal(t, "version", contact.Attributes[1].Name)
+	require.Len(t, contact.Elements, 1)
+	assert.Equal(t, "Name", contact.Elements[0].Name)
+
+	price := complexTypes["PriceType"]
+	assert.Equal(t, DerivationExtension, price.Derivation)
+	assert.Equal(t, "float64", price.ValueType)
+
+	euroPrice := complexTypes["EuroPriceType"]
+	assert.Equal(t, DerivationRestriction, euroPrice.Derivation)
+	assert.Equal(t, "PriceType", euroPrice.Base)
+	assert.Equal(t, "float64", euroPrice.ValueType)
+	require.Len(t, euroPrice.Attributes, 1)
+	assert.False(t, euroPrice.Attributes[0].Optional)
+}
+
+func TestParseHandlers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xgen-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "schema.xsd")
+	require.NoError(t, ioutil.WriteFile(file, []byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema" xmlns:ext="urn:ext">
+  <notation name="jpeg" public="image/jpeg"/>
+  <ext:marker name="custom"/>
+  <element name="root" type="string"/>
+</schema>`), 0644))
+
+	var markers []string
+	parser := NewParser(&Options{Lang: "Go"})
+	parser.RegisterHandler(xml.Name{Space: "urn:ext", Local: "marker"}, ElementHandler{
+		Start: func(opt *Options, ele xml.StartElement, protoTree []interface{}) error {
+			for _, attr := range ele.Attr {
+				if attr.Name.Local == "name" {
+					markers = append(markers, attr.Value)
+				}
+			}
+			return nil
+		},
+	})
+	set, err := parser.Load(file)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"custom"}, markers)
+	require.Len(t, set.Schemas, 1)
+	assert.Len(t, set.Schemas[0].ProtoTree, 1)
+	require.Len(t, set.Diagnostics, 1)
+	assert.Equal(t, file, set.Diagnostics[0].File)
+	assert.Equal(t, DiagnosticUnknownElement, set.Diagnostics[0].Code)
+	assert.Equal(t, 2, set.Diagnostics[0].Line)
+	assert.Equal(t, 3, set.Diagnostics[0].Column)
+	assert.Equal(t, file+":2:3: unknown XSD element <notation>", set.Diagnostics[0].Error())
+}
+
+func TestParseWildcards(t *testing.T) {
+	protoTree := parseSchemaString(t, `<schema xmlns="http://www.w3.org/2001/XMLSchema">
+  <complexType name="extensible">
+    <sequence>
+      <element name="id" type="string"/>
+      <any namespace="##other" processContents="lax" minOccurs="0" maxOccurs="unbounded"/>
+    </sequence>
+    <anyAttribute namespace="urn:a urn:b" processContents="skip"/>
+  </complexType>
+  <attributeGroup name="open">
+    <anyAttribute/>
+  </attributeGroup>
+</schema>`)
+	require.Len(t, protoTree, 2)
+
+	complexType := protoTree[0].(*ComplexType)
+	require.Len(t, complexType.Elements, 2)
+	assert.Nil(t, complexType.Elements[0].Wildcard)
+	wildcard := complexType.Elements[1]
+	assert.Equal(t, &Wildcard{Namespace: "##other", ProcessContents: "lax"}, wildcard.Wildcard)
+	assert.Equal(t, 0, wildcard.MinOccurs)
+	assert.Equal(t, Unbounded, wildcard.MaxOccurs)
+	assert.True(t, wildcard.Optional)
+	assert.True(t, wildcard.Plural)
+	assert.Equal(t, &Wildcard{Namespace: "urn:a urn:b", ProcessContents: "skip"}, complexType.AnyAttribute)
+
+	attributeGroup := protoTree[1].(*AttributeGroup)
+	assert.Equal(t, &Wildcard{Namespace: "##any", ProcessContents: "strict"}, attributeGroup.AnyAttribute)
+}
+
+func TestLoadSubstitutionGroups(t *testing.T) {
+	set, err := NewParser(&Options{Lang: "Go"}).Load(filepath.Join(testFixtureDir, "xsd", "substitution.xsd"))
+	require.NoError(t, err)
+	require.Len(t, set.Schemas, 1)
+	require.Len(t, set.Schemas[0].GlobalElements, 8)
+
+	shape := set.Schemas[0].GlobalElements[0]
+	assert.True(t, shape.Abstract)
+	circle := set.Schemas[0].GlobalElements[1]
+	assert.Equal(t, "Shape", circle.SubstitutionGroup)
+	assert.Equal(t, "http://example.org/substitution", circle.SubstitutionGroupNamespace)
+
+	names := func(elements []*Element) (names []string) {
+		for _, e := range elements {
+			names = append(names, e.Name)
+		}
+		return
+	}
+	require.Len(t, set.SubstitutionGroups, 3)
+	group := set.SubstitutionGroups[xml.Name{Space: "http://example.org/substitution", Local: "Shape"}]
+	require.NotNil(t, group)
+	assert.Equal(t, shape, group.Head)
+	assert.Equal(t, []string{"Circle", "Square", "RoundedSquare"}, names(group.Elements))
+	group = set.SubstitutionGroups[xml.Name{Space: "http://example.org/substitution", Local: "Square"}]
+	require.NotNil(t, group)
+	assert.Equal(t, []string{"Square", "RoundedSquare"}, names(group.Elements))
+	group = set.SubstitutionGroups[xml.Name{Space: "http://example.org/substitution", Local: "Label"}]
+	require.NotNil(t, group)
+	assert.Equal(t, []string{"Caption"}, names(group.Elements))
+}
+
+func TestParseIdentityConstraints(t *testing.T) {
+	protoTree := parseSchemaString(t, `<schema xmlns="http://www.w3.org/2001/XMLSchema" xmlns:p="urn:p" targetNamespace="urn:p">
+  <complexType name="library">
+    <sequence>
+      <element name="shelf" type="string" maxOccurs="unbounded">
+        <unique name="shelfBook">
+          <selector xpath="book"/>
+          <field xpath="@isbn"/>
+        </unique>
+      </element>
+    </sequence>
+  </complexType>
+  <element name="catalog" type="p:library">
+    <key name="book">
+      <selector xpath=".//book"/>
+      <field xpath="@isbn"/>
+      <field xpath="edition"/>
+    </key>
+    <keyref name="loan" refer="p:book">
+      <selector xpath="loan"/>
+      <field xpath="@isbn"/>
+      <field xpath="@edition"/>
+    </keyref>
+  </element>
+</schema>`)
+	require.Len(t, protoTree, 2)
+
+	complexType := protoTree[0].(*ComplexType)
+	require.Len(t, complexType.Elements, 1)
+	assert.Equal(t, []IdentityConstraint{
+		{Name: "shelfBook", TargetNamespace: "urn:p", Category: "unique", Selector: "book", Fields: []string{"@isbn"}},
+	}, complexType.Elements[0].IdentityConstraints)
+
+	element := protoTree[1].(*Element)
+	assert.Equal(t, []IdentityConstraint{
+		{Name: "book", TargetNamespace: "urn:p", Category: "key", Selector: ".//book", Fields: []string{"@isbn", "edition"}},
+		{Name: "loan", TargetNamespace: "urn:p", Category: "keyref", Selector: "loan", Fields: []string{"@isbn", "@edition"}, Refer: "book", ReferNamespace: "urn:p"},
+	}, element.IdentityConstraints)
+}
+
+func TestLoadRedefine(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xgen-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "base.xsd"), []byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:lib">
+  <simpleType name="code">
+    <restriction base="string">
+      <maxLength value="10"/>
+    </restriction>
+  </simpleType>
+  <complexType name="address">
+    <sequence>
+      <element name="street" type="string"/>
+    </sequence>
+  </complexType>
+  <group name="contact">
+    <sequence>
+      <element name="email" type="string"/>
+    </sequence>
+  </group>
+  <attributeGroup name="audit">
+    <attribute name="created" type="dateTime"/>
+  </attributeGroup>
+  <complexType name="phone">
+    <sequence>
+      <element name="number" type="string"/>
+      <element name="extension" type="string" minOccurs="0"/>
+    </sequence>
+    <attribute name="kind" type="string"/>
+  </complexType>
+</schema>`), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "redefine.xsd"), []byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema" xmlns:lib="urn:lib" targetNamespace="urn:lib">
+  <redefine schemaLocation="base.xsd">
+    <simpleType name="code">
+      <restriction base="lib:code">
+        <enumeration value="A"/>
+        <enumeration value="B"/>
+      </restriction>
+    </simpleType>
+    <complexType name="address">
+      <complexContent>
+        <extension base="lib:address">
+          <sequence>
+            <element name="city" type="string"/>
+          </sequence>
+        </extension>
+      </complexContent>
+    </complexType>
+    <group name="contact">
+      <sequence>
+        <group ref="lib:contact"/>
+        <element name="phone" type="string"/>
+      </sequence>
+    </group>
+    <attributeGroup name="audit">
+      <attributeGroup ref="lib:audit"/>
+      <attribute name="modified" type="dateTime"/>
+    </attributeGroup>
+    <complexType name="phone">
+      <complexContent>
+        <restriction base="lib:phone">
+          <sequence>
+            <element name="number" type="string"/>
+          </sequence>
+        </restriction>
+      </complexContent>
+    </complexType>
+    <attributeGroup name="missing"/>
+  </redefine>
+  <element name="office" type="lib:address"/>
+</schema>`), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "override.xsd"), []byte(`<schema xmlns="http://www.w3.org/2001/XMLSchema" xmlns:lib="urn:lib" targetNamespace="urn:lib">
+  <override schemaLocation="base.xsd">
+    <complexType name="address">
+      <sequence>
+        <element name="line" type="string" maxOccurs="3"/>
+      </sequence>
+    </complexType>
+    <attributeGroup name="missing"/>
+  </override>
+</schema>`), 0644))
+
+	set, err := NewParser(&Options{Lang: "Go"}).Load(filepath.Join(dir, "redefine.xsd"))
+	require.NoError(t, err)
+	require.Len(t, set.Schemas, 2)
+	require.Len(t, set.Diagnostics, 1)
+	assert.Equal(t, DiagnosticRedefineNotFound, set.Diagnostics[0].Code)
+	assert.Equal(t, "redefined component missing not found in base.xsd", set.Diagnostics[0].Message)
+	assert.Len(t, set.Schemas[1].ProtoTree, 1)
+	assert.Len(t, set.Schemas[1].Redefines, 1)
+
+	base := set.Schemas[0].ProtoTree
+	require.Len(t, base, 5)
+	simpleType := base[0].(*SimpleType)
+	assert.Equal(t, "string", simpleType.Base)
+	assert.Equal(t, []string{"A", "B"}, simpleType.Restriction.Enum)
+	assert.Equal(t, 10, *simpleType.Restriction.MaxLength)
+	complexType := base[1].(*ComplexType)
+	assert.Empty(t, complexType.Base)
+	require.Len(t, complexType.Elements, 2)
+	assert.Equal(t, "street", complexType.Elements[0].Name)
+	assert.Equal(t, "city", complexType.Elements[1].Name)
+	group := base[2].(*Group)
+	assert.Empty(t, group.Groups)
+	require.Len(t, group.Elements, 2)
+	assert.Equal(t, "email", group.Elements[0].Name)
+	assert.Equal(t, "phone", group.Elements[1].Name)
+	attributeGroup := base[3].(*AttributeGroup)
+	assert.Empty(t, attributeGroup.Ref)
+	require.Len(t, attributeGroup.Attributes, 2)
+	assert.Equal(t, "created", attributeGroup.Attributes[0].Name)
+	assert.Equal(t, "modified", attributeGroup.Attributes[1].Name)
+	complexType = base[4].(*ComplexType)
+	assert.Empty(t, complexType.Base)
+	assert.Empty(t, complexType.Derivation)
+	require.Len(t, complexType.Elements, 1)
+	assert.Equal(t, "number", complexType.Elements[0].Name)
+	require.Len(t, complexType.Attributes, 1)
+	assert.Equal(t, "kind", complexType.Attributes[0].Name)
+
+	set, err = NewParser(&Options{Lang: "Go"}).Load(filepath.Join(dir, "override.xsd"))
+	require.NoError(t, err)
+	require.Len(t, set.Schemas, 2)
+	assert.Empty(t, set.Diagnostics)
+	complexType = set.Schemas[0].ProtoTree[1].(*ComplexType)
+	require.Len(t, complexType.Elements, 1)
+	assert.Equal(t, "line", complexType.Elements[0].Name)
+	assert.True(t, complexType.Elements[0].Plural)
+}
+
+func TestLoadXSD11(t *testing.T) {
+	file := filepath.Join(testFixtureDir, "xsd", "xsd11.xsd")
+	set, err := NewParser(&Options{Lang: "Go"}).Load(file)
+	require.NoError(t, err)
+	require.Len(t, set.Schemas, 1)
+	protoTree := set.Schemas[0].ProtoTree
+	require.Len(t, protoTree, 6)
+
+	simpleType := protoTree[0].(*SimpleType)
+	assert.Equal(t, []Assertion{{Test: "$value mod 2 = 0"}}, simpleType.Restriction.Assertions)
+	rangeType := protoTree[1].(*ComplexType)
+	assert.Equal(t, []Assertion{{Test: "@min le @max"}}, rangeType.Assertions)
+	assert.Nil(t, rangeType.OpenContent)
+	vehicleType := protoTree[2].(*ComplexType)
+	assert.Equal(t, &OpenContent{Mode: "interleave", Wildcard: &Wildcard{Namespace: "##any", ProcessContents: "skip"}}, vehicleType.OpenContent)
+	require.Len(t, vehicleType.Elements, 1)
+	garageType := protoTree[4].(*ComplexType)
+	assert.Equal(t, &OpenContent{Mode: "suffix", Wildcard: &Wildcard{Namespace: "##other", ProcessContents: "lax"}}, garageType.OpenContent)
+	require.Len(t, garageType.Elements, 1)
+	assert.Equal(t, "Range", garageType.Elements[0].Name)
+	vehicle := protoTree[5].(*Element)
+	assert.Equal(t, []Alternative{{Test: "@kind = 'truck'", Type: "TruckType"}, {Type: "VehicleType"}}, vehicle.Alternatives)
+
+	set, err = NewParser(&Options{Lang: "Go", XSDVersion: "1.0"}).Load(file)
+	require.NoError(t, err)
+	require.Len(t, set.Schemas, 1)
+	assert.Empty(t, set.Schemas[0].ProtoTree)
+
+	protoTree = parseSchemaString(t, `<schema xmlns="http://www.w3.org/2001/XMLSchema" xmlns:vc="http://www.w3.org/2007/XMLSchema-versioning">
+  <element name="a" type="string" vc:typeAvailable="string dateTimeStamp"/>
+  <element name="b" type="dateTime" vc:typeUnavailable="dateTimeStamp"/>
+  <element name="c" type="string" vc:facetAvailable="pattern"/>
+  <element name="d" type="string" vc:facetAvailable="explicitTimezone"/>
+  <element name="e" type="string">
+    <alternative test="@x">
+      <simpleType>
+        <restriction base="string"/>
+      </simpleType>
+    </alternative>
+  </element>
+</schema>`)
+	require.Len(t, protoTree, 3)
+	assert.Equal(t, "b", protoTree[0].(*Element).Name)
+	assert.Equal(t, "c", protoTree[1].(*Element).Name)
+	assert.Equal(t, "e", protoTree[2].(*Element).Name)
+	assert.Empty(t, protoTree[2].(*Element).Alternatives)
+}
+
+func TestLoadRemote(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xgen-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	documents := map[string]string{
+		"/schemas/a.xsd": `<schema xmlns="http://www.w3.org/2001/XMLSchema" xmlns:b="urn:b" targetNamespace="urn:a">
+  <import namespace="urn:b" schemaLocation="b.xsd"/>
+  <complexType name="aType">
+    <attribute name="b" type="b:code"/>
+  </complexType>
+</schema>`,
+		"/schemas/b.xsd": `<schema xmlns="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:b">
+  <simpleType name="code">
+    <restriction base="string"/>
+  </simpleType>
+</schema>`,
+	}
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		document, ok := documents[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprint(w, document)
+	}))
+	defer server.Close()
+
+	file := filepath.Join(dir, "root.xsd")
+	require.NoError(t, ioutil.WriteFile(file, []byte(fmt.Sprintf(`<schema xmlns="http://www.w3.org/2001/XMLSchema" xmlns:a="urn:a" targetNamespace="urn:root">
+  <import namespace="urn:a" schemaLocation="%s/schemas/a.xsd"/>
+  <import namespace="urn:missing" schemaLocation="%s/schemas/missing.xsd"/>
+  <element name="root" type="a:aType"/>
+</schema>`, server.URL, server.URL)), 0644))
+
+	cacheDir := filepath.Join(dir, "cache")
+	cache := &SchemaCache{Dir: cacheDir, Fetcher: &HTTPFetcher{Client: server.Client()}}
+	set, err := NewParser(&Options{Lang: "Go", Cache: cache}).Load(file)
+	require.NoError(t, err)
+	require.Len(t, set.Schemas, 3)
+	urls := map[string]bool{}
+	for _, schema := range set.Schemas {
+		urls[schema.URL] = true
+		if schema.URL != "" {
+			assert.True(t, strings.HasPrefix(schema.FilePath, cacheDir), schema.FilePath)
+		}
+	}
+	assert.Equal(t, map[string]bool{"": true, server.URL + "/schemas/a.xsd": true, server.URL + "/schemas/b.xsd": true}, urls)
+	require.Len(t, set.Diagnostics, 1)
+	assert.Equal(t, DiagnosticUnresolvedLocation, set.Diagnostics[0].Code)
+	assert.Contains(t, set.Diagnostics[0].Message, "missing.xsd")
+	assert.Equal(t, []string{"/schemas/a.xsd", "/schemas/b.xsd", "/schemas/missing.xsd"}, requests)
+	// the documents are renamed into the cache without leaving temporary files
+	cached, err := filepath.Glob(filepath.Join(cacheDir, "schemas", "*"))
+	require.NoError(t, err)
+	assert.Len(t, cached, 2)
+
+	outputDir := filepath.Join(dir, "output")
+	parser := NewParser(&Options{InputDir: dir, OutputDir: outputDir, Lang: "Go", Cache: cache})
+	require.NoError(t, parser.Generate(set))
+	_, err = os.Stat(filepath.Join(outputDir, strings.TrimPrefix(server.URL, "http://"), "schemas", "a.xsd.go"))
+	assert.NoError(t, err)
+
+	// documents are read from the cache without fetching in offline mode.
+	offline := &SchemaCache{Dir: cacheDir, Offline: true, Fetcher: FetcherFunc(func(url string) ([]byte, error) {
+		t.Errorf("unexpected fetch of %s", url)
+		return nil, fmt.Errorf("offline")
+	})}
+	set, err = NewParser(&Options{Lang: "Go", Cache: offline}).Load(file)
+	require.NoError(t, err)
+	assert.Len(t, set.Schemas, 3)
+	require.Len(t, set.Diagnostics, 1)
+	assert.Contains(t, set.Diagnostics[0].Message, "is not cached in offline mode")
+}
+
+func TestLoadRemoteSameContent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xgen-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// the documents with the same content include the documents relative to
+	// their own URL.
+	include := `<schema xmlns="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:a">
+  <include schemaLocation="types.xsd"/>
+</schema>`
+	documents := map[string]string{
+		"/v1/a.xsd": include,
+		"/v2/a.xsd": include,
+		"/v1/types.xsd": `<schema xmlns="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:a">
+  <simpleType name="v1"><restriction base="string"/></simpleType>
+</schema>`,
+		"/v2/types.xsd": `<schema xmlns="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:a">
+  <simpleType name="v2"><restriction base="string"/></simpleType>
+</schema>`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, documents[r.URL.Path])
+	}))
+	defer server.Close()
+
+	file := filepath.Join(dir, "root.xsd")
+	require.NoError(t, ioutil.WriteFile(file, []byte(fmt.Sprintf(`<schema xmlns="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:a">
+  <include schemaLocation="%s/v1/a.xsd"/>
+  <include schemaLocation="%s/v2/a.xsd"/>
+</schema>`, server.URL, server.URL)), 0644))
+
+	cache := &SchemaCache{Dir: filepath.Join(dir, "cache"), Fetcher: &HTTPFetcher{Client: server.Client()}}
+	set, err := NewParser(&Options{Lang: "Go", Cache: cache}).Load(file)
+	require.NoError(t, err)
+	assert.Empty(t, set.Diagnostics)
+	urls := map[string]bool{}
+	for _, schema := range set.Schemas {
+		urls[strings.TrimPrefix(schema.URL, server.URL)] = true
+	}
+	assert.Equal(t, map[string]bool{"": true, "/v1/a.xsd": true, "/v2/a.xsd": true, "/v1/types.xsd": true, "/v2/types.xsd": true}, urls)
+}
+
+func TestLoadCatalog(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xgen-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"catalog.xml": `<catalog xmlns="urn:oasis:names:tc:entity:xmlns:xml:catalog">
+  <uri name="urn:b" uri="vendor/b.xsd"/>
+  <rewriteSystem systemIdStartString="http://example.com/schemas/" rewritePrefix="vendor/"/>
+  <group xml:base="vendor/">
+    <systemSuffix systemIdSuffix="/d.xsd" uri="d.xsd"/>
+  </group>
+  <delegateURI uriStartString="urn:delegated:" catalog="delegate.xml"/>
+  <nextCatalog catalog="next.xml"/>
+</catalog>`,
+		"delegate.xml": `<catalog xmlns="urn:oasis:names:tc:entity:xmlns:xml:catalog">
+  <uri name="urn:delegated:e" uri="vendor/e.xsd"/>
+</catalog>`,
+		"next.xml": `<catalog xmlns="urn:oasis:names:tc:entity:xmlns:xml:catalog">
+  <system systemId="http://other.org/f.xsd" uri="vendor/f.xsd"/>
+  <uri name="urn:delegated:g" uri="vendor/g.xsd"/>
+</catalog>`,
+		"vendor/b.xsd": `<schema xmlns="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:b">
+  <simpleType name="code">
+    <restriction base="string"/>
+  </simpleType>
+</schema>`,
+		"vendor/c.xsd": `<schema xmlns="http://www.w3.org/2001/XMLSchema" targetNamespace="urn:c">
+  <simpleType name="name">
+    <restriction base="string"/>
+  </simpleType>
+</schema>`,
+		"root.xsd": `<schema xmlns="http://www.w3.org/2001/XMLSchema" xmlns:b="urn:b" xmlns:c="urn:c" targetNamespace="urn:root">
+  <import namespace="urn:b"/>
+  <import namespace="urn:c" schemaLocation="http://example.com/schemas/c.xsd"/>
+  <complexType name="rootType">
+    <attribute name="code" type="b:code"/>
+    <attribute name="name" type="c:name"/>
+  </complexType>
+</schema>`,
+	}
+	for name, content := range files {
+		require.NoError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755))
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	catalog, err := LoadCatalog(filepath.Join(dir, "catalog.xml"))
+	require.NoError(t, err)
+	for id, expected := range map[string]string{
+		"urn:b":                                "vendor/b.xsd",
+		"urn:delegated:e":                      "vendor/e.xsd",
+		"urn:delegated:g":                      "",
+		"http://example.com/schemas/sub/c.xsd": "",
+		"http://example.com/schemas/sub/test.xsd": "",
+	} {
+		location, ok := catalog.ResolveURI(id)
+		if expected == "" {
+			assert.False(t, ok, id)
+			continue
+		}
+		assert.Equal(t, filepath.Join(dir, expected), location, id)
+	}
+	for id, expected := range map[string]string{
+		"http://example.com/schemas/sub/c.xsd": "vendor/sub/c.xsd",
+		"http://example.org/schemas/d.xsd":     "vendor/d.xsd",
+		"http://other.org/f.xsd":               "vendor/f.xsd",
+		"http://other.org/g.xsd":               "",
+	} {
+		location, ok := catalog.ResolveSystem(id)
+		if expected == "" {
+			assert.False(t, ok, id)
+			continue
+		}
+		assert.Equal(t, filepath.Join(dir, expected), location, id)
+	}
+
+	set, err := NewParser(&Options{Lang: "Go", Catalog: catalog}).Load(filepath.Join(dir, "root.xsd"))
+	require.NoError(t, err)
+	assert.Empty(t, set.Diagnostics)
+	var paths []string
+	for _, schema := range set.Schemas {
+		paths = append(paths, schema.FilePath)
+	}
+	assert.Equal(t, []string{filepath.Join(dir, "root.xsd"), filepath.Join(dir, "vendor", "b.xsd"), filepath.Join(dir, "vendor", "c.xsd")}, paths)
+	complexType := set.Schemas[0].ProtoTree[0].(*ComplexType)
+	assert.Equal(t, "string", complexType.Attributes[0].Type)
+	assert.Equal(t, "string", complexType.Attributes[1].Type)
+
+	_, err = LoadCatalog(filepath.Join(dir, "missing.xml"))
+	assert.Error(t, err)
+}
+
+func TestLoadAnonymousTypeNaming(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xgen-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "order.xsd")
+	require.NoError(t, ioutil.WriteFile(file, []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+  <xs:element name="Order">
+    <xs:complexType>
+      <xs:sequence>
+        <xs:element name="Line" maxOccurs="unbounded">
+          <xs:complexType>
+            <xs:sequence>
+              <xs:element name="Item">
+                <xs:complexType>
+                  <xs:attribute name="sku" type="xs:string"/>
+                </xs:complexType>
+              </xs:element>
+            </xs:sequence>
+          </xs:complexType>
+        </xs:element>
+      </xs:sequence>
+    </xs:complexType>
+  </xs:element>
+  <xs:complexType name="CatalogType">
+    <xs:sequence>
+      <xs:element name="Item">
+        <xs:complexType>
+          <xs:attribute name="id" type="xs:string"/>
+        </xs:complexType>
+      </xs:element>
+    </xs:sequence>
+  </xs:complexType>
+</xs:schema>`), 0644))
+
+	for naming, expected := range map[string][]string{
+		"":                     {"Item", "Line", "Order", "CatalogTypeItem", "CatalogType"},
+		AnonymousNamingElement: {"Item", "Line", "Order", "CatalogTypeItem", "CatalogType"},
+		AnonymousNamingPath:    {"OrderLineItem", "OrderLine", "Order", "CatalogTypeItem", "CatalogType"},
+		AnonymousNamingSuffix:  {"ItemType", "LineType", "OrderType", "CatalogTypeItemType", "CatalogType"},
+	} {
+		set, err := NewParser(&Options{Lang: "Go", AnonymousTypeNaming: naming}).Load(file)
+		require.NoError(t, err)
+		var names []string
+		complexTypes := map[string]*ComplexType{}
+		for _, ele := range set.Schemas[0].ProtoTree {
+			if complexType, ok := ele.(*ComplexType); ok {
+				names = append(names, complexType.Name)
+				complexTypes[complexType.Parent] = complexType
+			}
+		}
+		assert.Equal(t, expected, names, naming)
+		assert.True(t, complexTypes["Order/Line/Item"].Anonymous, naming)
+		assert.Equal(t, expected[0], complexTypes["Order/Line"].Elements[0].Type, naming)
+		assert.Equal(t, expected[1], complexTypes["Order"].Elements[0].Type, naming)
+		assert.Equal(t, expected[3], complexTypes[""].Elements[0].Type, naming)
+		assert.Equal(t, "CatalogType/Item", complexTypes["CatalogType/Item"].Parent, naming)
+	}
+
+	outputDir := filepath.Join(dir, "output")
+	parser := NewParser(&Options{InputDir: dir, OutputDir: outputDir, Lang: "Go", Package: "schema", AnonymousTypeNaming: AnonymousNamingPath})
+	set, err := parser.Load(file)
+	require.NoError(t, err)
+	require.NoError(t, parser.Generate(set))
+	code, err := ioutil.ReadFile(filepath.Join(outputDir, "order.xsd.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(code), "type OrderLineItem struct")
+	assert.Contains(t, string(code), "type CatalogTypeItem struct")
+	assert.Regexp(t, `Item\s+\*OrderLineItem`, string(code))
+	assert.Regexp(t, `Item\s+\*CatalogTypeItem`, string(code))
+
+	// the anonymous types of the declarations with the same name are kept
+	// apart by the names of the declarations enclosing them
+	parser = NewParser(&Options{InputDir: dir, OutputDir: outputDir, Lang: "Go", Package: "schema", AnonymousTypeNaming: AnonymousNamingSuffix})
+	set, err = parser.Load(file)
+	require.NoError(t, err)
+	require.NoError(t, parser.Generate(set))
+	code, err = ioutil.ReadFile(filepath.Join(outputDir, "order.xsd.go"))
+	require.NoError(t, err)
+	assert.Contains(t, string(code), "type ItemType struct")
+	assert.Contains(t, string(code), "type CatalogTypeItemType struct")
+	assert.Regexp(t, `Item\s+\*ItemType`, string(code))
+	assert.Regexp(t, `Item\s+\*CatalogTypeItemType`, string(code))
+}
+
+func TestParseParticles(t *testing.T) {
+	set, err := NewParser(&Options{Lang: "Go"}).Load(filepath.Join(testFixtureDir, "xsd", "sequence.xsd"))
+	require.NoError(t, err)
+	require.Len(t, set.Schemas, 1)
+	particles := map[string]*Particle{}
+	for _, ele := range set.Schemas[0].ProtoTree {
+		switch v := ele.(type) {
+		case *ComplexType:
+			particles[v.Name] = v.Particle
+		case *Group:
+			particles[v.Name] = v.Particle
+		}
+	}
+
+	assert.Equal(t, &Particle{Kind: ParticleAll, MinOccurs: 1, MaxOccurs: 1, Particles: []*Particle{
+		{Kind: ParticleElement, Name: "Street", MinOccurs: 1, MaxOccurs: 1},
+		{Kind: ParticleElement, Name: "City", MinOccurs: 1, MaxOccurs: 1},
+	}}, particles["PostalAddressType"])
+	assert.Equal(t, &Particle{Kind: ParticleSequence, MinOccurs: 1, MaxOccurs: 1, Particles: []*Particle{
+		{Kind: ParticleElement, Name: "Remark", MinOccurs: 1, MaxOccurs: 1},
+		{Kind: ParticleElement, Name: "Priority", MinOccurs: 0, MaxOccurs: 1},
+	}}, particles["RemarkGroup"])
+	assert.Equal(t, &Particle{Kind: ParticleSequence, MinOccurs: 1, MaxOccurs: 1, Particles: []*Particle{
+		{Kind: ParticleElement, Name: "Number", MinOccurs: 1, MaxOccurs: 1},
+		{Kind: ParticleGroup, Name: "RemarkGroup", MinOccurs: 0, MaxOccurs: 1},
+		{Kind: ParticleSequence, MinOccurs: 1, MaxOccurs: 1, Particles: []*Particle{
+			{Kind: ParticleElement, Name: "Parcel", MinOccurs: 1, MaxOccurs: 1},
+			{Kind: ParticleElement, Name: "Weight", MinOccurs: 1, MaxOccurs: 1},
+		}},
+	}}, particles["WaybillType"])
+	assert.Equal(t, []*Particle{{Kind: ParticleElement, Name: "Account", MinOccurs: 1, MaxOccurs: 1}}, particles["CustomerType"].Particles)
+	assert.Equal(t, []*Particle{
+		{Kind: ParticleElement, Name: "Customer", MinOccurs: 1, MaxOccurs: 1},
+		{Kind: ParticleElement, Name: "Address", MinOccurs: 1, MaxOccurs: 1},
+	}, particles["Shipment"].Particles)
+}
+
+func TestParseChoices(t *testing.T) {
+	set, err := NewParser(&Options{Lang: "Go"}).Load(filepath.Join(testFixtureDir, "xsd", "choice.xsd"))
+	require.NoError(t, err)
+	require.Len(t, set.Schemas, 1)
+	complexTypes := map[string]*ComplexType{}
+	for _, ele := range set.Schemas[0].ProtoTree {
+		if complexType, ok := ele.(*ComplexType); ok {
+			complexTypes[complexType.Name] = complexType
+		}
+	}
+
+	payment := complexTypes["PaymentType"]
+	require.Len(t, payment.Choice, 1)
+	assert.Equal(t, "CardOrTransfer", payment.Choice[0].Name)
+	require.Len(t, payment.Choice[0].Elements, 2)
+	assert.Equal(t, "Card", payment.Choice[0].Elements[0].Name)
+	assert.Equal(t, "Transfer", payment.Choice[0].Elements[1].Name)
+	assert.Equal(t, "TransferType", payment.Choice[0].Elements[1].Type)
+	assert.Equal(t, "CardOrTransfer", payment.Particle.Particles[1].Name)
+
+	channel := complexTypes["ChannelType"]
+	require.Len(t, channel.Choice, 1)
+	assert.Equal(t, "EmailOrPhoneOrFax", channel.Choice[0].Name)
+	require.Len(t, channel.Choice[0].Elements, 1)
+	require.Len(t, channel.Choice[0].Choice, 1)
+	assert.Equal(t, "PhoneOrFax", channel.Choice[0].Choice[0].Name)
+	assert.True(t, channel.Choice[0].Choice[0].Optional)
+
+	gen := &CodeGenerator{SubstitutionGroups: set.SubstitutionGroups}
+	assert.Equal(t, &payment.Choice[0], gen.complexTypeUnion(payment))
+	union := gen.complexTypeUnion(channel)
+	require.NotNil(t, union)
+	assert.Equal(t, "EmailOrPhoneOrFax", union.Name)
+	assert.False(t, union.Optional)
+	assert.Empty(t, union.Choice)
+	require.Len(t, union.Elements, 3)
+	assert.Equal(t, "Email", union.Elements[0].Name)
+	assert.Equal(t, "Phone", union.Elements[1].Name)
+	assert.Equal(t, "Fax", union.Elements[2].Name)
+}
+
+func TestParseMixedContent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xgen-mixed")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "mixed.xsd")
+	require.NoError(t, ioutil.WriteFile(file, []byte(`<?xml version="1.0" encoding="utf-8"?>
+<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+  <xs:complexType name="TextType" mixed="true">
+    <xs:sequence>
+      <xs:element name="em" type="xs:string" minOccurs="0"/>
+    </xs:sequence>
+  </xs:complexType>
+  <xs:complexType name="LabelType">
+    <xs:complexContent>
+      <xs:extension base="TextType"/>
+    </xs:complexContent>
+  </xs:complexType>
+  <xs:complexType name="CodeType">
+    <xs:complexContent mixed="false">
+      <xs:restriction base="TextType"/>
+    </xs:complexContent>
+  </xs:complexType>
+  <xs:complexType name="RecordType">
+    <xs:complexContent mixed="true">
+      <xs:extension base="xs:anyType"/>
+    </xs:complexContent>
+  </xs:complexType>
+</xs:schema>`), 0644))
+
+	set, err := NewParser(&Options{Lang: "Go"}).Load(file)
+	require.NoError(t, err)
+	mixed := map[string]bool{}
+	for _, ele := range set.Schemas[0].ProtoTree {
+		if complexType, ok := ele.(*ComplexType); ok {
+			mixed[complexType.Name] = complexType.Mixed
+		}
+	}
+	assert.Equal(t, map[string]bool{"TextType": true, "LabelType": true, "CodeType": false, "RecordType": true}, mixed)
+}
+
+func TestParseValueConstraints(t *testing.T) {
+	set, err := NewParser(&Options{Lang: "Go"}).Load(filepath.Join(testFixtureDir, "xsd", "defaults.xsd"))
+	require.NoError(t, err)
+	require.Len(t, set.Schemas, 1)
+	settings, ok := set.Schemas[0].ProtoTree[0].(*ComplexType)
+	require.True(t, ok)
+	assert.Equal(t, "SettingsType", settings.Name)
+
+	assert.Equal(t, "light", settings.Elements[0].Default)
+	assert.Empty(t, settings.Elements[0].Fixed)
+	assert.Empty(t, settings.Elements[3].Default)
+	assert.Equal(t, "UTF-8", settings.Elements[3].Fixed)
+	assert.Equal(t, "2.0", settings.Attributes[0].Fixed)
+	assert.Equal(t, "true", settings.Attributes[1].Default)
+
+	literal, ok := genIntegerLiteral("-1", 16, true)
+	assert.False(t, ok, literal)
+	literal, ok = genFloatLiteral("INF")
+	assert.False(t, ok, literal)
+	literal, ok = genBooleanLiteral("1")
+	assert.True(t, ok)
+	assert.Equal(t, "true", literal)
+}
+
+func TestParseAnnotations(t *testing.T) {
+	set, err := NewParser(&Options{Lang: "Go"}).Load(filepath.Join(testFixtureDir, "xsd", "annotation.xsd"))
+	require.NoError(t, err)
+	require.Len(t, set.Schemas, 1)
+	schema := set.Schemas[0]
+	require.Len(t, schema.Annotations, 1)
+	assert.Equal(t, "Schema of the notes.", schema.Annotations[0].Doc(""))
+	assert.Equal(t, []AppInfo{{InnerXML: "<app:version>1.0</app:version>"}}, schema.Annotations[0].AppInfo)
+
+	priority := schema.ProtoTree[0].(*SimpleType)
+	assert.Equal(t, []Documentation{{Lang: "en", Text: "Priority of a note."}, {Lang: "de", Text: "Priorität einer Notiz."}}, priority.Annotation.Documentation)
+	assert.Equal(t, "Priority of a note.", priority.Doc)
+	assert.Equal(t, "Priorität einer Notiz.", priority.Annotation.Doc("de-DE"))
+	assert.Equal(t, "Priority of a note.", priority.Annotation.Doc("fr"))
+
+	note := schema.ProtoTree[1].(*ComplexType)
+	assert.Equal(t, "A note with a title and a priority.\nNotes are ordered by priority.", note.Doc)
+	assert.Equal(t, "https://example.com/notes", note.Annotation.Documentation[1].Source)
+	assert.Equal(t, []AppInfo{{Source: "urn:example:mapping", InnerXML: `<app:table name="notes"/>`}}, note.Annotation.AppInfo)
+	assert.Equal(t, "Title of the note.", note.Elements[0].Doc)
+	assert.Empty(t, note.Elements[1].Doc)
+	assert.Equal(t, "Identifier of the note.", note.Attributes[0].Doc)
+
+	entry := schema.ProtoTree[2].(*Element)
+	assert.Equal(t, "en", entry.Annotation.Documentation[0].Lang)
+	assert.Equal(t, "The note entry.", entry.Doc)
+	assert.Equal(t, "Der Notizeintrag.", (&CodeGenerator{DocLanguage: "de"}).doc(entry.Annotation, entry.Doc))
+}
+
+func TestParsePositions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xgen-positions")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "positions.xsd")
+	require.NoError(t, ioutil.WriteFile(file, []byte(`<?xml version="1.0" encoding="utf-8"?>
+<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+  <xs:simpleType name="CodeType">
+    <xs:restriction base="xs:string"/>
+  </xs:simpleType>
+  <xs:group name="NameGroup">
+    <xs:sequence>
+      <xs:element name="Name" type="xs:string"/>
+    </xs:sequence>
+  </xs:group>
+  <xs:attributeGroup name="CommonAttributes"><xs:attribute name="lang" type="xs:language"/></xs:attributeGroup>
+  <xs:complexType name="ItemType">
+    <xs:sequence>
+      <xs:element name="Code" type="CodeType"/>
+    </xs:sequence>
+    <xs:attribute name="id" type="xs:ID"/>
+  </xs:complexType>
+  <xs:element name="Item" type="ItemType"/>
+</xs:schema>`), 0644))
+
+	set, err := NewParser(&Options{Lang: "Go"}).Load(file)
+	require.NoError(t, err)
+	protoTree := set.Schemas[0].ProtoTree
+	require.Len(t, protoTree, 5)
+	assert.Equal(t, Position{File: file, Line: 3, Column: 3}, protoTree[0].(*SimpleType).Pos)
+	assert.Equal(t, Position{File: file, Line: 6, Column: 3}, protoTree[1].(*Group).Pos)
+	assert.Equal(t, Position{File: file, Line: 8, Column: 7}, protoTree[1].(*Group).Elements[0].Pos)
+	attributeGroup := protoTree[2].(*AttributeGroup)
+	assert.Equal(t, Position{File: file, Line: 11, Column: 3}, attributeGroup.Pos)
+	assert.Equal(t, Position{File: file, Line: 11, Column: 46}, attributeGroup.Attributes[0].Pos)
+	itemType := protoTree[3].(*ComplexType)
+	assert.Equal(t, Position{File: file, Line: 12, Column: 3}, itemType.Pos)
+	assert.Equal(t, Position{File: file, Line: 14, Column: 7}, itemType.Elements[0].Pos)
+	assert.Equal(t, Position{File: file, Line: 16, Column: 5}, itemType.Attributes[0].Pos)
+	assert.Equal(t, file+":18:3", protoTree[4].(*Element).Pos.String())
+}
+
+func TestParseDiagnosticErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xgen-diagnostic-errors")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	for name, content := range map[string]string{
+		"truncated.xsd": `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+  <xs:complexType name="ItemType">
+    <xs:sequence>`,
+		"malformed.xsd": `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+  <xs:element name="Item" type="xs:string"></xs:complexType>
+</xs:schema>`,
+		"invalid.xsd": `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+  <xs:complexType name="ItemType">
+    <xs:sequence>
+      <xs:element name="Code" type="xs:string" maxOccurs="many"/>
+    </xs:sequence>
+  </xs:complexType>
+</xs:schema>`,
+		"valid.xsd": `<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+  <xs:element name="Valid" type="xs:string"/>
+</xs:schema>`,
+	} {
+		require.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+
+	file := filepath.Join(dir, "truncated.xsd")
+	parser := NewParser(&Options{FilePath: file, Lang: "Go"})
+	err = parser.Parse()
+	var diagnostic *Diagnostic
+	require.True(t, errors.As(err, &diagnostic))
+	assert.Equal(t, file, diagnostic.File)
+	assert.Equal(t, SeverityError, diagnostic.Severity)
+	assert.Equal(t, DiagnosticSyntax, diagnostic.Code)
+	assert.Equal(t, 3, diagnostic.Line)
+	assert.Equal(t, "XML syntax error: unexpected EOF", diagnostic.Message)
+	assert.Equal(t, Diagnostics{diagnostic}, parser.Diagnostics.Errors())
+
+	set, err := NewParser(&Options{Lang: "Go"}).Load(dir)
+	require.NotNil(t, set)
+	require.True(t, errors.As(err, &diagnostic))
+	assert.Len(t, set.Schemas, 2)
+	errs := set.Diagnostics.Errors()
+	require.Len(t, errs, 2)
+	assert.Equal(t, err, errs[0])
+	assert.Equal(t, filepath.Join(dir, "malformed.xsd"), errs[0].File)
+	assert.Equal(t, DiagnosticSyntax, errs[0].Code)
+	assert.Equal(t, 2, errs[0].Line)
+	assert.Equal(t, "XML syntax error: element <element> closed by </complexType>", errs[0].Message)
+	assert.Equal(t, file, errs[1].File)
+
+	// an invalid occurrence is reported as a warning, and the default is used
+	require.Len(t, set.Diagnostics, 3)
+	warning := set.Diagnostics[0]
+	assert.Equal(t, SeverityWarning, warning.Severity)
+	assert.Equal(t, filepath.Join(dir, "invalid.xsd")+":4:7: invalid maxOccurs value \"many\"", warning.Error())
+	code := set.Schemas[0].ProtoTree[0].(*ComplexType).Elements[0]
+	assert.Equal(t, 1, code.MaxOccurs)
+	assert.False(t, code.Plural)
+}
+
+func TestParseReferencedDiagnosticError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xgen-referenced-error")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "a.xsd"), []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+  <xs:include schemaLocation="b.xsd"/>
+</xs:schema>`), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "b.xsd"), []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema">
+  <xs:element name="B" type="xs:string">
+</xs:schema>`), 0644))
+
+	parser := NewParser(&Options{FilePath: filepath.Join(dir, "a.xsd"), Lang: "Go"})
+	err = parser.Parse()
+	var diagnostic *Diagnostic
+	require.True(t, errors.As(err, &diagnostic))
+	assert.Equal(t, filepath.Join(dir, "b.xsd"), diagnostic.File)
+	assert.Equal(t, DiagnosticSyntax, diagnostic.Code)
+	assert.Len(t, parser.Diagnostics.Errors(), 1)
+}
+
+func TestParseUnresolvedReferences(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xgen-unresolved")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "unresolved.xsd")
+	require.NoError(t, ioutil.WriteFile(file, []byte(`<xs:schema xmlns:xs="http://www.w3.org/2001/XMLSchema" xmlns:a="urn:a" targetNamespace="urn:a">
+  <xs:complexType name="ItemType">
+    <xs:sequence>
+      <xs:element name="Code" type="a:CodeType"/>
+      <xs:element name="Date" type="a:date"/>
+      <xs:element name="Self" type="a:Self"/>
+      <xs:element name="Any"/>
+      <xs:element ref="a:Missing"/>
+      <xs:group ref="a:MissingGroup"/>
+    </xs:sequence>
+    <xs:attributeGroup ref="a:MissingAttributes"/>
+  </xs:complexType>
+  <xs:element name="Item">
+    <xs:complexType>
+      <xs:attribute ref="a:missing"/>
+      <xs:attribute ref="xml:lang"/>
+    </xs:complexType>
+  </xs:element>
+</xs:schema>`), 0644))
+
+	set, err := NewParser(&Options{Lang: "Go"}).Load(file)
+	require.NotNil(t, set)
+	var diagnostic *Diagnostic
+	require.True(t, errors.As(err, &diagnostic))
+	errs := set.Diagnostics.Errors()
+	require.Len(t, errs, 7)
+	assert.Equal(t, errs[0], diagnostic)
+	for _, diagnostic := range errs {
+		assert.Equal(t, DiagnosticUnresolvedReference, diagnostic.Code)
+		assert.Equal(t, file, diagnostic.File)
+	}
+	assert.Equal(t, file+":4:7: unresolved type reference CodeType in namespace urn:a", errs[0].Error())
+	var messages []string
+	for _, diagnostic := range errs[1:] {
+		messages = append(messages, diagnostic.Message)
+	}
+	assert.ElementsMatch(t, []string{
+		"unresolved type reference date in namespace urn:a",
+		"unresolved type reference Self in namespace urn:a",
+		"unresolved element reference Missing in namespace urn:a",
+		"unresolved group reference MissingGroup in namespace urn:a",
+		"unresolved attribute group reference MissingAttributes in namespace urn:a",
+		"unresolved attribute reference missing in namespace urn:a",
+	}, messages)
+}
+
+func TestParseUnionMemberTypes(t *testing.T) {
+	set, err := NewParser(&Options{Lang: "Go"}).Load(filepath.Join(testFixtureDir, "xsd", "union.xsd"))
+	require.NoError(t, err)
+	protoTree := set.Schemas[0].ProtoTree
+	require.Len(t, protoTree, 10)
+
+	member := protoTree[0].(*SimpleType)
+	assert.Equal(t, "DimensionTypeMember2", member.Name)
+	assert.True(t, member.Anonymous)
+	assert.Equal(t, "DimensionType", member.Parent)
+	assert.Equal(t, "string", member.Base)
+	assert.Equal(t, []string{"auto", "inherit"}, member.Restriction.Enum)
+	assert.Equal(t, "0", protoTree[1].(*SimpleType).Restriction.MinValue)
+	dimensionType := protoTree[2].(*SimpleType)
+	assert.True(t, dimensionType.Union)
+	assert.Empty(t, dimensionType.Restriction.Enum)
+	assert.Equal(t, map[string]string{"int": "int", "DimensionTypeMember2": "string", "DimensionTypeMember3": "float64"}, dimensionType.MemberTypes)
+
+	margin := protoTree[5].(*SimpleType)
+	assert.Equal(t, "Margin", margin.Name)
+	assert.True(t, margin.Anonymous)
+	assert.Equal(t, map[string]string{"MarginMember1": "string", "MarginMember2": "int"}, margin.MemberTypes)
+	assert.Equal(t, "BoxType/Margin", protoTree[3].(*SimpleType).Parent)
+	align := protoTree[8].(*SimpleType)
+	assert.Equal(t, "align", align.Name)
+	assert.Equal(t, map[string]string{"alignMember1": "string", "alignMember2": "int"}, align.MemberTypes)
+
+	boxType := protoTree[9].(*ComplexType)
+	require.Len(t, boxType.Elements, 2)
+	assert.Equal(t, "Margin", boxType.Elements[1].Type)
+	require.Len(t, boxType.Attributes, 1)
+	assert.Equal(t, "align", boxType.Attributes[0].Type)
+}
+
+func TestLoadCatalogMalformed(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xgen-*")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "catalog.xml")
+	require.NoError(t, ioutil.WriteFile(file, []byte(`<catalog xmlns="urn:oasis:names:tc:entity:xmlns:xml:catalog">
+  <uri name="urn:b" uri="vendor/b.xsd"/>`), 0644))
+
+	_, err = LoadCatalog(file)
+	var syntaxErr *xml.SyntaxError
+	assert.True(t, errors.As(err, &syntaxErr))
+	assert.Contains(t, err.Error(), file)
+}
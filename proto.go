@@ -8,20 +8,70 @@
 
 package xgen
 
-import "regexp"
+import (
+	"fmt"
+	"regexp"
+)
+
+// Unbounded is the value of the maximum occurrence for particles declared
+// with maxOccurs="unbounded".
+const Unbounded = -1
+
+// Kinds of particles in the particle tree of a content model.
+const (
+	ParticleSequence = "sequence"
+	ParticleAll      = "all"
+	ParticleChoice   = "choice"
+	ParticleElement  = "element"
+	ParticleGroup    = "group"
+	ParticleAny      = "any"
+)
+
+// Position is the location of a schema component in the schema document,
+// the Line and Column of the start tag of the component are 1-based.
+type Position struct {
+	File   string
+	Line   int
+	Column int
+}
+
+// String returns the position in the form of "file:line:column".
+func (pos Position) String() string {
+	return fmt.Sprintf("%s:%d:%d", pos.File, pos.Line, pos.Column)
+}
+
+// Derivation methods of complex types.
+const (
+	DerivationExtension   = "extension"
+	DerivationRestriction = "restriction"
+)
 
 // SimpleType definitions provide for constraining character information item
-// [children] of element and attribute information items.
+// [children] of element and attribute information items. Schema components
+// are identified by their name and target namespace, the BaseNamespace is the
+// namespace of the Base when the Base refers to a type definition instead of
+// a build-in type. The Parent of an anonymous type is the path of the
+// declaration which defines the type. The MemberTypes of a union hold the
+// member types keyed by their names, the Members are the names of the member
+// types in declaration order, and the MemberTypeNamespaces hold the
+// namespaces of the member types which refer to type definitions.
 // https://www.w3.org/TR/xmlschema-1/#Simple_Type_Definitions
 type SimpleType struct {
-	Doc         string
-	Name        string
-	Base        string
-	Anonymous   bool
-	List        bool
-	Union       bool
-	MemberTypes map[string]string
-	Restriction Restriction
+	Doc                  string
+	Annotation           *Annotation
+	Pos                  Position
+	Name                 string
+	TargetNamespace      string
+	Base                 string
+	BaseNamespace        string
+	Anonymous            bool
+	Parent               string
+	List                 bool
+	Union                bool
+	MemberTypes          map[string]string
+	Members              []string
+	MemberTypeNamespaces map[string]string
+	Restriction          Restriction
 }
 
 // Element declarations provide for: Local validation of element information
@@ -29,31 +79,125 @@ type SimpleType struct {
 // an element information items; Establishing uniquenesses and reference
 // constraint relationships among the values of related elements and
 // attributes; Controlling the substitutability of elements through the
-// mechanism of element substitution groups.
+// mechanism of element substitution groups. The MinOccurs and MaxOccurs hold
+// the occurrence bounds of a local element, where MaxOccurs is Unbounded for
+// maxOccurs="unbounded". An element is Optional when it may be absent and
+// Plural when it may occur more than once. The TypeNamespace is the namespace
+// of the Type when the Type refers to a type definition. An element with a
+// Wildcard stands for an <any> element wildcard, it has no name and type. The
+// Ref and RefNamespace hold the qualified name of the element declaration
+// referenced by the element, the SubstitutionGroup and
+// SubstitutionGroupNamespace hold the qualified name of the head element of
+// the substitution group that the element declaration is a member of. The
+// IdentityConstraints are the key, keyref and unique constraints scoped to
+// the element, and the Alternatives are the conditional type assignments of
+// the element. The Default and Fixed hold the value constraint of the
+// element, a fixed value is the only value the element may have. The
+// AnonymousType is set for an element declaration without type and ref
+// attributes, the Type of which is the anonymous type defined by the
+// declaration, or the name of the declaration if it doesn't define a type.
+// The Restriction holds the facets of the anonymous simple type derived by
+// restriction of the element, the Type of which is the base type.
 // https://www.w3.org/TR/xmlschema-1/#cElement_Declarations
 type Element struct {
-	Doc      string
-	Name     string
-	Wildcard bool
-	Type     string
-	Abstract bool
-	Plural   bool
-	Optional bool
-	Nillable bool
-	Default  string
+	Doc                        string
+	Annotation                 *Annotation
+	Pos                        Position
+	Name                       string
+	TargetNamespace            string
+	Wildcard                   *Wildcard
+	Type                       string
+	TypeNamespace              string
+	AnonymousType              bool
+	Ref                        string
+	RefNamespace               string
+	SubstitutionGroup          string
+	SubstitutionGroupNamespace string
+	Abstract                   bool
+	MinOccurs                  int
+	MaxOccurs                  int
+	Plural                     bool
+	Optional                   bool
+	Nillable                   bool
+	Default                    string
+	Fixed                      string
+	IdentityConstraints        []IdentityConstraint
+	Alternatives               []Alternative
+	Restriction                *Restriction
+}
+
+// Assertion constrains the value of a simple type or the content of a
+// complex type by an XPath 2.0 expression which must evaluate to true, it's
+// introduced by XML Schema 1.1.
+// https://www.w3.org/TR/xmlschema11-1/#cAssertions
+type Assertion struct {
+	Test                  string
+	XPathDefaultNamespace string
+}
+
+// Alternative provides for conditional type assignment of an element
+// declaration by XML Schema 1.1: the type of the first alternative whose
+// Test evaluates to true on the element is used to validate the element. The
+// Test of the default alternative is empty.
+// https://www.w3.org/TR/xmlschema11-1/#cTypeAlternative
+type Alternative struct {
+	Test          string
+	Type          string
+	TypeNamespace string
+}
+
+// IdentityConstraint definitions provide for uniqueness and reference
+// constraints with respect to the contents of multiple elements and
+// attributes. The Category is one of "key", "keyref" or "unique", the Selector
+// and Fields hold the XPath expressions of the selector and field elements,
+// the Refer and ReferNamespace hold the qualified name of the key or unique
+// constraint referenced by a keyref.
+// https://www.w3.org/TR/xmlschema-1/#cIdentity-constraint_Definitions
+type IdentityConstraint struct {
+	Name            string
+	TargetNamespace string
+	Category        string
+	Selector        string
+	Fields          []string
+	Refer           string
+	ReferNamespace  string
+}
+
+// SubstitutionGroup holds the element declarations which can be used in
+// place of the head element of a substitution group: the head itself unless
+// it is abstract, and every direct or indirect member of the group in
+// declaration order. The Head is nil when the head element is not declared
+// in the loaded schema documents.
+// https://www.w3.org/TR/xmlschema-1/#Element_Equivalence_Class
+type SubstitutionGroup struct {
+	Head     *Element
+	Elements []*Element
 }
 
 // Attribute declarations provide for: Local validation of attribute
 // information item values using a simple type definition; Specifying default
-// or fixed values for attribute information items.
+// or fixed values for attribute information items. The Ref and RefNamespace
+// hold the qualified name of the attribute declaration referenced by the
+// attribute. The Restriction holds the facets of the anonymous simple type
+// derived by restriction of the attribute, the Type of which is the base
+// type.
 // https://www.w3.org/TR/xmlschema-1/structures.html#element-attribute
 type Attribute struct {
-	Name     string
-	Doc      string
-	Type     string
-	Plural   bool
-	Default  string
-	Optional bool
+	Name            string
+	TargetNamespace string
+	Doc             string
+	Annotation      *Annotation
+	Pos             Position
+	Type            string
+	TypeNamespace   string
+	Ref             string
+	RefNamespace    string
+	Plural          bool
+	Default         string
+	Fixed           string
+	Optional        bool
+	Prohibited      bool
+	Restriction     *Restriction
 }
 
 // ComplexType definitions are identified by their {name} and {target
@@ -65,46 +209,112 @@ type Attribute struct {
 // namespace}s are provided for reference from instances, and for use in the
 // XML representation of schema components (specifically in <element>). See
 // References to schema components across namespaces for the use of component
-// identifiers when importing one schema into another.
+// identifiers when importing one schema into another. The OpenContent and
+// Assertions are the open content and assertions of XML Schema 1.1. The
+// Derivation is the derivation method of a complex type with a Base, and the
+// ValueType is the built-in type of the value of a complex type with simple
+// content. The content of a complex type derived by restriction is complete,
+// its attributes include the attributes inherited from the base type. The
+// Parent of an anonymous type is the path of the declaration which defines
+// the type. The Particle is the root of the particle tree of the content.
 // https://www.w3.org/TR/xmlschema-1/structures.html#element-complexType
 type ComplexType struct {
-	Doc            string
-	Name           string
-	Base           string
-	Anonymous      bool
-	Elements       []Element
-	Attributes     []Attribute
-	Groups         []Group
-	Choice         []Choice
-	AttributeGroup []AttributeGroup
-	Mixed          bool
+	Doc             string
+	Annotation      *Annotation
+	Pos             Position
+	Name            string
+	TargetNamespace string
+	Base            string
+	BaseNamespace   string
+	Derivation      string
+	ValueType       string
+	Anonymous       bool
+	Parent          string
+	Elements        []Element
+	Attributes      []Attribute
+	Groups          []Group
+	Choice          []Choice
+	Particle        *Particle
+	AttributeGroup  []AttributeGroup
+	AnyAttribute    *Wildcard
+	OpenContent     *OpenContent
+	Assertions      []Assertion
+	Mixed           bool
 }
 
 // Group (model group) definitions are provided primarily for reference from
 // the XML Representation of Complex Type Definitions. Thus, model group
 // definitions provide a replacement for some uses of XML's parameter entity
-// facility.
+// facility. The Particle is the root of the particle tree of the definition.
 // https://www.w3.org/TR/xmlschema-1/structures.html#cModel_Group_Definitions
 type Group struct {
-	Doc      string
-	Name     string
-	Elements []Element
-	Groups   []Group
-	Plural   bool
-	Ref      string
+	Doc             string
+	Annotation      *Annotation
+	Pos             Position
+	Name            string
+	TargetNamespace string
+	Elements        []Element
+	Groups          []Group
+	Choice          []Choice
+	Particle        *Particle
+	MinOccurs       int
+	MaxOccurs       int
+	Plural          bool
+	Optional        bool
+	Ref             string
+	RefNamespace    string
+}
+
+// Particle is a node of the particle tree of a content model, which keeps
+// the particles of the content model in the declared order. A particle of a
+// sequence, all or choice compositor holds its child particles, a particle of
+// an element, group or any Kind refers to the element, group reference or
+// element wildcard of the complex type or model group definition by the Name.
+// https://www.w3.org/TR/xmlschema-1/#cParticles
+type Particle struct {
+	Kind      string
+	Name      string
+	MinOccurs int
+	MaxOccurs int
+	Particles []*Particle
 }
 
 // Choice definitions are provided primarily for reference from
 // the XML Representation of Choice Definitions which acts as a container
 // stating that one and only one element in the selected group should be
-// present in the containing element. Generated code does not enforce the "one
-// and only one" constraint but the choice container is parsed in order to effectively
-// define if the elements it contains should be plural or not (as defined by the maxOccurs).
+// present in the containing element. The choice container is parsed in order
+// to effectively define if the elements it contains should be plural or not
+// (as defined by the maxOccurs). Every alternative of a choice is optional
+// since only one of them is present. The Name of a choice is made up of the
+// names of its alternatives, and the Elements are the alternatives declared
+// by element declarations, a choice of distinct element declarations is
+// generated as a union of its alternatives. The Choice holds the choices
+// nested in the choice.
 // https://www.w3.org/TR/xmlschema-1/#Complex_Type_Definition_details
 type Choice struct {
-	ID       string
-	Choice   []Choice
-	Plural   bool
+	ID        string
+	Name      string
+	Elements  []Element
+	Choice    []Choice
+	MinOccurs int
+	MaxOccurs int
+	Plural    bool
+	Optional  bool
+}
+
+// Redefine holds the components defined in a <redefine> or <override>
+// element of a schema document, they replace the components with the same
+// kind and name of the schema document at the SchemaLocation. The components
+// of a <redefine> are defined in terms of the components they replace: a
+// simple type restricts and a complex type extends the original type, and a
+// model group or attribute group may reference the original group. The
+// components of an <override> replace the original components as is.
+// https://www.w3.org/TR/xmlschema-1/#modify-schema
+// https://www.w3.org/TR/xmlschema11-1/#override-schema
+type Redefine struct {
+	SchemaLocation string
+	Override       bool
+	Components     []interface{}
 }
 
 // AttributeGroup definitions do not participate in ·validation· as such, but
@@ -114,23 +324,94 @@ type Choice struct {
 // for some uses of XML's parameter entity facility. Attribute group
 // definitions are provided primarily for reference from the XML
 // representation of schema components (see <complexType> and
-// <attributeGroup>).
+// <attributeGroup>). The Ref of an attribute group definition in a
+// <redefine> is the name of the definition itself when it includes the
+// attributes of the original definition.
 // https://www.w3.org/TR/xmlschema-1/structures.html#Attribute_Group_Definition
 type AttributeGroup struct {
-	Doc        string
-	Name       string
-	Ref        string
-	Attributes []Attribute
+	Doc             string
+	Annotation      *Annotation
+	Pos             Position
+	Name            string
+	TargetNamespace string
+	Ref             string
+	RefNamespace    string
+	Attributes      []Attribute
+	AnyAttribute    *Wildcard
+}
+
+// Wildcard provides for validation of attribute and element information
+// items dependent on their namespace names. The Namespace holds the namespace
+// constraint as declared in the schema: "##any", "##other" or a list of
+// namespace names, "##targetNamespace" and "##local". The ProcessContents is
+// one of "strict", "lax" or "skip".
+// https://www.w3.org/TR/xmlschema-1/#Wildcards
+type Wildcard struct {
+	Namespace       string
+	ProcessContents string
+}
+
+// OpenContent allows elements matched by the Wildcard in the content of a
+// complex type besides the elements of its content model, it's introduced by
+// XML Schema 1.1. The Mode is one of "interleave", "suffix" or "none". The
+// default open content of a schema document applies to the complex types
+// with non-empty content, or every complex type when AppliesToEmpty is true.
+// https://www.w3.org/TR/xmlschema11-1/#oc
+type OpenContent struct {
+	Mode           string
+	AppliesToEmpty bool
+	Wildcard       *Wildcard
 }
 
 // Restriction are used to define acceptable values for XML elements or
-// attributes. Restriction on XML elements are called facets.
+// attributes. Restriction on XML elements are called facets. The MinValue
+// and MaxValue hold the lexical bounds as declared in the schema, Min and Max
+// hold the numeric bounds when the lexical value is a number. Precision is
+// the value of the fractionDigits facet, the length and digits facets are nil
+// if not specified. Patterns hold every pattern facet in the XSD regular
+// expression syntax, Pattern is the compiled form of them when the
+// expressions are compatible with Go regular expression syntax. The
+// Assertions are the assertion facets of XML Schema 1.1.
 // https://www.w3.org/TR/xmlschema-1/structures.html#element-restriction
 type Restriction struct {
-	Doc                  string
-	Precision            int
-	Enum                 []string
-	Min, Max             float64
-	MinLength, MaxLength int
-	Pattern              *regexp.Regexp
+	Doc                        string
+	Precision                  *int
+	TotalDigits                *int
+	Enum                       []string
+	Min, Max                   float64
+	MinValue, MaxValue         string
+	MinExclusive, MaxExclusive bool
+	Length                     *int
+	MinLength, MaxLength       *int
+	Pattern                    *regexp.Regexp
+	Patterns                   []string
+	WhiteSpace                 string
+	Assertions                 []Assertion
+}
+
+// Annotation holds the documentation and application information annotating
+// a schema component. The Documentation entries are kept in the declared
+// order with their language and source, the AppInfo entries hold the content
+// of the appinfo elements as raw XML. The Doc of an annotated component is
+// the documentation of its annotation without a preferred language.
+// https://www.w3.org/TR/xmlschema-1/#cAnnotations
+type Annotation struct {
+	Documentation []Documentation
+	AppInfo       []AppInfo
+}
+
+// Documentation is the text of a documentation element. The Lang is the
+// xml:lang in scope of the element, and the Source is the URI reference of
+// the supplementary information.
+type Documentation struct {
+	Lang   string
+	Source string
+	Text   string
+}
+
+// AppInfo is the raw XML content of an appinfo element, the Source is the
+// URI reference of the supplementary information.
+type AppInfo struct {
+	Source   string
+	InnerXML string
 }
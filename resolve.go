@@ -0,0 +1,278 @@
+// Copyright 2020 - 2021 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// resolver links the references of schema components to the definitions
+// they refer to. It's the resolution phase of loading, which runs once the
+// components of every schema document have been collected by the parser, so
+// that a reference resolves regardless of the order of the declarations.
+type resolver struct {
+	buildInTypes    map[string]bool
+	types           map[xml.Name]interface{}
+	elements        map[xml.Name]*Element
+	attributes      map[xml.Name]*Attribute
+	groups          map[xml.Name]bool
+	attributeGroups map[xml.Name]bool
+	resolving       map[xml.Name]bool
+	reported        map[string]bool
+	diagnostics     *Diagnostics
+}
+
+// resolveReferences resolves the type, element, attribute, model group and
+// attribute group references of the components in given schema documents
+// and their chameleons. A reference to a simple type which is neither a list
+// nor a union is resolved to the base type of the simple type. The
+// references which remain unresolved are reported as error diagnostics.
+func resolveReferences(schemas []*Schema, lang string, diagnostics *Diagnostics) {
+	r := &resolver{
+		buildInTypes:    buildInTypeNames(lang),
+		types:           make(map[xml.Name]interface{}),
+		elements:        make(map[xml.Name]*Element),
+		attributes:      make(map[xml.Name]*Attribute),
+		groups:          make(map[xml.Name]bool),
+		attributeGroups: make(map[xml.Name]bool),
+		resolving:       make(map[xml.Name]bool),
+		reported:        make(map[string]bool),
+		diagnostics:     diagnostics,
+	}
+	var protoTrees [][]interface{}
+	var walk func(schemas []*Schema)
+	walk = func(schemas []*Schema) {
+		for _, schema := range schemas {
+			protoTrees = append(protoTrees, schema.ProtoTree)
+			for _, chameleon := range schema.Chameleons {
+				walk([]*Schema{chameleon})
+			}
+		}
+	}
+	walk(schemas)
+	for _, protoTree := range protoTrees {
+		for _, ele := range protoTree {
+			r.define(ele)
+		}
+	}
+	// the declarations referenced by elements and attributes are resolved
+	// before the references to them.
+	for _, visit := range []func(component interface{}){r.resolveTypes, r.resolveDeclarations} {
+		for _, protoTree := range protoTrees {
+			for _, ele := range protoTree {
+				walkComponent(ele, visit)
+			}
+		}
+	}
+}
+
+// define adds the top-level component of a proto tree to the definitions,
+// the first definition of a qualified name is used.
+func (r *resolver) define(component interface{}) {
+	name := componentName(component)
+	switch v := component.(type) {
+	case *SimpleType, *ComplexType:
+		if _, ok := r.types[name]; !ok {
+			r.types[name] = v
+		}
+	case *Element:
+		if _, ok := r.elements[name]; !ok {
+			r.elements[name] = v
+		}
+	case *Attribute:
+		if _, ok := r.attributes[name]; !ok {
+			r.attributes[name] = v
+		}
+	case *Group:
+		if v.Ref == "" {
+			r.groups[name] = true
+		}
+	case *AttributeGroup:
+		r.attributeGroups[name] = true
+	}
+}
+
+// walkComponent visits the component and the components nested in it.
+func walkComponent(component interface{}, visit func(component interface{})) {
+	visit(component)
+	switch v := component.(type) {
+	case *ComplexType:
+		for i := range v.Elements {
+			walkComponent(&v.Elements[i], visit)
+		}
+		for i := range v.Attributes {
+			walkComponent(&v.Attributes[i], visit)
+		}
+		for i := range v.Groups {
+			walkComponent(&v.Groups[i], visit)
+		}
+		for i := range v.Choice {
+			walkComponent(&v.Choice[i], visit)
+		}
+		for i := range v.AttributeGroup {
+			walkComponent(&v.AttributeGroup[i], visit)
+		}
+	case *Group:
+		for i := range v.Elements {
+			walkComponent(&v.Elements[i], visit)
+		}
+		for i := range v.Groups {
+			walkComponent(&v.Groups[i], visit)
+		}
+		for i := range v.Choice {
+			walkComponent(&v.Choice[i], visit)
+		}
+	case *Choice:
+		for i := range v.Elements {
+			walkComponent(&v.Elements[i], visit)
+		}
+		for i := range v.Choice {
+			walkComponent(&v.Choice[i], visit)
+		}
+	case *AttributeGroup:
+		for i := range v.Attributes {
+			walkComponent(&v.Attributes[i], visit)
+		}
+	}
+}
+
+// resolveTypes resolves the type references of the component. The type of
+// an element or attribute referencing a declaration is resolved with the
+// declaration, and the anonymous type of an element declaration is not
+// reported if the declaration doesn't define it.
+func (r *resolver) resolveTypes(component interface{}) {
+	switch v := component.(type) {
+	case *SimpleType:
+		v.Base, v.BaseNamespace = r.resolveTypeRef(v.Pos, v.Base, v.BaseNamespace)
+		for member, memberType := range v.MemberTypes {
+			memberType, ns := r.resolveTypeRef(v.Pos, memberType, v.MemberTypeNamespaces[member])
+			v.MemberTypes[member] = memberType
+			if ns == "" {
+				delete(v.MemberTypeNamespaces, member)
+				continue
+			}
+			v.MemberTypeNamespaces[member] = ns
+		}
+	case *ComplexType:
+		if base, ns := r.resolveTypeRef(v.Pos, v.Base, v.BaseNamespace); base != v.Base || ns != v.BaseNamespace {
+			v.Base, v.BaseNamespace, v.ValueType = base, ns, base
+		}
+	case *Element:
+		for i := range v.Alternatives {
+			alternative := &v.Alternatives[i]
+			alternative.Type, alternative.TypeNamespace = r.resolveTypeRef(v.Pos, alternative.Type, alternative.TypeNamespace)
+		}
+		if v.Ref != "" || v.Wildcard != nil {
+			return
+		}
+		if name, ok := r.resolveType(toQName(v.TypeNamespace, v.Type)); ok || v.AnonymousType {
+			v.Type, v.TypeNamespace = name.Local, name.Space
+			return
+		}
+		r.report(v.Pos, "type", toQName(v.TypeNamespace, v.Type))
+	case *Attribute:
+		if v.Ref == "" {
+			v.Type, v.TypeNamespace = r.resolveTypeRef(v.Pos, v.Type, v.TypeNamespace)
+		}
+	}
+}
+
+// resolveDeclarations resolves the element, attribute, model group and
+// attribute group declarations referenced by the component. An element or
+// attribute referencing a declaration takes on the type of the declaration.
+func (r *resolver) resolveDeclarations(component interface{}) {
+	switch v := component.(type) {
+	case *Element:
+		if v.Ref == "" {
+			return
+		}
+		if e, ok := r.elements[toQName(v.RefNamespace, v.Ref)]; ok {
+			v.Type, v.TypeNamespace = e.Type, e.TypeNamespace
+			return
+		}
+		r.report(v.Pos, "element", toQName(v.RefNamespace, v.Ref))
+	case *Attribute:
+		if v.Ref == "" || v.RefNamespace == xmlNamespace {
+			return
+		}
+		if attribute, ok := r.attributes[toQName(v.RefNamespace, v.Ref)]; ok {
+			v.Type, v.TypeNamespace = attribute.Type, attribute.TypeNamespace
+			return
+		}
+		r.report(v.Pos, "attribute", toQName(v.RefNamespace, v.Ref))
+	case *Group:
+		if v.Ref != "" && !r.groups[toQName(v.RefNamespace, v.Ref)] {
+			r.report(v.Pos, "group", toQName(v.RefNamespace, v.Ref))
+		}
+	case *AttributeGroup:
+		if v.Ref != "" && !r.attributeGroups[toQName(v.RefNamespace, v.Ref)] {
+			r.report(v.Pos, "attribute group", toQName(v.RefNamespace, v.Ref))
+		}
+	}
+}
+
+// resolveTypeRef resolves the type reference by given type name and
+// namespace of the component at the position, the unresolved reference is
+// reported and kept as is.
+func (r *resolver) resolveTypeRef(pos Position, valueType, ns string) (string, string) {
+	if valueType == "" {
+		return valueType, ns
+	}
+	name, ok := r.resolveType(toQName(ns, valueType))
+	if !ok {
+		r.report(pos, "type", name)
+	}
+	return name.Local, name.Space
+}
+
+// resolveType returns the resolved type of the type definition or build-in
+// type by given qualified name, and whether the type is defined. Names
+// without namespace may be the build-in types of the language already, the
+// build-in data types of the XML schema namespace have been mapped by the
+// parser. The simple type referencing itself directly or indirectly is kept
+// as is.
+func (r *resolver) resolveType(name xml.Name) (xml.Name, bool) {
+	definition, ok := r.types[name]
+	if !ok {
+		return name, name.Space == "" && r.buildInTypes[name.Local]
+	}
+	simpleType, ok := definition.(*SimpleType)
+	if !ok || simpleType.List || simpleType.Union || simpleType.Base == "" || r.resolving[name] {
+		return name, true
+	}
+	r.resolving[name] = true
+	defer delete(r.resolving, name)
+	if base, ok := r.resolveType(toQName(simpleType.BaseNamespace, simpleType.Base)); ok {
+		return base, true
+	}
+	return name, true
+}
+
+// report adds an error diagnostic for the unresolved reference of the
+// component at the position, each reference is reported once.
+func (r *resolver) report(pos Position, kind string, name xml.Name) {
+	message := fmt.Sprintf("unresolved %s reference %s", kind, name.Local)
+	if name.Space != "" {
+		message += " in namespace " + name.Space
+	}
+	diagnostic := &Diagnostic{
+		File:     pos.File,
+		Line:     pos.Line,
+		Column:   pos.Column,
+		Severity: SeverityError,
+		Code:     DiagnosticUnresolvedReference,
+		Message:  message,
+	}
+	if r.reported[diagnostic.Error()] {
+		return
+	}
+	r.reported[diagnostic.Error()] = true
+	*r.diagnostics = append(*r.diagnostics, diagnostic)
+}
@@ -8,39 +8,184 @@
 
 package xgen
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
 
-func (opt *Options) prepareLocalNameNSMap(element xml.StartElement) {
-	for _, ele := range element.Attr {
-		if ele.Name.Space == "xmlns" {
-			opt.LocalNameNSMap[ele.Name.Local] = ele.Value
-		}
+const (
+	xsdNamespace = "http://www.w3.org/2001/XMLSchema"
+	xmlNamespace = "http://www.w3.org/XML/1998/namespace"
+	vcNamespace  = "http://www.w3.org/2007/XMLSchema-versioning"
+)
+
+// locateSchema returns the local path of the schema document at the schema
+// location referenced by the document being parsed. The schema location which
+// can't be resolved is reported as a diagnostic.
+func (opt *Options) locateSchema(location string) (string, bool) {
+	path, err := opt.schemaPath(opt.FilePath, location)
+	if err != nil {
+		opt.report(DiagnosticUnresolvedLocation, "unresolved schema location %s: %s", location, err)
+		return "", false
 	}
+	return path, true
 }
 
-func (opt *Options) prepareNSSchemaLocationMap(element xml.StartElement) {
-	var currentNS string
-	for _, ele := range element.Attr {
-		if ele.Name.Local == "namespace" {
-			currentNS = ele.Value
+// pushNSScope pushes the namespace declarations in scope of the given element
+// onto the namespace scope stack. The declarations of the element override
+// the declarations of its ancestors with the same prefix.
+func (opt *Options) pushNSScope(element xml.StartElement) {
+	parent, _ := opt.NSScope.Peek().(map[string]string)
+	scope, copied := parent, false
+	for _, attr := range element.Attr {
+		var prefix string
+		if attr.Name.Space == "xmlns" {
+			prefix = attr.Name.Local
+		} else if attr.Name.Space != "" || attr.Name.Local != "xmlns" {
+			continue
 		}
-		if ele.Name.Local == "schemaLocation" {
-			if _, ok := opt.NSSchemaLocationMap[currentNS]; ok {
-				continue
+		if !copied {
+			scope, copied = make(map[string]string, len(parent)+1), true
+			for p, ns := range parent {
+				scope[p] = ns
 			}
-			if isValidURL(ele.Value) {
-				continue
-				// TODO: fetch remote schema
-				// var err error
-				// if opt.RemoteSchema[ele.Value], err = fetchSchema(ele.Value); err != nil {
-				// 	continue
-				// }
+		}
+		scope[prefix] = attr.Value
+	}
+	opt.NSScope.Push(scope)
+}
+
+// resolveQName resolves the prefix of the QName value of an attribute
+// through the namespace declarations in scope. The unprefixed value will be
+// resolved to the default namespace.
+func (opt *Options) resolveQName(value string) xml.Name {
+	prefix, local := getNSPrefix(value), trimNSPrefix(value)
+	if prefix == "xml" {
+		return xml.Name{Space: xmlNamespace, Local: local}
+	}
+	scope, _ := opt.NSScope.Peek().(map[string]string)
+	return xml.Name{Space: scope[prefix], Local: local}
+}
+
+// anonymousTypeName returns the name of the anonymous type being parsed by
+// the naming strategy of the parser, and the parent of the type, which is the
+// path of the declaration defining the type, such as Order/Line/Item. The
+// name taken by the type of another declaration in the document is replaced
+// by the names of the declarations enclosing the type, followed by a number
+// if it's still taken.
+func (opt *Options) anonymousTypeName() (name, parent string) {
+	var names []string
+	for _, ancestor := range opt.ancestors {
+		if ancestor.Name.Space != xsdNamespace {
+			continue
+		}
+		switch ancestor.Name.Local {
+		case "element", "attribute", "complexType", "simpleType", "group", "attributeGroup":
+		default:
+			continue
+		}
+		for _, attr := range ancestor.Attr {
+			if attr.Name.Local == "name" {
+				names = append(names, attr.Value)
 			}
-			opt.NSSchemaLocationMap[currentNS] = ele.Value
 		}
 	}
+	if len(names) == 0 {
+		return
+	}
+	parent = strings.Join(names, "/")
+	switch opt.AnonymousTypeNaming {
+	case AnonymousNamingPath:
+		for _, n := range names {
+			name += MakeFirstUpperCase(n)
+		}
+	case AnonymousNamingSuffix:
+		suffix := opt.AnonymousTypeSuffix
+		if suffix == "" {
+			suffix = "Type"
+		}
+		name = names[len(names)-1] + suffix
+	default:
+		name = names[len(names)-1]
+	}
+	if opt.anonymousTypes == nil {
+		opt.anonymousTypes = make(map[string]string)
+	}
+	if taken, ok := opt.anonymousTypes[name]; ok && taken != parent {
+		var path string
+		for _, n := range names {
+			path += MakeFirstUpperCase(n)
+		}
+		path += strings.TrimPrefix(name, names[len(names)-1])
+		name = path
+		for i := 2; opt.anonymousTypes[name] != "" && opt.anonymousTypes[name] != parent; i++ {
+			name = fmt.Sprintf("%s%d", path, i)
+		}
+	}
+	opt.anonymousTypes[name] = parent
+	return
 }
 
-func (opt *Options) parseNS(str string) (ns string) {
-	return opt.LocalNameNSMap[getNSPrefix(str)]
+// conditionallyExcluded reports whether the element is to be ignored by the
+// conditional inclusion attributes of the XML Schema versioning namespace,
+// with the XML Schema version of the parser which defaults to 1.1. A type is
+// available when it's a built-in data type, and a facet is available when
+// the parser has a handler registered for it.
+// https://www.w3.org/TR/xmlschema11-1/#cip
+func (opt *Options) conditionallyExcluded(element xml.StartElement) bool {
+	version, err := strconv.ParseFloat(opt.XSDVersion, 64)
+	if err != nil {
+		version = 1.1
+	}
+	available := func(value string, names func(xml.Name) bool) bool {
+		scope, _ := opt.NSScope.Peek().(map[string]string)
+		for _, name := range strings.Fields(value) {
+			if !names(xml.Name{Space: scope[getNSPrefix(name)], Local: trimNSPrefix(name)}) {
+				return false
+			}
+		}
+		return true
+	}
+	isType := func(name xml.Name) bool {
+		_, ok := BuildInTypes[name.Local]
+		return ok && name.Space == xsdNamespace
+	}
+	isFacet := func(name xml.Name) bool {
+		_, ok := opt.Handlers[name]
+		return ok
+	}
+	for _, attr := range element.Attr {
+		if attr.Name.Space != vcNamespace {
+			continue
+		}
+		switch attr.Name.Local {
+		case "minVersion":
+			if v, err := strconv.ParseFloat(attr.Value, 64); err == nil && version < v {
+				return true
+			}
+		case "maxVersion":
+			if v, err := strconv.ParseFloat(attr.Value, 64); err == nil && version >= v {
+				return true
+			}
+		case "typeAvailable":
+			if !available(attr.Value, isType) {
+				return true
+			}
+		case "typeUnavailable":
+			if available(attr.Value, isType) {
+				return true
+			}
+		case "facetAvailable":
+			if !available(attr.Value, isFacet) {
+				return true
+			}
+		case "facetUnavailable":
+			if available(attr.Value, isFacet) {
+				return true
+			}
+		}
+	}
+	return false
 }
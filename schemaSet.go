@@ -0,0 +1,649 @@
+// Copyright 2020 - 2021 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+)
+
+// Schema holds the component model of a parsed XML schema document, the
+// GlobalElements are the top-level element declarations of the document, the
+// Redefines are the <redefine> and <override> elements of the document. The
+// URL is the location of a remote document, whose FilePath is the path of
+// its copy in the schema cache. The References are the <include>, <import>,
+// <redefine> and <override> elements of the document in document order. The
+// Chameleons of a document without target namespace are its components
+// taking on the target namespaces of the documents including it, keyed by
+// the namespace. The code of the components is generated by the document.
+// The Annotations are the annotations of the <schema> element.
+type Schema struct {
+	FilePath        string
+	URL             string
+	TargetNamespace string
+	ProtoTree       []interface{}
+	GlobalElements  []*Element
+	Redefines       []*Redefine
+	References      []*SchemaReference
+	Chameleons      map[string]*Schema
+	Annotations     []*Annotation
+}
+
+// SchemaReference describes a reference of a schema document to another
+// schema document. The Kind is the local name of the referencing element,
+// the FilePath is the local path of the referenced document, which is empty
+// when the document can't be located, such as an import without schema
+// location. The Namespace is the namespace of an import, or the namespace
+// the components of a chameleon included document take on.
+type SchemaReference struct {
+	Kind           string
+	Namespace      string
+	SchemaLocation string
+	FilePath       string
+}
+
+// SchemaSet holds the schema documents loaded by the Load, including the
+// documents referenced by <import> or <include> statements, and the
+// diagnostics reported while parsing them. The schemas are sorted by file
+// path. The SubstitutionGroups is the substitution group membership graph of
+// the documents keyed by the qualified name of the head elements. The
+// ComplexTypes are the complex type definitions of the documents keyed by
+// their qualified name. The Cycles
+// are the reference cycles between the documents, each cycle is the file
+// paths of the documents on the cycle starting and ending with the same
+// document.
+type SchemaSet struct {
+	Schemas            []*Schema
+	SubstitutionGroups map[xml.Name]*SubstitutionGroup
+	ComplexTypes       map[xml.Name]*ComplexType
+	Cycles             [][]string
+	Diagnostics        Diagnostics
+}
+
+// Schema returns the schema document in the schema set by given file path,
+// or nil if the document is not in the schema set.
+func (set *SchemaSet) Schema(path string) *Schema {
+	i := sort.Search(len(set.Schemas), func(i int) bool {
+		return set.Schemas[i].FilePath >= path
+	})
+	if i < len(set.Schemas) && set.Schemas[i].FilePath == path {
+		return set.Schemas[i]
+	}
+	return nil
+}
+
+// Load reads the XML schema documents by given file or directory paths and
+// returns the schema set with the component model of every document, using
+// the language and input options of the parser. Directories will be walked
+// recursively. Every document is parsed once, the documents referenced by
+// the documents are loaded too. Once every document has been parsed, the
+// references of the components are linked to their definitions, so that the
+// order of the declarations doesn't matter, and the references which remain
+// unresolved are reported as error diagnostics. A document which can't be
+// parsed doesn't stop loading the other documents, the first error
+// diagnostic is returned with the schema set of the documents parsed, and
+// the diagnostics of the schema set hold every problem found. Load never
+// writes to the filesystem, use Generate to produce code from the returned
+// schema set.
+func (opt *Options) Load(paths ...string) (*SchemaSet, error) {
+	var files []string
+	for _, path := range paths {
+		if isValidURL(path) {
+			file, err := opt.schemaPath("", path)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, file)
+			continue
+		}
+		list, err := GetFileList(path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, list...)
+	}
+	loader := newSchemaLoader(opt)
+	var loadErr error
+	for _, file := range files {
+		err := loader.load(file)
+		var diagnostic *Diagnostic
+		if errors.As(err, &diagnostic) {
+			if loadErr == nil {
+				loadErr = diagnostic
+			}
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+	}
+	set := &SchemaSet{Cycles: loader.cycles}
+	for _, schema := range loader.schemas {
+		set.Schemas = append(set.Schemas, schema)
+	}
+	sort.Slice(set.Schemas, func(i, j int) bool {
+		return set.Schemas[i].FilePath < set.Schemas[j].FilePath
+	})
+	for _, schema := range set.Schemas {
+		for _, redefine := range schema.Redefines {
+			if path, err := opt.schemaPath(schema.FilePath, redefine.SchemaLocation); err == nil {
+				applyRedefine(schema, set.Schema(path), redefine, loader.diagnostics)
+			}
+		}
+	}
+	errs := len(loader.diagnostics.Errors())
+	resolveReferences(set.Schemas, opt.Lang, loader.diagnostics)
+	if resolveErrs := loader.diagnostics.Errors(); loadErr == nil && len(resolveErrs) > errs {
+		loadErr = resolveErrs[errs]
+	}
+	set.ComplexTypes = deriveComplexTypes(set.Schemas)
+	set.Diagnostics = *loader.diagnostics
+	var elements []*Element
+	for _, schema := range set.Schemas {
+		elements = append(elements, schema.GlobalElements...)
+	}
+	set.SubstitutionGroups = substitutionGroups(elements)
+	return set, loadErr
+}
+
+// schemaLoader loads the schema documents of a schema set. A document is
+// parsed once, and the documents it references are loaded when the
+// referencing elements are parsed, before the components of the document,
+// so that the components of the referenced documents are available for
+// looking up types. A document referenced while it's being parsed closes a
+// reference cycle, and its components are not available to the documents on
+// the cycle.
+type schemaLoader struct {
+	options     *Options
+	schemas     map[string]*Schema
+	loading     []string
+	cycles      [][]string
+	diagnostics *Diagnostics
+}
+
+// newSchemaLoader creates a schema loader which parses documents with the
+// language and input options of given parser options.
+func newSchemaLoader(opt *Options) *schemaLoader {
+	diagnostics := opt.Diagnostics
+	if diagnostics == nil {
+		diagnostics = &Diagnostics{}
+	}
+	return &schemaLoader{
+		options:     opt,
+		schemas:     make(map[string]*Schema),
+		diagnostics: diagnostics,
+	}
+}
+
+// load parses the schema document by given local path unless the document
+// has been loaded or is being loaded.
+func (l *schemaLoader) load(path string) error {
+	if _, ok := l.schemas[path]; ok {
+		return nil
+	}
+	for i, loading := range l.loading {
+		if loading == path {
+			l.cycles = append(l.cycles, append(append([]string{}, l.loading[i:]...), path))
+			return nil
+		}
+	}
+	return l.parser(path).Parse()
+}
+
+// parser creates the parser for the schema document by given local path
+// with the language and input options of the schema loader.
+func (l *schemaLoader) parser(path string) *Options {
+	return NewParser(&Options{
+		FilePath:            path,
+		InputDir:            l.options.InputDir,
+		OutputDir:           l.options.OutputDir,
+		Lang:                l.options.Lang,
+		Package:             l.options.Package,
+		XSDVersion:          l.options.XSDVersion,
+		Handlers:            l.options.Handlers,
+		Diagnostics:         l.diagnostics,
+		ProtoTree:           make([]interface{}, 0),
+		Cache:               l.options.Cache,
+		Catalog:             l.options.Catalog,
+		AnonymousTypeNaming: l.options.AnonymousTypeNaming,
+		AnonymousTypeSuffix: l.options.AnonymousTypeSuffix,
+		loader:              l,
+	})
+}
+
+// loadChameleon copies the components of the loaded document without target
+// namespace by given local path for the namespace, the components of the
+// document take on the namespace. The document is parsed once, and a copy is
+// made for each namespace, the documents without target namespace included
+// by the document are copied for the namespace too.
+func (l *schemaLoader) loadChameleon(path, namespace string) {
+	schema, ok := l.schemas[path]
+	if !ok || schema.TargetNamespace != "" {
+		return
+	}
+	if _, ok = schema.Chameleons[namespace]; ok {
+		return
+	}
+	if schema.Chameleons == nil {
+		schema.Chameleons = make(map[string]*Schema)
+	}
+	chameleon := &chameleon{namespace: namespace, buildInTypes: buildInTypeNames(l.options.Lang)}
+	schema.Chameleons[namespace] = chameleon.schema(schema)
+	for _, reference := range schema.References {
+		if reference.Kind == "include" && reference.FilePath != "" {
+			l.loadChameleon(reference.FilePath, namespace)
+		}
+	}
+}
+
+// add records the parsed schema document.
+func (l *schemaLoader) add(schema *Schema) {
+	l.schemas[schema.FilePath] = schema
+}
+
+// loadReference records the reference of the document being parsed to
+// another schema document, and loads the referenced document. A referenced
+// document which can't be located or read is reported as a diagnostic. The
+// document without target namespace included by a document with target
+// namespace is loaded as a chameleon for the target namespace.
+func (opt *Options) loadReference(reference *SchemaReference) (err error) {
+	opt.References = append(opt.References, reference)
+	if reference.SchemaLocation == "" {
+		return
+	}
+	path, ok := opt.locateSchema(reference.SchemaLocation)
+	if !ok {
+		return
+	}
+	if _, err = os.Stat(path); err != nil {
+		opt.report(DiagnosticUnresolvedLocation, "unresolved schema location %s: %s", reference.SchemaLocation, err)
+		return nil
+	}
+	reference.FilePath = path
+	if err = opt.loader.load(path); err == nil && reference.Kind == "include" && opt.TargetNamespace != "" {
+		if schema, ok := opt.loader.schemas[path]; ok && schema.TargetNamespace == "" {
+			reference.Namespace = opt.TargetNamespace
+			opt.loader.loadChameleon(path, reference.Namespace)
+		}
+	}
+	if err != nil {
+		err = fmt.Errorf("%s: %w", path, err)
+	}
+	return
+}
+
+// deriveComplexTypes completes the content of the complex types derived by
+// restriction in given schema documents and their chameleons. A restricted
+// type inherits the attributes and attribute groups of its base type, which
+// are not restated or prohibited by the type, and the value type of a base
+// type with simple content. The content of a type extending a mixed type is
+// mixed. It returns the complex type definitions keyed by their qualified
+// name.
+func deriveComplexTypes(schemas []*Schema) map[xml.Name]*ComplexType {
+	var complexTypes []*ComplexType
+	definitions := make(map[xml.Name]*ComplexType)
+	var walk func(schemas []*Schema)
+	walk = func(schemas []*Schema) {
+		for _, schema := range schemas {
+			for _, ele := range schema.ProtoTree {
+				if complexType, ok := ele.(*ComplexType); ok {
+					complexTypes = append(complexTypes, complexType)
+					if _, ok = definitions[componentName(complexType)]; !ok {
+						definitions[componentName(complexType)] = complexType
+					}
+				}
+			}
+			for _, chameleon := range schema.Chameleons {
+				walk([]*Schema{chameleon})
+			}
+		}
+	}
+	walk(schemas)
+	derived := make(map[*ComplexType]bool)
+	var derive func(complexType *ComplexType)
+	derive = func(complexType *ComplexType) {
+		if derived[complexType] {
+			return
+		}
+		derived[complexType] = true
+		base, ok := definitions[toQName(complexType.BaseNamespace, complexType.Base)]
+		if !ok || complexType.Derivation == "" {
+			complexType.Attributes = permittedAttributes(complexType.Attributes)
+			return
+		}
+		derive(base)
+		if complexType.ValueType == "" {
+			complexType.ValueType = base.ValueType
+		}
+		if complexType.Derivation != DerivationRestriction {
+			complexType.Mixed = complexType.Mixed || base.Mixed
+			return
+		}
+		restated := make(map[string]Attribute)
+		for _, attribute := range complexType.Attributes {
+			restated[attribute.Name] = attribute
+		}
+		var attributes []Attribute
+		for _, attribute := range base.Attributes {
+			if a, ok := restated[attribute.Name]; ok {
+				attribute = a
+				delete(restated, attribute.Name)
+			}
+			attributes = append(attributes, attribute)
+		}
+		for _, attribute := range complexType.Attributes {
+			if _, ok := restated[attribute.Name]; ok {
+				attributes = append(attributes, attribute)
+			}
+		}
+		complexType.Attributes = permittedAttributes(attributes)
+		var attributeGroups []AttributeGroup
+		for _, attributeGroup := range base.AttributeGroup {
+			if !hasAttributeGroup(complexType.AttributeGroup, attributeGroup) {
+				attributeGroups = append(attributeGroups, attributeGroup)
+			}
+		}
+		complexType.AttributeGroup = append(attributeGroups, complexType.AttributeGroup...)
+	}
+	for _, complexType := range complexTypes {
+		derive(complexType)
+	}
+	return definitions
+}
+
+// permittedAttributes returns the attributes which are not prohibited.
+func permittedAttributes(attributes []Attribute) (permitted []Attribute) {
+	for _, attribute := range attributes {
+		if !attribute.Prohibited {
+			permitted = append(permitted, attribute)
+		}
+	}
+	return
+}
+
+// hasAttributeGroup returns whether the attribute group references contain a
+// reference to the same attribute group with given reference.
+func hasAttributeGroup(attributeGroups []AttributeGroup, attributeGroup AttributeGroup) bool {
+	for _, ref := range attributeGroups {
+		if ref.Ref == attributeGroup.Ref && ref.RefNamespace == attributeGroup.RefNamespace {
+			return true
+		}
+	}
+	return false
+}
+
+// substitutionGroups returns the substitution groups formed by given global
+// element declarations keyed by the qualified name of the head elements.
+func substitutionGroups(elements []*Element) map[xml.Name]*SubstitutionGroup {
+	declarations, members := make(map[xml.Name]*Element), make(map[xml.Name][]*Element)
+	for _, e := range elements {
+		declarations[toQName(e.TargetNamespace, e.Name)] = e
+		if e.SubstitutionGroup != "" {
+			head := toQName(e.SubstitutionGroupNamespace, e.SubstitutionGroup)
+			members[head] = append(members[head], e)
+		}
+	}
+	groups := make(map[xml.Name]*SubstitutionGroup)
+	for head := range members {
+		group := &SubstitutionGroup{Head: declarations[head]}
+		if group.Head != nil && !group.Head.Abstract {
+			group.Elements = append(group.Elements, group.Head)
+		}
+		visited, queue := map[xml.Name]bool{head: true}, members[head]
+		for len(queue) > 0 {
+			e := queue[0]
+			queue = queue[1:]
+			name := toQName(e.TargetNamespace, e.Name)
+			if visited[name] {
+				continue
+			}
+			visited[name] = true
+			if !e.Abstract {
+				group.Elements = append(group.Elements, e)
+			}
+			queue = append(queue, members[name]...)
+		}
+		groups[head] = group
+	}
+	return groups
+}
+
+// applyRedefine replaces the components of the redefined schema document by
+// the components of the redefine or override element of the schema document.
+func applyRedefine(schema, redefined *Schema, redefine *Redefine, diagnostics *Diagnostics) {
+	if redefined == nil {
+		return
+	}
+	for _, component := range redefine.Components {
+		i := findComponent(component, redefined.ProtoTree)
+		if i == -1 {
+			// override components without a counterpart are ignored.
+			if !redefine.Override {
+				pos := componentPos(component)
+				*diagnostics = append(*diagnostics, &Diagnostic{
+					File:     schema.FilePath,
+					Line:     pos.Line,
+					Column:   pos.Column,
+					Severity: SeverityWarning,
+					Code:     DiagnosticRedefineNotFound,
+					Message:  fmt.Sprintf("redefined component %s not found in %s", componentName(component).Local, redefine.SchemaLocation),
+				})
+			}
+			continue
+		}
+		if !redefine.Override {
+			redefineComponent(redefined.ProtoTree[i], component)
+		}
+		redefined.ProtoTree[i] = component
+	}
+}
+
+// findComponent returns the index of the component with the same kind and
+// qualified name of given component in the proto tree, or -1 if not found.
+func findComponent(component interface{}, protoTree []interface{}) int {
+	for i, ele := range protoTree {
+		if reflect.TypeOf(ele) == reflect.TypeOf(component) && componentName(ele) == componentName(component) {
+			return i
+		}
+	}
+	return -1
+}
+
+// componentName returns the qualified name of the named component.
+func componentName(component interface{}) xml.Name {
+	switch v := component.(type) {
+	case *SimpleType:
+		return toQName(v.TargetNamespace, v.Name)
+	case *ComplexType:
+		return toQName(v.TargetNamespace, v.Name)
+	case *Group:
+		return toQName(v.TargetNamespace, v.Name)
+	case *AttributeGroup:
+		return toQName(v.TargetNamespace, v.Name)
+	case *Element:
+		return toQName(v.TargetNamespace, v.Name)
+	case *Attribute:
+		return toQName(v.TargetNamespace, v.Name)
+	}
+	return xml.Name{}
+}
+
+// componentPos returns the position of the schema component.
+func componentPos(component interface{}) Position {
+	switch v := component.(type) {
+	case *SimpleType:
+		return v.Pos
+	case *ComplexType:
+		return v.Pos
+	case *Group:
+		return v.Pos
+	case *AttributeGroup:
+		return v.Pos
+	case *Element:
+		return v.Pos
+	case *Attribute:
+		return v.Pos
+	}
+	return Position{}
+}
+
+// redefineComponent completes the component defined in a redefine element by
+// the original component it's defined in terms of. A simple type inherits
+// the facets it doesn't restrict, a complex type extending itself inherits
+// the content of the original type, a complex type restricting itself
+// inherits the attributes of the original type it doesn't restate, and the
+// reference of a group to itself is replaced by the content of the original
+// group.
+func redefineComponent(original, component interface{}) {
+	switch v := component.(type) {
+	case *SimpleType:
+		o := original.(*SimpleType)
+		v.Base, v.BaseNamespace = o.Base, o.BaseNamespace
+		v.List, v.Union, v.MemberTypes, v.MemberTypeNamespaces = o.List, o.Union, o.MemberTypes, o.MemberTypeNamespaces
+		inheritFacets(&v.Restriction, o.Restriction)
+	case *ComplexType:
+		o := original.(*ComplexType)
+		if v.Base != v.Name || v.BaseNamespace != v.TargetNamespace {
+			return
+		}
+		restriction := v.Derivation != DerivationExtension
+		v.Base, v.BaseNamespace, v.Derivation = o.Base, o.BaseNamespace, o.Derivation
+		if v.ValueType == "" {
+			v.ValueType = o.ValueType
+		}
+		if restriction {
+			// a complex type restricting itself restates the content of the
+			// original type, only the attributes not restated are inherited.
+			restated := make(map[string]bool)
+			for _, attribute := range v.Attributes {
+				restated[attribute.Name] = true
+			}
+			var attributes []Attribute
+			for _, attribute := range o.Attributes {
+				if !restated[attribute.Name] {
+					attributes = append(attributes, attribute)
+				}
+			}
+			v.Attributes = append(attributes, v.Attributes...)
+			var attributeGroups []AttributeGroup
+			for _, attributeGroup := range o.AttributeGroup {
+				if !hasAttributeGroup(v.AttributeGroup, attributeGroup) {
+					attributeGroups = append(attributeGroups, attributeGroup)
+				}
+			}
+			v.AttributeGroup = append(attributeGroups, v.AttributeGroup...)
+			return
+		}
+		v.Elements = append(append([]Element{}, o.Elements...), v.Elements...)
+		v.Attributes = append(append([]Attribute{}, o.Attributes...), v.Attributes...)
+		v.Groups = append(append([]Group{}, o.Groups...), v.Groups...)
+		v.Choice = append(append([]Choice{}, o.Choice...), v.Choice...)
+		v.AttributeGroup = append(append([]AttributeGroup{}, o.AttributeGroup...), v.AttributeGroup...)
+		if v.AnyAttribute == nil {
+			v.AnyAttribute = o.AnyAttribute
+		}
+		v.Mixed = v.Mixed || o.Mixed
+		v.Particle = sequenceParticles(o.Particle, v.Particle)
+	case *Group:
+		o := original.(*Group)
+		for i, group := range v.Groups {
+			if group.Ref == v.Name && group.RefNamespace == v.TargetNamespace {
+				v.Groups = append(append(append([]Group{}, v.Groups[:i]...), o.Groups...), v.Groups[i+1:]...)
+				v.Elements = append(append([]Element{}, o.Elements...), v.Elements...)
+				v.Choice = append(append([]Choice{}, o.Choice...), v.Choice...)
+				v.Particle = replaceGroupParticle(v.Particle, group.Name, o.Particle)
+				break
+			}
+		}
+	case *AttributeGroup:
+		o := original.(*AttributeGroup)
+		if v.Ref == v.Name && v.RefNamespace == v.TargetNamespace {
+			v.Ref, v.RefNamespace = "", ""
+			v.Attributes = append(append([]Attribute{}, o.Attributes...), v.Attributes...)
+			if v.AnyAttribute == nil {
+				v.AnyAttribute = o.AnyAttribute
+			}
+		}
+	}
+}
+
+// sequenceParticles returns a sequence of the given particles, the content
+// of a complex type extending itself follows the content of the original
+// type.
+func sequenceParticles(particles ...*Particle) *Particle {
+	sequence := Particle{Kind: ParticleSequence, MinOccurs: 1, MaxOccurs: 1}
+	for _, particle := range particles {
+		if particle != nil {
+			sequence.Particles = append(sequence.Particles, particle)
+		}
+	}
+	if len(sequence.Particles) == 0 {
+		return nil
+	}
+	return &sequence
+}
+
+// replaceGroupParticle returns a copy of the particle tree in which the
+// particle of the reference to the named group is replaced by the given
+// particle.
+func replaceGroupParticle(particle *Particle, name string, replacement *Particle) *Particle {
+	if particle == nil {
+		return nil
+	}
+	if particle.Kind == ParticleGroup && particle.Name == name {
+		return replacement
+	}
+	p := *particle
+	p.Particles = nil
+	for _, child := range particle.Particles {
+		if child = replaceGroupParticle(child, name, replacement); child != nil {
+			p.Particles = append(p.Particles, child)
+		}
+	}
+	return &p
+}
+
+// inheritFacets sets the facets of the restriction which are not specified
+// by the facets of the base restriction.
+func inheritFacets(restriction *Restriction, base Restriction) {
+	if restriction.Enum == nil {
+		restriction.Enum = base.Enum
+	}
+	if restriction.Patterns == nil {
+		restriction.Pattern, restriction.Patterns = base.Pattern, base.Patterns
+	}
+	if restriction.Precision == nil {
+		restriction.Precision = base.Precision
+	}
+	if restriction.TotalDigits == nil {
+		restriction.TotalDigits = base.TotalDigits
+	}
+	if restriction.MinValue == "" {
+		restriction.Min, restriction.MinValue, restriction.MinExclusive = base.Min, base.MinValue, base.MinExclusive
+	}
+	if restriction.MaxValue == "" {
+		restriction.Max, restriction.MaxValue, restriction.MaxExclusive = base.Max, base.MaxValue, base.MaxExclusive
+	}
+	if restriction.Length == nil {
+		restriction.Length = base.Length
+	}
+	if restriction.MinLength == nil {
+		restriction.MinLength = base.MinLength
+	}
+	if restriction.MaxLength == nil {
+		restriction.MaxLength = base.MaxLength
+	}
+	if restriction.WhiteSpace == "" {
+		restriction.WhiteSpace = base.WhiteSpace
+	}
+}
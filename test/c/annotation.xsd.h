@@ -0,0 +1,14 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// PriorityType is Priority of a note.
+typedef int PriorityType;
+
+// NoteEntryType is A note with a title and a priority.
+// Notes are ordered by priority.
+typedef struct {
+	char IdAttr; // attr, optional
+	char Title;
+	int Priority;
+} NoteEntryType;
+
+typedef NoteEntryType NoteEntry;
@@ -38,7 +38,7 @@ typedef struct {
 typedef struct {
 	float CostAttr; // attr, optional
 	char LastUpdatedAttr; // attr, optional
-	MyType7 Nested;
+	MyType7 Nested; // optional
 	char MyType1[];
 	MyType2 MyType2[];
 } TopLevel;
@@ -0,0 +1,35 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// SettingsType ...
+typedef struct {
+	char VersionAttr; // attr, optional
+	bool EnabledAttr; // attr, optional
+	unsigned int RetriesAttr; // attr, optional
+	char Theme; // optional
+	int FontSize; // optional
+	float Scale; // optional
+	char Encoding; // optional
+	char Plugin[];
+} SettingsType;
+
+// UserSettingsType ...
+typedef struct {
+	char UserAttr; // attr, optional
+	char Locale; // optional
+} UserSettingsType;
+
+// ShippingType ...
+typedef struct {
+	char CountryAttr; // attr, optional
+	char City;
+} ShippingType;
+
+// ProfileType ...
+typedef struct {
+	char NameAttr; // attr
+	SettingsType Settings[];
+	UserSettingsType UserSettings; // optional
+	ShippingType Shipping; // optional
+} ProfileType;
+
+typedef ProfileType Profile;
@@ -0,0 +1,38 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// ShipmentType ...
+typedef struct {
+	char CurrencyAttr; // attr, optional
+	char Code;
+	int Quantity;
+	SizeType Size;
+	char Note; // optional
+} ShipmentType;
+
+// ChargeType ...
+typedef struct {
+	char CurrencyAttr; // attr, optional
+} ChargeType;
+
+typedef char Note;
+
+// Currency ...
+typedef char Currency;
+
+// SizeType ...
+typedef struct {
+	char CodeType;
+	int QuantityType;
+} SizeType;
+
+// CodeType ...
+typedef char CodeType;
+
+// QuantityType ...
+typedef int QuantityType;
+
+// AmountType ...
+typedef float AmountType;
+
+// CurrencyType ...
+typedef char CurrencyType;
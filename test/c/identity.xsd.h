@@ -0,0 +1,32 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// ProductType ...
+typedef struct {
+	char SkuAttr; // attr
+	char Title;
+} ProductType;
+
+// OrderType ...
+typedef struct {
+	char IdAttr; // attr
+	char ProductAttr; // attr
+} OrderType;
+
+// ItemType ...
+typedef struct {
+	char SkuAttr; // attr, optional
+} ItemType;
+
+// ShelfType ...
+typedef struct {
+	ItemType Item[];
+} ShelfType;
+
+// CatalogType ...
+typedef struct {
+	ProductType Product[];
+	OrderType Order[];
+	ShelfType Shelf[];
+} CatalogType;
+
+typedef CatalogType Catalog;
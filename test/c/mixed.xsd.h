@@ -0,0 +1,20 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// ParagraphType ...
+typedef struct {
+	char LangAttr; // attr, optional
+	char B[];
+	char I[];
+} ParagraphType;
+
+// NoteType ...
+typedef struct {
+	char AuthorAttr; // attr, optional
+} NoteType;
+
+// Article ...
+typedef struct {
+	char Title;
+	ParagraphType Para[];
+	NoteType Note; // optional
+} Article;
@@ -0,0 +1,33 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// ContactType ...
+typedef struct {
+	char IdAttr; // attr, optional
+	char LangAttr; // attr, optional
+	int VersionAttr; // attr, optional
+	char Name;
+	char Note; // optional
+} ContactType;
+
+// RequiredContactType ...
+typedef struct {
+	char IdAttr; // attr
+	int VersionAttr; // attr, optional
+	char Name;
+} RequiredContactType;
+
+// PriceType ...
+typedef struct {
+	char CurrencyAttr; // attr, optional
+} PriceType;
+
+// EuroPriceType ...
+typedef struct {
+	char CurrencyAttr; // attr
+} EuroPriceType;
+
+// Directory ...
+typedef struct {
+	RequiredContactType Contact[];
+	EuroPriceType Fee;
+} Directory;
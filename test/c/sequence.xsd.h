@@ -0,0 +1,45 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// PartyType ...
+typedef struct {
+	char Name;
+	char Email; // optional
+} PartyType;
+
+// CustomerType ...
+typedef struct {
+	int Account;
+} CustomerType;
+
+// PostalAddressType ...
+typedef struct {
+	char Street;
+	char City;
+} PostalAddressType;
+
+// RemarkGroup ...
+typedef struct {
+	char Remark;
+	int Priority; // optional
+} RemarkGroup;
+
+// WaybillType ...
+typedef struct {
+	char Number;
+	RemarkGroup RemarkGroup; // optional
+	char Parcel;
+	float Weight;
+} WaybillType;
+
+// ManifestType ...
+typedef struct {
+	char Code[];
+	char Note[];
+	RemarkGroup RemarkGroup[];
+} ManifestType;
+
+// Shipment ...
+typedef struct {
+	CustomerType Customer;
+	PostalAddressType Address;
+} Shipment;
@@ -0,0 +1,58 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// ShapeType ...
+typedef struct {
+	char ColorAttr; // attr, optional
+} ShapeType;
+
+// CircleType ...
+typedef struct {
+	int RadiusAttr; // attr, optional
+} CircleType;
+
+// SquareType ...
+typedef struct {
+	int SideAttr; // attr, optional
+} SquareType;
+
+typedef ShapeType Shape;
+
+typedef CircleType Circle;
+
+typedef SquareType Square;
+
+typedef SquareType RoundedSquare;
+
+typedef char Label;
+
+typedef char Caption;
+
+// Gallery ...
+typedef struct {
+	ShapeSubstitution Shape[];
+	LabelSubstitution Label; // optional
+} Gallery;
+
+// Drawing ...
+typedef struct {
+	char Title;
+	ShapeSubstitution Shape[];
+} Drawing;
+
+// ShapeSubstitution is the substitution group of the Shape element.
+typedef union {
+	CircleType Circle;
+	SquareType Square;
+	SquareType RoundedSquare;
+} ShapeSubstitution;
+
+// SquareSubstitution is the substitution group of the Square element.
+typedef union {
+	SquareType Square;
+	SquareType RoundedSquare;
+} SquareSubstitution;
+
+// LabelSubstitution is the substitution group of the Label element.
+typedef union {
+	char Caption;
+} LabelSubstitution;
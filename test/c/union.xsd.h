@@ -0,0 +1,45 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// DimensionTypeMember2 ...
+typedef char DimensionTypeMember2;
+
+// DimensionTypeMember3 ...
+typedef float DimensionTypeMember3;
+
+// DimensionType ...
+typedef struct {
+	char DimensionTypeMember2;
+	float DimensionTypeMember3;
+	int Int;
+} DimensionType;
+
+// MarginMember1 ...
+typedef char MarginMember1;
+
+// MarginMember2 ...
+typedef int MarginMember2;
+
+// Margin ...
+typedef struct {
+	char MarginMember1;
+	int MarginMember2;
+} Margin;
+
+// AlignMember1 ...
+typedef char AlignMember1;
+
+// AlignMember2 ...
+typedef int AlignMember2;
+
+// Align ...
+typedef struct {
+	char AlignMember1;
+	int AlignMember2;
+} Align;
+
+// BoxType ...
+typedef struct {
+	Align AlignAttr; // attr, optional
+	DimensionType Width;
+	Margin Margin;
+} BoxType;
@@ -0,0 +1,26 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// ExtensionType ...
+typedef struct {
+	char AnyAttr[]; // anyAttribute
+	char Id;
+	char Any[]; // any
+} ExtensionType;
+
+// ExtensionGroup ...
+typedef struct {
+	char Any[]; // any
+} ExtensionGroup;
+
+// ExtensionAttributes ...
+typedef struct {
+	char VersionAttr; // attr, optional
+	char AnyAttr[]; // anyAttribute
+} ExtensionAttributes;
+
+// Envelope ...
+typedef struct {
+	char AnyAttr[]; // anyAttribute
+	ExtensionType Header;
+	char Any[]; // any
+} Envelope;
@@ -0,0 +1,33 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// EvenNumber ...
+// assert: $value mod 2 = 0
+typedef int EvenNumber;
+
+// RangeType ...
+// assert: @min le @max
+typedef struct {
+	int MinAttr; // attr, optional
+	int MaxAttr; // attr, optional
+} RangeType;
+
+// VehicleType ...
+typedef struct {
+	char KindAttr; // attr, optional
+	char Any[]; // any
+	int Wheels;
+} VehicleType;
+
+// TruckType ...
+typedef struct {
+	char Any[]; // any
+	float Load;
+} TruckType;
+
+// GarageType ...
+typedef struct {
+	char Any[]; // any
+	RangeType Range;
+} GarageType;
+
+typedef VehicleType Vehicle;
@@ -0,0 +1,17 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+// PriorityType is Priority of a note.
+type PriorityType int
+
+// NoteEntryType is A note with a title and a priority.
+// Notes are ordered by priority.
+type NoteEntryType struct {
+	IdAttr   string `xml:"id,attr,omitempty"`
+	Title    string `xml:"Title"`
+	Priority int    `xml:"Priority"`
+}
+
+// NoteEntry is The note entry.
+type NoteEntry *NoteEntryType
@@ -48,10 +48,75 @@ type MyType7 struct {
 
 // TopLevel ...
 type TopLevel struct {
-	CostAttr        float64    `xml:"cost,attr,omitempty"`
-	LastUpdatedAttr string     `xml:"LastUpdated,attr,omitempty"`
-	Nested          *MyType7   `xml:"nested"`
-	MyType1         [][]byte   `xml:"myType1"`
-	MyType2         []*MyType2 `xml:"myType2"`
+	CostAttr        float64 `xml:"cost,attr,omitempty"`
+	LastUpdatedAttr string  `xml:"LastUpdated,attr,omitempty"`
 	*MyType6
+	Nested           *MyType7                     `xml:"nested,omitempty"`
+	MyType1OrMyType2 TopLevelMyType1OrMyType2List `xml:",any"`
+}
+
+// TopLevelMyType1OrMyType2 is the choice of the myType1 or myType2 element.
+type TopLevelMyType1OrMyType2 struct {
+	Value TopLevelMyType1OrMyType2Value
+}
+
+// TopLevelMyType1OrMyType2Value is implemented by the alternatives of the TopLevelMyType1OrMyType2.
+type TopLevelMyType1OrMyType2Value interface {
+	isTopLevelMyType1OrMyType2()
+}
+
+// TopLevelMyType1OrMyType2MyType1 is the myType1 alternative of the TopLevelMyType1OrMyType2.
+type TopLevelMyType1OrMyType2MyType1 struct {
+	Value []byte
+}
+
+func (TopLevelMyType1OrMyType2MyType1) isTopLevelMyType1OrMyType2() {}
+
+// TopLevelMyType1OrMyType2MyType2 is the myType2 alternative of the TopLevelMyType1OrMyType2.
+type TopLevelMyType1OrMyType2MyType2 struct {
+	Value *MyType2
+}
+
+func (TopLevelMyType1OrMyType2MyType2) isTopLevelMyType1OrMyType2() {}
+
+// UnmarshalXML decodes the alternative of the choice by the name of the element.
+func (v *TopLevelMyType1OrMyType2) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	switch start.Name.Local {
+	case "myType1":
+		var value TopLevelMyType1OrMyType2MyType1
+		err := d.DecodeElement(&value.Value, &start)
+		v.Value = value
+		return err
+	case "myType2":
+		var value TopLevelMyType1OrMyType2MyType2
+		err := d.DecodeElement(&value.Value, &start)
+		v.Value = value
+		return err
+	}
+	return d.Skip()
+}
+
+// MarshalXML encodes the alternative of the choice.
+func (v TopLevelMyType1OrMyType2) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	switch value := v.Value.(type) {
+	case TopLevelMyType1OrMyType2MyType1:
+		return e.EncodeElement(value.Value, xml.StartElement{Name: xml.Name{Local: "myType1"}})
+	case TopLevelMyType1OrMyType2MyType2:
+		return e.EncodeElement(value.Value, xml.StartElement{Name: xml.Name{Local: "myType2"}})
+	}
+	return nil
+}
+
+// TopLevelMyType1OrMyType2List is the list of the TopLevelMyType1OrMyType2.
+type TopLevelMyType1OrMyType2List []TopLevelMyType1OrMyType2
+
+// UnmarshalXML decodes the alternative of the choice, the elements which
+// are not the alternatives are skipped.
+func (v *TopLevelMyType1OrMyType2List) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var union TopLevelMyType1OrMyType2
+	if err := union.UnmarshalXML(d, start); err != nil || union.Value == nil {
+		return err
+	}
+	*v = append(*v, union)
+	return nil
 }
@@ -0,0 +1,15 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+// ItemCountType ...
+type ItemCountType int
+
+// TotalType ...
+type TotalType float64
+
+// AddressType ...
+type AddressType struct {
+	Street string `xml:"Street"`
+	City   string `xml:"City"`
+}
@@ -0,0 +1,146 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+import (
+	"encoding/xml"
+)
+
+// TransferType ...
+type TransferType struct {
+	IBAN string `xml:"IBAN"`
+}
+
+// PaymentType ...
+type PaymentType struct {
+	Amount         float64                   `xml:"Amount"`
+	CardOrTransfer PaymentTypeCardOrTransfer `xml:",any"`
+}
+
+// PaymentTypeCardOrTransfer is the choice of the Card or Transfer element.
+type PaymentTypeCardOrTransfer struct {
+	Value PaymentTypeCardOrTransferValue
+}
+
+// PaymentTypeCardOrTransferValue is implemented by the alternatives of the PaymentTypeCardOrTransfer.
+type PaymentTypeCardOrTransferValue interface {
+	isPaymentTypeCardOrTransfer()
+}
+
+// PaymentTypeCardOrTransferCard is the Card alternative of the PaymentTypeCardOrTransfer.
+type PaymentTypeCardOrTransferCard struct {
+	Value string
+}
+
+func (PaymentTypeCardOrTransferCard) isPaymentTypeCardOrTransfer() {}
+
+// PaymentTypeCardOrTransferTransfer is the Transfer alternative of the PaymentTypeCardOrTransfer.
+type PaymentTypeCardOrTransferTransfer struct {
+	Value *TransferType
+}
+
+func (PaymentTypeCardOrTransferTransfer) isPaymentTypeCardOrTransfer() {}
+
+// UnmarshalXML decodes the alternative of the choice by the name of the element.
+func (v *PaymentTypeCardOrTransfer) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	switch start.Name.Local {
+	case "Card":
+		var value PaymentTypeCardOrTransferCard
+		err := d.DecodeElement(&value.Value, &start)
+		v.Value = value
+		return err
+	case "Transfer":
+		var value PaymentTypeCardOrTransferTransfer
+		err := d.DecodeElement(&value.Value, &start)
+		v.Value = value
+		return err
+	}
+	return d.Skip()
+}
+
+// MarshalXML encodes the alternative of the choice.
+func (v PaymentTypeCardOrTransfer) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	switch value := v.Value.(type) {
+	case PaymentTypeCardOrTransferCard:
+		return e.EncodeElement(value.Value, xml.StartElement{Name: xml.Name{Local: "Card"}})
+	case PaymentTypeCardOrTransferTransfer:
+		return e.EncodeElement(value.Value, xml.StartElement{Name: xml.Name{Local: "Transfer"}})
+	}
+	return nil
+}
+
+// ChannelType ...
+type ChannelType struct {
+	EmailOrPhoneOrFax ChannelTypeEmailOrPhoneOrFax `xml:",any"`
+}
+
+// ChannelTypeEmailOrPhoneOrFax is the choice of the Email, Phone or Fax element.
+type ChannelTypeEmailOrPhoneOrFax struct {
+	Value ChannelTypeEmailOrPhoneOrFaxValue
+}
+
+// ChannelTypeEmailOrPhoneOrFaxValue is implemented by the alternatives of the ChannelTypeEmailOrPhoneOrFax.
+type ChannelTypeEmailOrPhoneOrFaxValue interface {
+	isChannelTypeEmailOrPhoneOrFax()
+}
+
+// ChannelTypeEmailOrPhoneOrFaxEmail is the Email alternative of the ChannelTypeEmailOrPhoneOrFax.
+type ChannelTypeEmailOrPhoneOrFaxEmail struct {
+	Value string
+}
+
+func (ChannelTypeEmailOrPhoneOrFaxEmail) isChannelTypeEmailOrPhoneOrFax() {}
+
+// ChannelTypeEmailOrPhoneOrFaxPhone is the Phone alternative of the ChannelTypeEmailOrPhoneOrFax.
+type ChannelTypeEmailOrPhoneOrFaxPhone struct {
+	Value string
+}
+
+func (ChannelTypeEmailOrPhoneOrFaxPhone) isChannelTypeEmailOrPhoneOrFax() {}
+
+// ChannelTypeEmailOrPhoneOrFaxFax is the Fax alternative of the ChannelTypeEmailOrPhoneOrFax.
+type ChannelTypeEmailOrPhoneOrFaxFax struct {
+	Value string
+}
+
+func (ChannelTypeEmailOrPhoneOrFaxFax) isChannelTypeEmailOrPhoneOrFax() {}
+
+// UnmarshalXML decodes the alternative of the choice by the name of the element.
+func (v *ChannelTypeEmailOrPhoneOrFax) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	switch start.Name.Local {
+	case "Email":
+		var value ChannelTypeEmailOrPhoneOrFaxEmail
+		err := d.DecodeElement(&value.Value, &start)
+		v.Value = value
+		return err
+	case "Phone":
+		var value ChannelTypeEmailOrPhoneOrFaxPhone
+		err := d.DecodeElement(&value.Value, &start)
+		v.Value = value
+		return err
+	case "Fax":
+		var value ChannelTypeEmailOrPhoneOrFaxFax
+		err := d.DecodeElement(&value.Value, &start)
+		v.Value = value
+		return err
+	}
+	return d.Skip()
+}
+
+// MarshalXML encodes the alternative of the choice.
+func (v ChannelTypeEmailOrPhoneOrFax) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	switch value := v.Value.(type) {
+	case ChannelTypeEmailOrPhoneOrFaxEmail:
+		return e.EncodeElement(value.Value, xml.StartElement{Name: xml.Name{Local: "Email"}})
+	case ChannelTypeEmailOrPhoneOrFaxPhone:
+		return e.EncodeElement(value.Value, xml.StartElement{Name: xml.Name{Local: "Phone"}})
+	case ChannelTypeEmailOrPhoneOrFaxFax:
+		return e.EncodeElement(value.Value, xml.StartElement{Name: xml.Name{Local: "Fax"}})
+	}
+	return nil
+}
+
+// Payments ...
+type Payments struct {
+	Payment []*PaymentType `xml:"Payment"`
+}
@@ -0,0 +1,260 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// SettingsType ...
+type SettingsType struct {
+	VersionAttr string   `xml:"version,attr,omitempty"`
+	EnabledAttr bool     `xml:"enabled,attr,omitempty"`
+	RetriesAttr uint16   `xml:"retries,attr,omitempty"`
+	Theme       string   `xml:"Theme,omitempty"`
+	FontSize    int      `xml:"FontSize,omitempty"`
+	Scale       float64  `xml:"Scale,omitempty"`
+	Encoding    string   `xml:"Encoding,omitempty"`
+	Plugin      []string `xml:"Plugin"`
+	absent      map[string]bool
+}
+
+// NewSettingsType returns a new SettingsType initialized with the default and
+// fixed values of its attributes and elements.
+func NewSettingsType() *SettingsType {
+	return &SettingsType{
+		VersionAttr: "2.0",
+		EnabledAttr: true,
+		RetriesAttr: 3,
+		Theme:       "light",
+		FontSize:    12,
+		Scale:       1.5,
+		Encoding:    "UTF-8",
+	}
+}
+
+// checkFixed checks the values of the attributes and elements of the SettingsType
+// with a fixed value.
+func (v *SettingsType) checkFixed() error {
+	if v.VersionAttr != "" && v.VersionAttr != "2.0" {
+		return fmt.Errorf("fixed version: value %v doesn't match %v", v.VersionAttr, "2.0")
+	}
+	if v.Encoding != "" && v.Encoding != "UTF-8" {
+		return fmt.Errorf("fixed Encoding: value %v doesn't match %v", v.Encoding, "UTF-8")
+	}
+	return nil
+}
+
+// UnmarshalXML decodes the element with the default and fixed values of the
+// absent attributes and the empty elements.
+func (v *SettingsType) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	empty, err := decodeElement(d, start, &struct {
+		*SettingsType
+		UnmarshalXML struct{} `xml:"-"`
+	}{SettingsType: v})
+	if err != nil {
+		return err
+	}
+	v.absent = make(map[string]bool)
+	if !hasAttr(start, "version") {
+		v.VersionAttr, v.absent["version"] = "2.0", true
+	}
+	if !hasAttr(start, "enabled") {
+		v.EnabledAttr, v.absent["enabled"] = true, true
+	}
+	if !hasAttr(start, "retries") {
+		v.RetriesAttr, v.absent["retries"] = 3, true
+	}
+	if empty["Theme"] {
+		v.Theme = "light"
+	}
+	if empty["FontSize"] {
+		v.FontSize = 12
+	}
+	if empty["Scale"] {
+		v.Scale = 1.5
+	}
+	if empty["Encoding"] {
+		v.Encoding = "UTF-8"
+	}
+	return nil
+}
+
+// MarshalXML encodes the element without the default and fixed values of the
+// attributes absent from the decoded element, the optional attributes with a
+// zero value are absent from the element which isn't decoded.
+func (v SettingsType) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	value := struct {
+		*SettingsType
+		MarshalXML  struct{} `xml:"-"`
+		VersionAttr *string  `xml:"version,attr"`
+		EnabledAttr *bool    `xml:"enabled,attr"`
+		RetriesAttr *uint16  `xml:"retries,attr"`
+	}{SettingsType: &v}
+	if v.absent == nil {
+		v.absent = map[string]bool{
+			"version": v.VersionAttr == "",
+			"enabled": v.EnabledAttr == false,
+			"retries": v.RetriesAttr == 0,
+		}
+	}
+	if !v.absent["version"] {
+		value.VersionAttr = &v.VersionAttr
+	}
+	if !v.absent["enabled"] {
+		value.EnabledAttr = &v.EnabledAttr
+	}
+	if !v.absent["retries"] {
+		value.RetriesAttr = &v.RetriesAttr
+	}
+	return e.EncodeElement(value, start)
+}
+
+// UserSettingsType ...
+type UserSettingsType struct {
+	UserAttr string `xml:"user,attr,omitempty"`
+	*SettingsType
+	Locale string `xml:"Locale,omitempty"`
+	absent map[string]bool
+}
+
+// UnmarshalXML decodes the element with the default and fixed values of the
+// absent attributes and the empty elements.
+func (v *UserSettingsType) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	if v.SettingsType == nil {
+		v.SettingsType = new(SettingsType)
+	}
+	empty, err := decodeElement(d, start, &struct {
+		*UserSettingsType
+		UnmarshalXML struct{} `xml:"-"`
+	}{UserSettingsType: v})
+	if err != nil {
+		return err
+	}
+	v.absent = make(map[string]bool)
+	if !hasAttr(start, "version") {
+		v.VersionAttr, v.absent["version"] = "2.0", true
+	}
+	if !hasAttr(start, "enabled") {
+		v.EnabledAttr, v.absent["enabled"] = true, true
+	}
+	if !hasAttr(start, "retries") {
+		v.RetriesAttr, v.absent["retries"] = 3, true
+	}
+	if empty["Theme"] {
+		v.Theme = "light"
+	}
+	if empty["FontSize"] {
+		v.FontSize = 12
+	}
+	if empty["Scale"] {
+		v.Scale = 1.5
+	}
+	if empty["Encoding"] {
+		v.Encoding = "UTF-8"
+	}
+	return nil
+}
+
+// MarshalXML encodes the element without the default and fixed values of the
+// attributes absent from the decoded element, the optional attributes with a
+// zero value are absent from the element which isn't decoded.
+func (v UserSettingsType) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	value := struct {
+		*UserSettingsType
+		MarshalXML  struct{} `xml:"-"`
+		VersionAttr *string  `xml:"version,attr"`
+		EnabledAttr *bool    `xml:"enabled,attr"`
+		RetriesAttr *uint16  `xml:"retries,attr"`
+	}{UserSettingsType: &v}
+	if v.absent == nil {
+		v.absent = map[string]bool{
+			"version": v.SettingsType == nil || v.VersionAttr == "",
+			"enabled": v.SettingsType == nil || v.EnabledAttr == false,
+			"retries": v.SettingsType == nil || v.RetriesAttr == 0,
+		}
+	}
+	if v.SettingsType != nil && !v.absent["version"] {
+		value.VersionAttr = &v.VersionAttr
+	}
+	if v.SettingsType != nil && !v.absent["enabled"] {
+		value.EnabledAttr = &v.EnabledAttr
+	}
+	if v.SettingsType != nil && !v.absent["retries"] {
+		value.RetriesAttr = &v.RetriesAttr
+	}
+	return e.EncodeElement(value, start)
+}
+
+// ShippingType ...
+type ShippingType struct {
+	CountryAttr string `xml:"country,attr,omitempty"`
+	City        string `xml:"City"`
+	absent      map[string]bool
+}
+
+// NewShippingType returns a new ShippingType initialized with the default and
+// fixed values of its attributes and elements.
+func NewShippingType() *ShippingType {
+	return &ShippingType{
+		CountryAttr: "US",
+	}
+}
+
+// checkFixed checks the values of the attributes and elements of the ShippingType
+// with a fixed value.
+func (v *ShippingType) checkFixed() error {
+	if v.CountryAttr != "" && v.CountryAttr != "US" {
+		return fmt.Errorf("fixed country: value %v doesn't match %v", v.CountryAttr, "US")
+	}
+	return nil
+}
+
+// UnmarshalXML decodes the element with the default and fixed values of the
+// absent attributes and the empty elements.
+func (v *ShippingType) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	_, err := decodeElement(d, start, &struct {
+		*ShippingType
+		UnmarshalXML struct{} `xml:"-"`
+	}{ShippingType: v})
+	if err != nil {
+		return err
+	}
+	v.absent = make(map[string]bool)
+	if !hasAttr(start, "country") {
+		v.CountryAttr, v.absent["country"] = "US", true
+	}
+	return nil
+}
+
+// MarshalXML encodes the element without the default and fixed values of the
+// attributes absent from the decoded element, the optional attributes with a
+// zero value are absent from the element which isn't decoded.
+func (v ShippingType) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	value := struct {
+		*ShippingType
+		MarshalXML  struct{} `xml:"-"`
+		CountryAttr *string  `xml:"country,attr"`
+	}{ShippingType: &v}
+	if v.absent == nil {
+		v.absent = map[string]bool{
+			"country": v.CountryAttr == "",
+		}
+	}
+	if !v.absent["country"] {
+		value.CountryAttr = &v.CountryAttr
+	}
+	return e.EncodeElement(value, start)
+}
+
+// ProfileType ...
+type ProfileType struct {
+	NameAttr     string            `xml:"name,attr"`
+	Settings     []*SettingsType   `xml:"Settings"`
+	UserSettings *UserSettingsType `xml:"UserSettings,omitempty"`
+	Shipping     *ShippingType     `xml:"Shipping,omitempty"`
+}
+
+// Profile ...
+type Profile *ProfileType
@@ -0,0 +1,83 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+import (
+	"encoding/xml"
+)
+
+// ShipmentType ...
+type ShipmentType struct {
+	CurrencyAttr string    `xml:"currency,attr,omitempty"`
+	Code         string    `xml:"Code"`
+	Quantity     int       `xml:"Quantity"`
+	Size         *SizeType `xml:"Size"`
+	Note         string    `xml:"Note,omitempty"`
+}
+
+// ChargeType ...
+type ChargeType struct {
+	CurrencyAttr string  `xml:"currency,attr,omitempty"`
+	Value        float64 `xml:",chardata"`
+}
+
+// Note ...
+type Note string
+
+// Currency ...
+type Currency string
+
+// SizeType ...
+type SizeType struct {
+	CodeType     *string
+	QuantityType *int
+}
+
+// UnmarshalXML decodes the value of the element into the first member type
+// of the union accepting it.
+func (v *SizeType) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var value string
+	if err := d.DecodeElement(&value, &start); err != nil {
+		return err
+	}
+	return v.UnmarshalXMLAttr(xml.Attr{Name: start.Name, Value: value})
+}
+
+// UnmarshalXMLAttr decodes the value of the attribute into the first member
+// type of the union accepting it.
+func (v *SizeType) UnmarshalXMLAttr(attr xml.Attr) error {
+	*v = SizeType{}
+	return decodeUnion(attr.Value, []interface{}{&v.CodeType, &v.QuantityType}, nil)
+}
+
+// MarshalXML encodes the value of the member type of the union as the
+// element.
+func (v SizeType) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	value, _, err := encodeUnion(v.CodeType, v.QuantityType)
+	if err != nil {
+		return err
+	}
+	return e.EncodeElement(value, start)
+}
+
+// MarshalXMLAttr encodes the value of the member type of the union as the
+// attribute, the union without value is absent.
+func (v SizeType) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	value, ok, err := encodeUnion(v.CodeType, v.QuantityType)
+	if !ok || err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: value}, nil
+}
+
+// CodeType ...
+type CodeType string
+
+// QuantityType ...
+type QuantityType int
+
+// AmountType ...
+type AmountType float64
+
+// CurrencyType ...
+type CurrencyType string
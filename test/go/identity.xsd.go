@@ -0,0 +1,87 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+import (
+	"encoding/xml"
+)
+
+// ProductType ...
+type ProductType struct {
+	SkuAttr string `xml:"sku,attr"`
+	Title   string `xml:"Title"`
+}
+
+// OrderType ...
+type OrderType struct {
+	IdAttr      string `xml:"id,attr"`
+	ProductAttr string `xml:"product,attr"`
+}
+
+// ItemType ...
+type ItemType struct {
+	SkuAttr string `xml:"sku,attr,omitempty"`
+	absent  map[string]bool
+}
+
+// absentAttrs returns the attributes absent from the decoded element by
+// their names.
+func (v *ItemType) absentAttrs() map[string]bool {
+	return v.absent
+}
+
+// UnmarshalXML decodes the element with the default and fixed values of the
+// absent attributes and the empty elements.
+func (v *ItemType) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	_, err := decodeElement(d, start, &struct {
+		*ItemType
+		UnmarshalXML struct{} `xml:"-"`
+	}{ItemType: v})
+	if err != nil {
+		return err
+	}
+	v.absent = make(map[string]bool)
+	v.absent["sku"] = !hasAttr(start, "sku")
+	return nil
+}
+
+// ShelfType ...
+type ShelfType struct {
+	Item []*ItemType `xml:"Item"`
+}
+
+// CatalogType ...
+type CatalogType struct {
+	Product []*ProductType `xml:"Product"`
+	Order   []*OrderType   `xml:"Order"`
+	Shelf   []*ShelfType   `xml:"Shelf"`
+}
+
+// Catalog ...
+type Catalog *CatalogType
+
+// CheckCatalogConstraints checks the identity constraints of the Catalog
+// element on the decoded document.
+func CheckCatalogConstraints(v interface{}) error {
+	values := map[string]map[string]bool{}
+	if err := checkIdentityConstraint(v, "key", "productKey", "Product", []string{"@sku"}, "", values); err != nil {
+		return err
+	}
+	if err := checkIdentityConstraint(v, "unique", "orderId", "Order", []string{"@id"}, "", values); err != nil {
+		return err
+	}
+	if err := checkIdentityConstraint(v, "keyref", "orderProduct", "Order", []string{"@product"}, "productKey", values); err != nil {
+		return err
+	}
+	return nil
+}
+
+// CheckCatalogTypeShelfConstraints checks the identity constraints of the
+// Shelf element of the CatalogType on the decoded document.
+func CheckCatalogTypeShelfConstraints(v interface{}) error {
+	values := map[string]map[string]bool{}
+	if err := checkIdentityConstraint(v, "unique", "shelfItem", "Item", []string{"@sku"}, "", values); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,9 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+// InvoiceType ...
+type InvoiceType struct {
+	TotalAttr float64      `xml:"total,attr,omitempty"`
+	BillTo    *AddressType `xml:"BillTo"`
+}
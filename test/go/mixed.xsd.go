@@ -0,0 +1,45 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+import (
+	"encoding/xml"
+)
+
+// ParagraphType ...
+type ParagraphType struct {
+	LangAttr string       `xml:"lang,attr,omitempty"`
+	Content  MixedContent `xml:",any"`
+}
+
+// UnmarshalXML decodes the attributes and the mixed content of the element.
+func (v *ParagraphType) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	v.Content, err = decodeMixedContent(d, start, &struct {
+		*ParagraphType
+		UnmarshalXML struct{} `xml:"-"`
+	}{ParagraphType: v})
+	return
+}
+
+// NoteType ...
+type NoteType struct {
+	AuthorAttr string `xml:"author,attr,omitempty"`
+	*ParagraphType
+	Content MixedContent `xml:",any"`
+}
+
+// UnmarshalXML decodes the attributes and the mixed content of the element.
+func (v *NoteType) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error) {
+	v.Content, err = decodeMixedContent(d, start, &struct {
+		*NoteType
+		UnmarshalXML struct{} `xml:"-"`
+	}{NoteType: v})
+	return
+}
+
+// Article ...
+type Article struct {
+	Title string           `xml:"Title"`
+	Para  []*ParagraphType `xml:"Para"`
+	Note  *NoteType        `xml:"Note,omitempty"`
+}
@@ -0,0 +1,9 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+// PurchaseOrderType ...
+type PurchaseOrderType struct {
+	QuantityAttr int          `xml:"quantity,attr,omitempty"`
+	ShipTo       *AddressType `xml:"ShipTo"`
+}
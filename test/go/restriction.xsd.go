@@ -0,0 +1,37 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+// ContactType ...
+type ContactType struct {
+	IdAttr      string `xml:"id,attr,omitempty"`
+	LangAttr    string `xml:"lang,attr,omitempty"`
+	VersionAttr int    `xml:"version,attr,omitempty"`
+	Name        string `xml:"Name"`
+	Note        string `xml:"Note,omitempty"`
+}
+
+// RequiredContactType ...
+type RequiredContactType struct {
+	IdAttr      string `xml:"id,attr"`
+	VersionAttr int    `xml:"version,attr,omitempty"`
+	Name        string `xml:"Name"`
+}
+
+// PriceType ...
+type PriceType struct {
+	CurrencyAttr string  `xml:"currency,attr,omitempty"`
+	Value        float64 `xml:",chardata"`
+}
+
+// EuroPriceType ...
+type EuroPriceType struct {
+	CurrencyAttr string  `xml:"currency,attr"`
+	Value        float64 `xml:",chardata"`
+}
+
+// Directory ...
+type Directory struct {
+	Contact []*RequiredContactType `xml:"Contact"`
+	Fee     *EuroPriceType         `xml:"Fee"`
+}
@@ -0,0 +1,49 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+// PartyType ...
+type PartyType struct {
+	Name  string `xml:"Name"`
+	Email string `xml:"Email,omitempty"`
+}
+
+// CustomerType ...
+type CustomerType struct {
+	*PartyType
+	Account int `xml:"Account"`
+}
+
+// PostalAddressType ...
+type PostalAddressType struct {
+	Street string `xml:"Street"`
+	City   string `xml:"City"`
+}
+
+// RemarkGroup ...
+type RemarkGroup struct {
+	Remark   string `xml:"Remark"`
+	Priority int    `xml:"Priority,omitempty"`
+}
+
+// WaybillType ...
+type WaybillType struct {
+	Number string `xml:"Number"`
+	*RemarkGroup
+	Parcel string  `xml:"Parcel"`
+	Weight float64 `xml:"Weight"`
+}
+
+// ManifestType ...
+type ManifestType struct {
+	Code     []string `xml:"Code"`
+	Note     []string `xml:"Note"`
+	Remark   []string `xml:"Remark"`
+	Priority []int    `xml:"Priority"`
+}
+
+// Shipment ...
+type Shipment struct {
+	Customer *CustomerType      `xml:"Customer"`
+	Address  *PostalAddressType `xml:"Address"`
+}
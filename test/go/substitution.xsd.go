@@ -0,0 +1,194 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+import (
+	"encoding/xml"
+)
+
+// ShapeType ...
+type ShapeType struct {
+	ColorAttr string `xml:"color,attr,omitempty"`
+}
+
+// CircleType ...
+type CircleType struct {
+	RadiusAttr int `xml:"radius,attr,omitempty"`
+	*ShapeType
+}
+
+// SquareType ...
+type SquareType struct {
+	SideAttr int `xml:"side,attr,omitempty"`
+	*ShapeType
+}
+
+// Shape ...
+type Shape *ShapeType
+
+// Circle ...
+type Circle *CircleType
+
+// Square ...
+type Square *SquareType
+
+// RoundedSquare ...
+type RoundedSquare *SquareType
+
+// Label ...
+type Label string
+
+// Caption ...
+type Caption string
+
+// Gallery ...
+type Gallery struct {
+	Circle        []*CircleType `xml:"http://example.org/substitution Circle"`
+	Square        []*SquareType `xml:"http://example.org/substitution Square"`
+	RoundedSquare []*SquareType `xml:"http://example.org/substitution RoundedSquare"`
+	Caption       string        `xml:"http://example.org/substitution Caption,omitempty"`
+}
+
+// Drawing ...
+type Drawing struct {
+	Title string                `xml:"Title"`
+	Shape ShapeSubstitutionList `xml:",any"`
+}
+
+// ShapeSubstitution is the substitution group of the Shape element.
+type ShapeSubstitution struct {
+	XMLName xml.Name
+	Value   interface{}
+}
+
+// UnmarshalXML decodes the element of the substitution group by its name, the
+// elements which are not in the group are skipped.
+func (v *ShapeSubstitution) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var value interface{}
+	switch start.Name.Local {
+	case "Circle":
+		value = new(CircleType)
+	case "Square":
+		value = new(SquareType)
+	case "RoundedSquare":
+		value = new(SquareType)
+	default:
+		return d.Skip()
+	}
+	v.XMLName, v.Value = start.Name, value
+	return d.DecodeElement(v.Value, &start)
+}
+
+// MarshalXML encodes the element of the substitution group, the substitution
+// without value is absent.
+func (v ShapeSubstitution) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if v.Value == nil {
+		return nil
+	}
+	start.Name = v.XMLName
+	return e.EncodeElement(v.Value, start)
+}
+
+// ShapeSubstitutionList is the list of the ShapeSubstitution.
+type ShapeSubstitutionList []ShapeSubstitution
+
+// UnmarshalXML decodes the element of the substitution group, the elements
+// which are not in the group are skipped.
+func (v *ShapeSubstitutionList) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var substitution ShapeSubstitution
+	if err := substitution.UnmarshalXML(d, start); err != nil || substitution.Value == nil {
+		return err
+	}
+	*v = append(*v, substitution)
+	return nil
+}
+
+// SquareSubstitution is the substitution group of the Square element.
+type SquareSubstitution struct {
+	XMLName xml.Name
+	Value   interface{}
+}
+
+// UnmarshalXML decodes the element of the substitution group by its name, the
+// elements which are not in the group are skipped.
+func (v *SquareSubstitution) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var value interface{}
+	switch start.Name.Local {
+	case "Square":
+		value = new(SquareType)
+	case "RoundedSquare":
+		value = new(SquareType)
+	default:
+		return d.Skip()
+	}
+	v.XMLName, v.Value = start.Name, value
+	return d.DecodeElement(v.Value, &start)
+}
+
+// MarshalXML encodes the element of the substitution group, the substitution
+// without value is absent.
+func (v SquareSubstitution) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if v.Value == nil {
+		return nil
+	}
+	start.Name = v.XMLName
+	return e.EncodeElement(v.Value, start)
+}
+
+// SquareSubstitutionList is the list of the SquareSubstitution.
+type SquareSubstitutionList []SquareSubstitution
+
+// UnmarshalXML decodes the element of the substitution group, the elements
+// which are not in the group are skipped.
+func (v *SquareSubstitutionList) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var substitution SquareSubstitution
+	if err := substitution.UnmarshalXML(d, start); err != nil || substitution.Value == nil {
+		return err
+	}
+	*v = append(*v, substitution)
+	return nil
+}
+
+// LabelSubstitution is the substitution group of the Label element.
+type LabelSubstitution struct {
+	XMLName xml.Name
+	Value   interface{}
+}
+
+// UnmarshalXML decodes the element of the substitution group by its name, the
+// elements which are not in the group are skipped.
+func (v *LabelSubstitution) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var value interface{}
+	switch start.Name.Local {
+	case "Caption":
+		value = new(string)
+	default:
+		return d.Skip()
+	}
+	v.XMLName, v.Value = start.Name, value
+	return d.DecodeElement(v.Value, &start)
+}
+
+// MarshalXML encodes the element of the substitution group, the substitution
+// without value is absent.
+func (v LabelSubstitution) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if v.Value == nil {
+		return nil
+	}
+	start.Name = v.XMLName
+	return e.EncodeElement(v.Value, start)
+}
+
+// LabelSubstitutionList is the list of the LabelSubstitution.
+type LabelSubstitutionList []LabelSubstitution
+
+// UnmarshalXML decodes the element of the substitution group, the elements
+// which are not in the group are skipped.
+func (v *LabelSubstitutionList) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var substitution LabelSubstitution
+	if err := substitution.UnmarshalXML(d, start); err != nil || substitution.Value == nil {
+		return err
+	}
+	*v = append(*v, substitution)
+	return nil
+}
@@ -0,0 +1,162 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+import (
+	"encoding/xml"
+)
+
+// DimensionTypeMember2 ...
+type DimensionTypeMember2 string
+
+// DimensionTypeMember3 ...
+type DimensionTypeMember3 float64
+
+// DimensionType ...
+type DimensionType struct {
+	Int                  *int
+	DimensionTypeMember2 *string
+	DimensionTypeMember3 *float64
+}
+
+// UnmarshalXML decodes the value of the element into the first member type
+// of the union accepting it.
+func (v *DimensionType) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var value string
+	if err := d.DecodeElement(&value, &start); err != nil {
+		return err
+	}
+	return v.UnmarshalXMLAttr(xml.Attr{Name: start.Name, Value: value})
+}
+
+// UnmarshalXMLAttr decodes the value of the attribute into the first member
+// type of the union accepting it.
+func (v *DimensionType) UnmarshalXMLAttr(attr xml.Attr) error {
+	*v = DimensionType{}
+	return decodeUnion(attr.Value, []interface{}{&v.Int, &v.DimensionTypeMember2, &v.DimensionTypeMember3}, [][]string{nil, {"auto", "inherit"}, nil})
+}
+
+// MarshalXML encodes the value of the member type of the union as the
+// element.
+func (v DimensionType) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	value, _, err := encodeUnion(v.Int, v.DimensionTypeMember2, v.DimensionTypeMember3)
+	if err != nil {
+		return err
+	}
+	return e.EncodeElement(value, start)
+}
+
+// MarshalXMLAttr encodes the value of the member type of the union as the
+// attribute, the union without value is absent.
+func (v DimensionType) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	value, ok, err := encodeUnion(v.Int, v.DimensionTypeMember2, v.DimensionTypeMember3)
+	if !ok || err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: value}, nil
+}
+
+// MarginMember1 ...
+type MarginMember1 string
+
+// MarginMember2 ...
+type MarginMember2 int
+
+// Margin ...
+type Margin struct {
+	MarginMember1 *string
+	MarginMember2 *int
+}
+
+// UnmarshalXML decodes the value of the element into the first member type
+// of the union accepting it.
+func (v *Margin) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var value string
+	if err := d.DecodeElement(&value, &start); err != nil {
+		return err
+	}
+	return v.UnmarshalXMLAttr(xml.Attr{Name: start.Name, Value: value})
+}
+
+// UnmarshalXMLAttr decodes the value of the attribute into the first member
+// type of the union accepting it.
+func (v *Margin) UnmarshalXMLAttr(attr xml.Attr) error {
+	*v = Margin{}
+	return decodeUnion(attr.Value, []interface{}{&v.MarginMember1, &v.MarginMember2}, [][]string{{"none"}, nil})
+}
+
+// MarshalXML encodes the value of the member type of the union as the
+// element.
+func (v Margin) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	value, _, err := encodeUnion(v.MarginMember1, v.MarginMember2)
+	if err != nil {
+		return err
+	}
+	return e.EncodeElement(value, start)
+}
+
+// MarshalXMLAttr encodes the value of the member type of the union as the
+// attribute, the union without value is absent.
+func (v Margin) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	value, ok, err := encodeUnion(v.MarginMember1, v.MarginMember2)
+	if !ok || err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: value}, nil
+}
+
+// AlignMember1 ...
+type AlignMember1 string
+
+// AlignMember2 ...
+type AlignMember2 int
+
+// Align ...
+type Align struct {
+	AlignMember1 *string
+	AlignMember2 *int
+}
+
+// UnmarshalXML decodes the value of the element into the first member type
+// of the union accepting it.
+func (v *Align) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var value string
+	if err := d.DecodeElement(&value, &start); err != nil {
+		return err
+	}
+	return v.UnmarshalXMLAttr(xml.Attr{Name: start.Name, Value: value})
+}
+
+// UnmarshalXMLAttr decodes the value of the attribute into the first member
+// type of the union accepting it.
+func (v *Align) UnmarshalXMLAttr(attr xml.Attr) error {
+	*v = Align{}
+	return decodeUnion(attr.Value, []interface{}{&v.AlignMember1, &v.AlignMember2}, [][]string{{"left", "right"}, nil})
+}
+
+// MarshalXML encodes the value of the member type of the union as the
+// element.
+func (v Align) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	value, _, err := encodeUnion(v.AlignMember1, v.AlignMember2)
+	if err != nil {
+		return err
+	}
+	return e.EncodeElement(value, start)
+}
+
+// MarshalXMLAttr encodes the value of the member type of the union as the
+// attribute, the union without value is absent.
+func (v Align) MarshalXMLAttr(name xml.Name) (xml.Attr, error) {
+	value, ok, err := encodeUnion(v.AlignMember1, v.AlignMember2)
+	if !ok || err != nil {
+		return xml.Attr{}, err
+	}
+	return xml.Attr{Name: name, Value: value}, nil
+}
+
+// BoxType ...
+type BoxType struct {
+	AlignAttr *Align         `xml:"align,attr,omitempty"`
+	Width     *DimensionType `xml:"Width"`
+	Margin    *Margin        `xml:"Margin"`
+}
@@ -0,0 +1,32 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+import (
+	"encoding/xml"
+)
+
+// ExtensionType ...
+type ExtensionType struct {
+	AnyAttr []xml.Attr   `xml:",any,attr"`
+	Id      string       `xml:"id"`
+	Any     []AnyElement `xml:",any"`
+}
+
+// ExtensionGroup ...
+type ExtensionGroup struct {
+	Any []AnyElement `xml:",any"`
+}
+
+// ExtensionAttributes ...
+type ExtensionAttributes struct {
+	VersionAttr string     `xml:"version,attr,omitempty"`
+	AnyAttr     []xml.Attr `xml:",any,attr"`
+}
+
+// Envelope ...
+type Envelope struct {
+	AnyAttr []xml.Attr     `xml:",any,attr"`
+	Header  *ExtensionType `xml:"header"`
+	Any     []AnyElement   `xml:",any"`
+}
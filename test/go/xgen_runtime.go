@@ -0,0 +1,400 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+import (
+	"encoding"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// AnyElement holds the raw XML of an element matched by an element wildcard.
+type AnyElement struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	InnerXML string     `xml:",innerxml"`
+}
+
+// MixedContent holds the character data and the elements of mixed content in
+// document order.
+type MixedContent []MixedItem
+
+// MixedItem is the character data of Text or the Element of mixed content.
+type MixedItem struct {
+	Text    string
+	Element *AnyElement
+}
+
+// MarshalXML encodes the character data and the elements in document order.
+func (c MixedContent) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	for _, item := range c {
+		if item.Element != nil {
+			if err := e.Encode(item.Element); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := e.EncodeToken(xml.CharData(item.Text)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeMixedContent decodes the attributes of the element into v, and
+// returns the character data and the elements of its content in document
+// order.
+func decodeMixedContent(d *xml.Decoder, start xml.StartElement, v interface{}) (MixedContent, error) {
+	if err := xml.NewTokenDecoder(&tokenReader{start, start.End()}).Decode(v); err != nil {
+		return nil, err
+	}
+	var content MixedContent
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch token := token.(type) {
+		case xml.CharData:
+			content = append(content, MixedItem{Text: string(token)})
+		case xml.StartElement:
+			element := new(AnyElement)
+			if err = d.DecodeElement(element, &token); err != nil {
+				return nil, err
+			}
+			content = append(content, MixedItem{Element: element})
+		case xml.EndElement:
+			return content, nil
+		}
+	}
+}
+
+// tokenReader reads the tokens in the slice.
+type tokenReader []xml.Token
+
+// Token returns the next token in the slice.
+func (r *tokenReader) Token() (xml.Token, error) {
+	if len(*r) == 0 {
+		return nil, io.EOF
+	}
+	token := (*r)[0]
+	*r = (*r)[1:]
+	return token, nil
+}
+
+// decodeUnion decodes the value into the first member type of a union
+// accepting it, the members are the pointers to the fields of the member
+// types in declaration order. A member type with enumeration values accepts
+// the enumerated values only.
+func decodeUnion(value string, members []interface{}, enumerations [][]string) error {
+	var text strings.Builder
+	if err := xml.EscapeText(&text, []byte(value)); err != nil {
+		return err
+	}
+	for i, member := range members {
+		if i < len(enumerations) && len(enumerations[i]) > 0 && !enumerated(strings.TrimSpace(value), enumerations[i]) {
+			continue
+		}
+		field := reflect.ValueOf(member).Elem()
+		v := reflect.New(field.Type().Elem())
+		if err := xml.Unmarshal([]byte("<v>"+text.String()+"</v>"), v.Interface()); err == nil {
+			field.Set(v)
+			return nil
+		}
+	}
+	return fmt.Errorf("value %q doesn't match the member types of the union", value)
+}
+
+// enumerated returns whether the value is one of the enumeration values.
+func enumerated(value string, enumeration []string) bool {
+	for _, enum := range enumeration {
+		if value == enum {
+			return true
+		}
+	}
+	return false
+}
+
+// encodeUnion returns the value of the first member type of a union with a
+// value, and whether there is such a member type.
+func encodeUnion(members ...interface{}) (string, bool, error) {
+	for _, member := range members {
+		if reflect.ValueOf(member).IsNil() {
+			continue
+		}
+		switch member := member.(type) {
+		case xml.MarshalerAttr:
+			attr, err := member.MarshalXMLAttr(xml.Name{Local: "v"})
+			return attr.Value, true, err
+		case encoding.TextMarshaler:
+			text, err := member.MarshalText()
+			return string(text), true, err
+		}
+		return fmt.Sprint(reflect.ValueOf(member).Elem().Interface()), true, nil
+	}
+	return "", false, nil
+}
+
+// decodeElement decodes the element into v, and returns whether the child
+// elements present in the element are empty by their local names.
+func decodeElement(d *xml.Decoder, start xml.StartElement, v interface{}) (map[string]bool, error) {
+	tokens, empty := tokenReader{start}, make(map[string]bool)
+	var child string
+	for depth := 0; depth >= 0; {
+		token, err := d.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch token := token.(type) {
+		case xml.StartElement:
+			if depth == 0 {
+				child = token.Name.Local
+			}
+			empty[child] = depth == 0
+			depth++
+		case xml.CharData:
+			if depth == 1 {
+				empty[child] = false
+			}
+		case xml.EndElement:
+			depth--
+		}
+		tokens = append(tokens, xml.CopyToken(token))
+	}
+	return empty, xml.NewTokenDecoder(&tokens).Decode(v)
+}
+
+// hasAttr returns whether the element has the attribute by its local name.
+func hasAttr(start xml.StartElement, name string) bool {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == name {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckFixedValues checks the values of the attributes and elements with a
+// fixed value on the decoded document.
+func CheckFixedValues(v interface{}) error {
+	return checkFixedValues(reflect.ValueOf(v))
+}
+
+// checkFixedValues checks the fixed values of the value and the values of
+// its exported fields, elements and referenced values.
+func checkFixedValues(value reflect.Value) error {
+	switch value.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		return checkFixedValues(value.Elem())
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			if err := checkFixedValues(value.Index(i)); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		if !value.CanAddr() {
+			addressable := reflect.New(value.Type()).Elem()
+			addressable.Set(value)
+			value = addressable
+		}
+		if checker, ok := value.Addr().Interface().(interface{ checkFixed() error }); ok {
+			if err := checker.checkFixed(); err != nil {
+				return err
+			}
+		}
+		for i := 0; i < value.NumField(); i++ {
+			if value.Type().Field(i).PkgPath != "" {
+				continue
+			}
+			if err := checkFixedValues(value.Field(i)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// checkIdentityConstraint checks the key, keyref or unique identity
+// constraint on the decoded value of the element declaring the constraint.
+// The values of the key and unique constraints are recorded in values by the
+// constraint name, to resolve the keyref constraints referring them.
+func checkIdentityConstraint(v interface{}, category, name, selector string, fields []string, refer string, values map[string]map[string]bool) error {
+	seen := map[string]bool{}
+	for _, node := range identitySelect([]reflect.Value{reflect.ValueOf(v)}, selector) {
+		var tuple []string
+		for _, field := range fields {
+			selected := identitySelect([]reflect.Value{node}, field)
+			if len(selected) > 1 {
+				return fmt.Errorf("%s %s: field %s selects more than one value", category, name, field)
+			}
+			if len(selected) == 1 {
+				if text, ok := identityText(selected[0]); ok {
+					tuple = append(tuple, text)
+				}
+			}
+		}
+		if len(tuple) < len(fields) {
+			if category == "key" {
+				return fmt.Errorf("key %s: missing field value", name)
+			}
+			continue
+		}
+		value := strings.Join(tuple, "\x00")
+		if category == "keyref" {
+			if !values[refer][value] {
+				return fmt.Errorf("keyref %s: no %s value matches %s", name, refer, strings.Join(tuple, ", "))
+			}
+			continue
+		}
+		if seen[value] {
+			return fmt.Errorf("%s %s: duplicate value %s", category, name, strings.Join(tuple, ", "))
+		}
+		seen[value] = true
+	}
+	if category != "keyref" {
+		values[name] = seen
+	}
+	return nil
+}
+
+// identitySelect returns the values selected by the restricted XPath
+// expression of the identity constraint from the given values.
+func identitySelect(nodes []reflect.Value, expr string) (selected []reflect.Value) {
+	for _, path := range strings.Split(expr, "|") {
+		current := nodes
+		path = strings.TrimSpace(path)
+		if strings.HasPrefix(path, ".//") {
+			current = identityDescendants(current)
+			path = strings.TrimPrefix(path, ".//")
+		}
+		for _, step := range strings.Split(path, "/") {
+			step = strings.TrimSpace(step)
+			if step == "" || step == "." {
+				continue
+			}
+			attr := strings.HasPrefix(step, "@") || strings.HasPrefix(step, "attribute::")
+			step = strings.TrimPrefix(strings.TrimPrefix(strings.TrimPrefix(step, "@"), "attribute::"), "child::")
+			if i := strings.Index(step, ":"); i != -1 {
+				step = step[i+1:]
+			}
+			var next []reflect.Value
+			for _, node := range current {
+				next = append(next, identityChildren(node, step, attr, nil)...)
+			}
+			current = next
+		}
+		selected = append(selected, current...)
+	}
+	return
+}
+
+// identityDescendants returns the given values and all of their descendant
+// element values.
+func identityDescendants(nodes []reflect.Value) (descendants []reflect.Value) {
+	for len(nodes) > 0 {
+		descendants = append(descendants, nodes...)
+		var next []reflect.Value
+		for _, node := range nodes {
+			next = append(next, identityChildren(node, "*", false, nil)...)
+		}
+		nodes = next
+	}
+	return
+}
+
+// identityChildren returns the child element or attribute values with given
+// local name of the value, the name "*" matches any name. The attributes
+// absent from the decoded element are recorded by the value, or by the value
+// embedding it given by absent, and they are skipped.
+func identityChildren(node reflect.Value, name string, attr bool, absent map[string]bool) (children []reflect.Value) {
+	for node.Kind() == reflect.Ptr || node.Kind() == reflect.Interface {
+		if node.IsNil() {
+			return
+		}
+		node = node.Elem()
+	}
+	if node.Kind() != reflect.Struct {
+		return
+	}
+	if absent == nil && node.CanAddr() {
+		if recorder, ok := node.Addr().Interface().(interface{ absentAttrs() map[string]bool }); ok {
+			absent = recorder.absentAttrs()
+		}
+	}
+	for i := 0; i < node.NumField(); i++ {
+		field := node.Type().Field(i)
+		tag := strings.Split(field.Tag.Get("xml"), ",")
+		if field.PkgPath != "" || field.Name == "XMLName" || tag[0] == "-" {
+			continue
+		}
+		if field.Anonymous && tag[0] == "" {
+			children = append(children, identityChildren(node.Field(i), name, attr, absent)...)
+			continue
+		}
+		isAttr, isElement := false, true
+		for _, option := range tag[1:] {
+			isAttr = isAttr || option == "attr"
+			isElement = isElement && option != "chardata" && option != "innerxml" && option != "comment" && option != "any"
+		}
+		if !isElement || isAttr != attr {
+			continue
+		}
+		fieldName := field.Name
+		if tag[0] != "" {
+			parts := strings.Fields(tag[0])
+			fieldName = parts[len(parts)-1]
+		}
+		if i := strings.LastIndex(fieldName, ">"); i != -1 {
+			fieldName = fieldName[i+1:]
+		}
+		if name != "*" && name != fieldName {
+			continue
+		}
+		value := node.Field(i)
+		if value.Kind() == reflect.Slice && value.Type().Elem().Kind() != reflect.Uint8 {
+			for j := 0; j < value.Len(); j++ {
+				if !identityNil(value.Index(j)) {
+					children = append(children, value.Index(j))
+				}
+			}
+			continue
+		}
+		if identityNil(value) || attr && absent[fieldName] && value.IsZero() {
+			continue
+		}
+		children = append(children, value)
+	}
+	return
+}
+
+// identityNil reports whether the value is a nil pointer or interface, which
+// holds an absent element or attribute.
+func identityNil(value reflect.Value) bool {
+	return (value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface) && value.IsNil()
+}
+
+// identityText returns the text content of the element or attribute value,
+// and whether the value is present.
+func identityText(value reflect.Value) (string, bool) {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return "", false
+		}
+		value = value.Elem()
+	}
+	if value.Kind() == reflect.Struct {
+		for i := 0; i < value.NumField(); i++ {
+			if strings.Contains(value.Type().Field(i).Tag.Get("xml"), ",chardata") {
+				return identityText(value.Field(i))
+			}
+		}
+	}
+	return fmt.Sprint(value.Interface()), true
+}
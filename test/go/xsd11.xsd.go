@@ -0,0 +1,39 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema
+
+// EvenNumber ...
+// assert: $value mod 2 = 0
+type EvenNumber int
+
+// RangeType ...
+// assert: @min le @max
+type RangeType struct {
+	MinAttr int `xml:"min,attr,omitempty"`
+	MaxAttr int `xml:"max,attr,omitempty"`
+}
+
+// VehicleType ...
+type VehicleType struct {
+	KindAttr string       `xml:"kind,attr,omitempty"`
+	Any      []AnyElement `xml:",any"`
+	Wheels   int          `xml:"Wheels"`
+}
+
+// TruckType ...
+type TruckType struct {
+	Any []AnyElement `xml:",any"`
+	*VehicleType
+	Load float64 `xml:"Load"`
+}
+
+// GarageType ...
+type GarageType struct {
+	Any   []AnyElement `xml:",any"`
+	Range *RangeType   `xml:"Range"`
+}
+
+// Vehicle ...
+// alternative: TruckType if @kind = 'truck'
+// alternative: VehicleType
+type Vehicle *VehicleType
@@ -0,0 +1,45 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+import javax.xml.bind.JAXBElement;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAnyAttribute;
+import javax.xml.bind.annotation.XmlAnyElement;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlElementRef;
+import javax.xml.bind.annotation.XmlElements;
+import javax.xml.bind.annotation.XmlMixed;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+import javax.xml.namespace.QName;
+
+// PriorityType is Priority of a note.
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "PriorityType")
+public class PriorityType {
+	protected Integer PriorityType;
+}
+
+// NoteEntryType is A note with a title and a priority.
+// Notes are ordered by priority.
+public class NoteEntryType {
+	@XmlAttribute(name = "id")
+	protected String IdAttr;
+	@XmlElement(required = true, name = "Title")
+	protected String Title;
+	@XmlElement(required = true, name = "Priority")
+	protected Integer Priority;
+}
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlElement(required = true, name = "NoteEntry")
+public class NoteEntry {
+	protected NoteEntryType NoteEntry;
+}
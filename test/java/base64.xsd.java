@@ -4,13 +4,21 @@ package schema;
 
 import java.util.ArrayList;
 import java.util.List;
+import java.util.Map;
+import javax.xml.bind.JAXBElement;
 import javax.xml.bind.annotation.XmlAccessType;
 import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAnyAttribute;
+import javax.xml.bind.annotation.XmlAnyElement;
 import javax.xml.bind.annotation.XmlAttribute;
 import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlElementRef;
+import javax.xml.bind.annotation.XmlElements;
+import javax.xml.bind.annotation.XmlMixed;
 import javax.xml.bind.annotation.XmlSchemaType;
 import javax.xml.bind.annotation.XmlType;
 import javax.xml.bind.annotation.XmlValue;
+import javax.xml.namespace.QName;
 
 // MyType1 ...
 @XmlAccessorType(XmlAccessType.FIELD)
@@ -74,10 +82,11 @@ public class TopLevel extends MyType6  {
 	protected Float CostAttr;
 	@XmlAttribute(name = "LastUpdated")
 	protected String LastUpdatedAttr;
-	@XmlElement(required = true, name = "nested")
+	@XmlElement(name = "nested")
 	protected MyType7 Nested;
-	@XmlElement(required = true, name = "myType1")
-	protected List<List<Byte>> MyType1;
-	@XmlElement(required = true, name = "myType2")
-	protected List<MyType2> MyType2;
+	@XmlElements({
+		@XmlElement(name = "myType1", type = List.class),
+		@XmlElement(name = "myType2", type = MyType2.class)
+	})
+	protected List<Object> MyType1OrMyType2;
 }
@@ -0,0 +1,54 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+import javax.xml.bind.JAXBElement;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAnyAttribute;
+import javax.xml.bind.annotation.XmlAnyElement;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlElementRef;
+import javax.xml.bind.annotation.XmlElements;
+import javax.xml.bind.annotation.XmlMixed;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+import javax.xml.namespace.QName;
+
+// TransferType ...
+public class TransferType {
+	@XmlElement(required = true, name = "IBAN")
+	protected String IBAN;
+}
+
+// PaymentType ...
+public class PaymentType {
+	@XmlElement(required = true, name = "Amount")
+	protected Float Amount;
+	@XmlElements({
+		@XmlElement(name = "Card", type = String.class),
+		@XmlElement(name = "Transfer", type = TransferType.class)
+	})
+	protected Object CardOrTransfer;
+}
+
+// ChannelType ...
+public class ChannelType {
+	@XmlElements({
+		@XmlElement(name = "Email", type = String.class),
+		@XmlElement(name = "Phone", type = String.class),
+		@XmlElement(name = "Fax", type = String.class)
+	})
+	protected Object EmailOrPhoneOrFax;
+}
+
+// Payments ...
+public class Payments {
+	@XmlElement(required = true, name = "Payment")
+	protected List<PaymentType> Payment;
+}
@@ -0,0 +1,75 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+import javax.xml.bind.JAXBElement;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAnyAttribute;
+import javax.xml.bind.annotation.XmlAnyElement;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlElementRef;
+import javax.xml.bind.annotation.XmlElements;
+import javax.xml.bind.annotation.XmlMixed;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+import javax.xml.namespace.QName;
+
+// SettingsType ...
+public class SettingsType {
+	@XmlAttribute(name = "version")
+	protected String VersionAttr = "2.0";
+	@XmlAttribute(name = "enabled")
+	protected Boolean EnabledAttr = true;
+	@XmlAttribute(name = "retries")
+	protected Short RetriesAttr = 3;
+	@XmlElement(name = "Theme")
+	protected String Theme = "light";
+	@XmlElement(name = "FontSize")
+	protected Integer FontSize = 12;
+	@XmlElement(name = "Scale")
+	protected Float Scale = 1.5f;
+	@XmlElement(name = "Encoding")
+	protected String Encoding = "UTF-8";
+	@XmlElement(name = "Plugin")
+	protected List<String> Plugin;
+}
+
+// UserSettingsType ...
+public class UserSettingsType extends SettingsType  {
+	@XmlAttribute(name = "user")
+	protected String UserAttr;
+	@XmlElement(name = "Locale")
+	protected String Locale;
+}
+
+// ShippingType ...
+public class ShippingType {
+	@XmlAttribute(name = "country")
+	protected String CountryAttr = "US";
+	@XmlElement(required = true, name = "City")
+	protected String City;
+}
+
+// ProfileType ...
+public class ProfileType {
+	@XmlAttribute(name = "name", required = true)
+	protected String NameAttr;
+	@XmlElement(required = true, name = "Settings")
+	protected List<SettingsType> Settings;
+	@XmlElement(name = "UserSettings")
+	protected UserSettingsType UserSettings;
+	@XmlElement(name = "Shipping")
+	protected ShippingType Shipping;
+}
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlElement(required = true, name = "Profile")
+public class Profile {
+	protected ProfileType Profile;
+}
@@ -0,0 +1,91 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+import javax.xml.bind.JAXBElement;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAnyAttribute;
+import javax.xml.bind.annotation.XmlAnyElement;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlElementRef;
+import javax.xml.bind.annotation.XmlElements;
+import javax.xml.bind.annotation.XmlMixed;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+import javax.xml.namespace.QName;
+
+// ShipmentType ...
+public class ShipmentType {
+	@XmlAttribute(name = "currency")
+	protected String CurrencyAttr;
+	@XmlElement(required = true, name = "Code")
+	protected String Code;
+	@XmlElement(required = true, name = "Quantity")
+	protected Integer Quantity;
+	@XmlElement(required = true, name = "Size")
+	protected SizeType Size;
+	@XmlElement(name = "Note")
+	protected String Note;
+}
+
+// ChargeType ...
+public class ChargeType {
+	@XmlAttribute(name = "currency")
+	protected String CurrencyAttr;
+	@XmlValue
+	protected Float value;
+}
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlElement(required = true, name = "Note")
+public class Note {
+	protected String Note;
+}
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "currency")
+public class Currency {
+	protected String Currency;
+}
+
+// SizeType ...
+public class SizeType {
+	@XmlElement(required = true)
+	protected Integer QuantityType;
+	@XmlElement(required = true)
+	protected String CodeType;
+}
+
+// CodeType ...
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "CodeType")
+public class CodeType {
+	protected String CodeType;
+}
+
+// QuantityType ...
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "QuantityType")
+public class QuantityType {
+	protected Integer QuantityType;
+}
+
+// AmountType ...
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "AmountType")
+public class AmountType {
+	protected Float AmountType;
+}
+
+// CurrencyType ...
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "CurrencyType")
+public class CurrencyType {
+	protected String CurrencyType;
+}
@@ -0,0 +1,65 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+import javax.xml.bind.JAXBElement;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAnyAttribute;
+import javax.xml.bind.annotation.XmlAnyElement;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlElementRef;
+import javax.xml.bind.annotation.XmlElements;
+import javax.xml.bind.annotation.XmlMixed;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+import javax.xml.namespace.QName;
+
+// ProductType ...
+public class ProductType {
+	@XmlAttribute(name = "sku", required = true)
+	protected String SkuAttr;
+	@XmlElement(required = true, name = "Title")
+	protected String Title;
+}
+
+// OrderType ...
+public class OrderType {
+	@XmlAttribute(name = "id", required = true)
+	protected String IdAttr;
+	@XmlAttribute(name = "product", required = true)
+	protected String ProductAttr;
+}
+
+// ItemType ...
+public class ItemType {
+	@XmlAttribute(name = "sku")
+	protected String SkuAttr;
+}
+
+// ShelfType ...
+public class ShelfType {
+	@XmlElement(required = true, name = "Item")
+	protected List<ItemType> Item;
+}
+
+// CatalogType ...
+public class CatalogType {
+	@XmlElement(required = true, name = "Product")
+	protected List<ProductType> Product;
+	@XmlElement(name = "Order")
+	protected List<OrderType> Order;
+	@XmlElement(name = "Shelf")
+	protected List<ShelfType> Shelf;
+}
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlElement(required = true, name = "Catalog")
+public class Catalog {
+	protected CatalogType Catalog;
+}
@@ -0,0 +1,46 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+import javax.xml.bind.JAXBElement;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAnyAttribute;
+import javax.xml.bind.annotation.XmlAnyElement;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlElementRef;
+import javax.xml.bind.annotation.XmlElements;
+import javax.xml.bind.annotation.XmlMixed;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+import javax.xml.namespace.QName;
+
+// ParagraphType ...
+public class ParagraphType {
+	@XmlAttribute(name = "lang")
+	protected String LangAttr;
+	@XmlMixed
+	@XmlAnyElement(lax = true)
+	protected List<Object> content;
+}
+
+// NoteType ...
+public class NoteType extends ParagraphType  {
+	@XmlAttribute(name = "author")
+	protected String AuthorAttr;
+}
+
+// Article ...
+public class Article {
+	@XmlElement(required = true, name = "Title")
+	protected String Title;
+	@XmlElement(required = true, name = "Para")
+	protected List<ParagraphType> Para;
+	@XmlElement(name = "Note")
+	protected NoteType Note;
+}
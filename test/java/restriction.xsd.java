@@ -0,0 +1,69 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+import javax.xml.bind.JAXBElement;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAnyAttribute;
+import javax.xml.bind.annotation.XmlAnyElement;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlElementRef;
+import javax.xml.bind.annotation.XmlElements;
+import javax.xml.bind.annotation.XmlMixed;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+import javax.xml.namespace.QName;
+
+// ContactType ...
+public class ContactType {
+	@XmlAttribute(name = "id")
+	protected String IdAttr;
+	@XmlAttribute(name = "lang")
+	protected String LangAttr;
+	@XmlAttribute(name = "version")
+	protected Integer VersionAttr;
+	@XmlElement(required = true, name = "Name")
+	protected String Name;
+	@XmlElement(name = "Note")
+	protected String Note;
+}
+
+// RequiredContactType ...
+public class RequiredContactType {
+	@XmlAttribute(name = "id", required = true)
+	protected String IdAttr;
+	@XmlAttribute(name = "version")
+	protected Integer VersionAttr;
+	@XmlElement(required = true, name = "Name")
+	protected String Name;
+}
+
+// PriceType ...
+public class PriceType {
+	@XmlAttribute(name = "currency")
+	protected String CurrencyAttr;
+	@XmlValue
+	protected Float value;
+}
+
+// EuroPriceType ...
+public class EuroPriceType {
+	@XmlAttribute(name = "currency", required = true)
+	protected String CurrencyAttr;
+	@XmlValue
+	protected Float value;
+}
+
+// Directory ...
+public class Directory {
+	@XmlElement(required = true, name = "Contact")
+	protected List<RequiredContactType> Contact;
+	@XmlElement(required = true, name = "Fee")
+	protected EuroPriceType Fee;
+}
@@ -0,0 +1,79 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+import javax.xml.bind.JAXBElement;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAnyAttribute;
+import javax.xml.bind.annotation.XmlAnyElement;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlElementRef;
+import javax.xml.bind.annotation.XmlElements;
+import javax.xml.bind.annotation.XmlMixed;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+import javax.xml.namespace.QName;
+
+// PartyType ...
+public class PartyType {
+	@XmlElement(required = true, name = "Name")
+	protected String Name;
+	@XmlElement(name = "Email")
+	protected String Email;
+}
+
+// CustomerType ...
+public class CustomerType extends PartyType  {
+	@XmlElement(required = true, name = "Account")
+	protected Integer Account;
+}
+
+// PostalAddressType ...
+public class PostalAddressType {
+	@XmlElement(required = true, name = "Street")
+	protected String Street;
+	@XmlElement(required = true, name = "City")
+	protected String City;
+}
+
+// RemarkGroup ...
+public class RemarkGroup {
+	@XmlElement(required = true, name = "Remark")
+	protected String Remark;
+	@XmlElement(name = "Priority")
+	protected Integer Priority;
+}
+
+// WaybillType ...
+public class WaybillType {
+	@XmlElement(required = true, name = "Number")
+	protected String Number;
+	protected RemarkGroup RemarkGroup;
+	@XmlElement(required = true, name = "Parcel")
+	protected String Parcel;
+	@XmlElement(required = true, name = "Weight")
+	protected Float Weight;
+}
+
+// ManifestType ...
+public class ManifestType {
+	@XmlElement(required = true, name = "Code")
+	protected List<String> Code;
+	@XmlElement(name = "Note")
+	protected List<String> Note;
+	protected List<RemarkGroup> RemarkGroup;
+}
+
+// Shipment ...
+public class Shipment {
+	@XmlElement(required = true, name = "Customer")
+	protected CustomerType Customer;
+	@XmlElement(required = true, name = "Address")
+	protected PostalAddressType Address;
+}
@@ -0,0 +1,91 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+import javax.xml.bind.JAXBElement;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAnyAttribute;
+import javax.xml.bind.annotation.XmlAnyElement;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlElementRef;
+import javax.xml.bind.annotation.XmlElements;
+import javax.xml.bind.annotation.XmlMixed;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+import javax.xml.namespace.QName;
+
+// ShapeType ...
+public class ShapeType {
+	@XmlAttribute(name = "color")
+	protected String ColorAttr;
+}
+
+// CircleType ...
+public class CircleType extends ShapeType  {
+	@XmlAttribute(name = "radius")
+	protected Integer RadiusAttr;
+}
+
+// SquareType ...
+public class SquareType extends ShapeType  {
+	@XmlAttribute(name = "side")
+	protected Integer SideAttr;
+}
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlElement(required = true, name = "Shape")
+public class Shape {
+	protected ShapeType Shape;
+}
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlElement(required = true, name = "Circle")
+public class Circle {
+	protected CircleType Circle;
+}
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlElement(required = true, name = "Square")
+public class Square {
+	protected SquareType Square;
+}
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlElement(required = true, name = "RoundedSquare")
+public class RoundedSquare {
+	protected SquareType RoundedSquare;
+}
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlElement(required = true, name = "Label")
+public class Label {
+	protected String Label;
+}
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlElement(required = true, name = "Caption")
+public class Caption {
+	protected String Caption;
+}
+
+// Gallery ...
+public class Gallery {
+	@XmlElementRef(name = "Shape", type = JAXBElement.class)
+	protected List<JAXBElement<?>> Shape;
+	@XmlElementRef(name = "Label", type = JAXBElement.class)
+	protected JAXBElement<?> Label;
+}
+
+// Drawing ...
+public class Drawing {
+	@XmlElement(required = true, name = "Title")
+	protected String Title;
+	@XmlElementRef(name = "Shape", type = JAXBElement.class)
+	protected List<JAXBElement<?>> Shape;
+}
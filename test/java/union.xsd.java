@@ -0,0 +1,99 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+import javax.xml.bind.JAXBElement;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAnyAttribute;
+import javax.xml.bind.annotation.XmlAnyElement;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlElementRef;
+import javax.xml.bind.annotation.XmlElements;
+import javax.xml.bind.annotation.XmlMixed;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+import javax.xml.namespace.QName;
+
+// DimensionTypeMember2 ...
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "DimensionTypeMember2")
+public class DimensionTypeMember2 {
+	protected String DimensionTypeMember2;
+}
+
+// DimensionTypeMember3 ...
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "DimensionTypeMember3")
+public class DimensionTypeMember3 {
+	protected Float DimensionTypeMember3;
+}
+
+// DimensionType ...
+public class DimensionType {
+	@XmlElement(required = true)
+	protected Float DimensionTypeMember3;
+	@XmlElement(required = true)
+	protected Integer Int;
+	@XmlElement(required = true)
+	protected String DimensionTypeMember2;
+}
+
+// MarginMember1 ...
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "MarginMember1")
+public class MarginMember1 {
+	protected String MarginMember1;
+}
+
+// MarginMember2 ...
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "MarginMember2")
+public class MarginMember2 {
+	protected Integer MarginMember2;
+}
+
+// Margin ...
+public class Margin {
+	@XmlElement(required = true)
+	protected Integer MarginMember2;
+	@XmlElement(required = true)
+	protected String MarginMember1;
+}
+
+// AlignMember1 ...
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "alignMember1")
+public class AlignMember1 {
+	protected String AlignMember1;
+}
+
+// AlignMember2 ...
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "alignMember2")
+public class AlignMember2 {
+	protected Integer AlignMember2;
+}
+
+// Align ...
+public class Align {
+	@XmlElement(required = true)
+	protected Integer AlignMember2;
+	@XmlElement(required = true)
+	protected String AlignMember1;
+}
+
+// BoxType ...
+public class BoxType {
+	@XmlAttribute(name = "align")
+	protected Align AlignAttr;
+	@XmlElement(required = true, name = "Width")
+	protected DimensionType Width;
+	@XmlElement(required = true, name = "Margin")
+	protected Margin Margin;
+}
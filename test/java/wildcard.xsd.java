@@ -0,0 +1,55 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+import javax.xml.bind.JAXBElement;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAnyAttribute;
+import javax.xml.bind.annotation.XmlAnyElement;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlElementRef;
+import javax.xml.bind.annotation.XmlElements;
+import javax.xml.bind.annotation.XmlMixed;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+import javax.xml.namespace.QName;
+
+// ExtensionType ...
+public class ExtensionType {
+	@XmlAnyAttribute
+	protected Map<QName, String> otherAttributes;
+	@XmlElement(required = true, name = "id")
+	protected String Id;
+	@XmlAnyElement(lax = true)
+	protected List<Object> any;
+}
+
+// ExtensionGroup ...
+public class ExtensionGroup {
+	@XmlAnyElement(lax = true)
+	protected List<Object> any;
+}
+
+// ExtensionAttributes ...
+public class ExtensionAttributes {
+	@XmlAttribute(name = "version")
+	protected StringAttr Version;
+	@XmlAnyAttribute
+	protected Map<QName, String> otherAttributes;
+}
+
+// Envelope ...
+public class Envelope {
+	@XmlAnyAttribute
+	protected Map<QName, String> otherAttributes;
+	@XmlElement(required = true, name = "header")
+	protected ExtensionType Header;
+	@XmlAnyElement(lax = true)
+	protected List<Object> any;
+}
@@ -0,0 +1,70 @@
+// Code generated by xgen. DO NOT EDIT.
+
+package schema;
+
+import java.util.ArrayList;
+import java.util.List;
+import java.util.Map;
+import javax.xml.bind.JAXBElement;
+import javax.xml.bind.annotation.XmlAccessType;
+import javax.xml.bind.annotation.XmlAccessorType;
+import javax.xml.bind.annotation.XmlAnyAttribute;
+import javax.xml.bind.annotation.XmlAnyElement;
+import javax.xml.bind.annotation.XmlAttribute;
+import javax.xml.bind.annotation.XmlElement;
+import javax.xml.bind.annotation.XmlElementRef;
+import javax.xml.bind.annotation.XmlElements;
+import javax.xml.bind.annotation.XmlMixed;
+import javax.xml.bind.annotation.XmlSchemaType;
+import javax.xml.bind.annotation.XmlType;
+import javax.xml.bind.annotation.XmlValue;
+import javax.xml.namespace.QName;
+
+// EvenNumber ...
+// assert: $value mod 2 = 0
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlAttribute(required = true, name = "EvenNumber")
+public class EvenNumber {
+	protected Integer EvenNumber;
+}
+
+// RangeType ...
+// assert: @min le @max
+public class RangeType {
+	@XmlAttribute(name = "min")
+	protected Integer MinAttr;
+	@XmlAttribute(name = "max")
+	protected Integer MaxAttr;
+}
+
+// VehicleType ...
+public class VehicleType {
+	@XmlAttribute(name = "kind")
+	protected String KindAttr;
+	@XmlAnyElement(lax = true)
+	protected List<Object> any;
+	@XmlElement(required = true, name = "Wheels")
+	protected Integer Wheels;
+}
+
+// TruckType ...
+public class TruckType extends VehicleType  {
+	@XmlAnyElement(lax = true)
+	protected List<Object> any;
+	@XmlElement(required = true, name = "Load")
+	protected Float Load;
+}
+
+// GarageType ...
+public class GarageType {
+	@XmlAnyElement(lax = true)
+	protected List<Object> any;
+	@XmlElement(required = true, name = "Range")
+	protected RangeType Range;
+}
+
+@XmlAccessorType(XmlAccessType.FIELD)
+@XmlElement(required = true, name = "Vehicle")
+public class Vehicle {
+	protected VehicleType Vehicle;
+}
@@ -0,0 +1,37 @@
+// Code generated by xgen. DO NOT EDIT.
+
+#[macro_use]
+extern crate serde_derive;
+extern crate serde;
+extern crate serde_xml_rs;
+
+use serde_xml_rs::from_reader;
+
+
+// PriorityType is Priority of a note.
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct PriorityType {
+	#[serde(rename = "PriorityType")]
+	pub priority_type: i32,
+}
+
+
+// NoteEntryType is A note with a title and a priority.
+// Notes are ordered by priority.
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct NoteEntryType {
+	#[serde(rename = "id")]
+	pub id: Option<String>,
+	#[serde(rename = "Title")]
+	pub title: String,
+	#[serde(rename = "Priority")]
+	pub priority: i32,
+}
+
+
+// note_entry is The note entry.
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct note_entry {
+	#[serde(rename = "NoteEntry")]
+	pub note_entry: NoteEntryType,
+}
@@ -83,12 +83,20 @@ pub struct TopLevel {
 	pub cost: Option<f64>,
 	#[serde(rename = "LastUpdated")]
 	pub last_updated: Option<u8>,
+	#[serde(flatten)]
+	pub my_type6: MyType6,
 	#[serde(rename = "nested")]
-	pub nested: MyType7,
+	pub nested: Option<MyType7>,
+	#[serde(rename = "$value")]
+	pub my_type1_or_my_type2: Vec<TopLevelMyType1OrMyType2>,
+}
+
+
+// TopLevelMyType1OrMyType2 is the choice of the myType1 or myType2 element.
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub enum TopLevelMyType1OrMyType2 {
 	#[serde(rename = "myType1")]
-	pub my_type1: Vec<String>,
+	MyType1(String),
 	#[serde(rename = "myType2")]
-	pub my_type2: Vec<MyType2>,
-	#[serde(flatten)]
-	pub my_type6: MyType6,
+	MyType2(MyType2),
 }
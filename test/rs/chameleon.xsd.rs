@@ -0,0 +1,34 @@
+// Code generated by xgen. DO NOT EDIT.
+
+#[macro_use]
+extern crate serde_derive;
+extern crate serde;
+extern crate serde_xml_rs;
+
+use serde_xml_rs::from_reader;
+
+
+// ItemCountType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct ItemCountType {
+	#[serde(rename = "ItemCountType")]
+	pub item_count_type: i32,
+}
+
+
+// TotalType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct TotalType {
+	#[serde(rename = "TotalType")]
+	pub total_type: f64,
+}
+
+
+// AddressType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct AddressType {
+	#[serde(rename = "Street")]
+	pub street: String,
+	#[serde(rename = "City")]
+	pub city: String,
+}
@@ -0,0 +1,64 @@
+// Code generated by xgen. DO NOT EDIT.
+
+#[macro_use]
+extern crate serde_derive;
+extern crate serde;
+extern crate serde_xml_rs;
+
+use serde_xml_rs::from_reader;
+
+
+// TransferType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct TransferType {
+	#[serde(rename = "IBAN")]
+	pub iban: String,
+}
+
+
+// PaymentType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct PaymentType {
+	#[serde(rename = "Amount")]
+	pub amount: f64,
+	#[serde(rename = "$value")]
+	pub card_or_transfer: PaymentTypeCardOrTransfer,
+}
+
+
+// PaymentTypeCardOrTransfer is the choice of the Card or Transfer element.
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub enum PaymentTypeCardOrTransfer {
+	#[serde(rename = "Card")]
+	Card(String),
+	#[serde(rename = "Transfer")]
+	Transfer(TransferType),
+}
+
+
+// ChannelType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct ChannelType {
+	#[serde(rename = "$value")]
+	pub email_or_phone_or_fax: ChannelTypeEmailOrPhoneOrFax,
+}
+
+
+// ChannelTypeEmailOrPhoneOrFax is the choice of the Email, Phone or Fax element.
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub enum ChannelTypeEmailOrPhoneOrFax {
+	#[serde(rename = "Email")]
+	Email(String),
+	#[serde(rename = "Phone")]
+	Phone(String),
+	#[serde(rename = "Fax")]
+	Fax(String),
+}
+
+
+// Payments ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Payments {
+	#[serde(rename = "Payment")]
+	pub payment: Vec<PaymentType>,
+}
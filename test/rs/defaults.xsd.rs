@@ -0,0 +1,106 @@
+// Code generated by xgen. DO NOT EDIT.
+
+#[macro_use]
+extern crate serde_derive;
+extern crate serde;
+extern crate serde_xml_rs;
+
+use serde_xml_rs::from_reader;
+
+
+// SettingsType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct SettingsType {
+	#[serde(rename = "version", default = "default_settings_type_version")]
+	pub version: String,
+	#[serde(rename = "enabled", default = "default_settings_type_enabled")]
+	pub enabled: bool,
+	#[serde(rename = "retries", default = "default_settings_type_retries")]
+	pub retries: u16,
+	#[serde(rename = "Theme", default = "default_settings_type_theme")]
+	pub theme: String,
+	#[serde(rename = "FontSize", default = "default_settings_type_font_size")]
+	pub font_size: i32,
+	#[serde(rename = "Scale", default = "default_settings_type_scale")]
+	pub scale: f64,
+	#[serde(rename = "Encoding", default = "default_settings_type_encoding")]
+	pub encoding: String,
+	#[serde(rename = "Plugin")]
+	pub plugin: Vec<String>,
+}
+
+fn default_settings_type_version() -> String {
+	"2.0".to_string()
+}
+
+fn default_settings_type_enabled() -> bool {
+	true
+}
+
+fn default_settings_type_retries() -> u16 {
+	3
+}
+
+fn default_settings_type_theme() -> String {
+	"light".to_string()
+}
+
+fn default_settings_type_font_size() -> i32 {
+	12
+}
+
+fn default_settings_type_scale() -> f64 {
+	1.5
+}
+
+fn default_settings_type_encoding() -> String {
+	"UTF-8".to_string()
+}
+
+
+// UserSettingsType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct UserSettingsType {
+	#[serde(rename = "user")]
+	pub user: Option<String>,
+	#[serde(flatten)]
+	pub settings_type: SettingsType,
+	#[serde(rename = "Locale")]
+	pub locale: Option<String>,
+}
+
+
+// ShippingType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct ShippingType {
+	#[serde(rename = "country", default = "default_shipping_type_country")]
+	pub country: String,
+	#[serde(rename = "City")]
+	pub city: String,
+}
+
+fn default_shipping_type_country() -> String {
+	"US".to_string()
+}
+
+
+// ProfileType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct ProfileType {
+	#[serde(rename = "name")]
+	pub name: String,
+	#[serde(rename = "Settings")]
+	pub settings: Vec<SettingsType>,
+	#[serde(rename = "UserSettings")]
+	pub user_settings: Option<UserSettingsType>,
+	#[serde(rename = "Shipping")]
+	pub shipping: Option<ShippingType>,
+}
+
+
+// profile ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct profile {
+	#[serde(rename = "Profile")]
+	pub profile: ProfileType,
+}
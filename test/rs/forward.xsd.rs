@@ -0,0 +1,90 @@
+// Code generated by xgen. DO NOT EDIT.
+
+#[macro_use]
+extern crate serde_derive;
+extern crate serde;
+extern crate serde_xml_rs;
+
+use serde_xml_rs::from_reader;
+
+
+// ShipmentType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct ShipmentType {
+	#[serde(rename = "currency")]
+	pub currency: Option<String>,
+	#[serde(rename = "Code")]
+	pub code: String,
+	#[serde(rename = "Quantity")]
+	pub quantity: i32,
+	#[serde(rename = "Size")]
+	pub size: SizeType,
+	#[serde(rename = "Note")]
+	pub note: Option<String>,
+}
+
+
+// ChargeType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct ChargeType {
+	#[serde(rename = "currency")]
+	pub currency: Option<String>,
+	#[serde(rename = "$value")]
+	pub value: f64,
+}
+
+
+// note ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct note {
+	#[serde(rename = "Note")]
+	pub note: String,
+}
+
+
+// currency ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct currency {
+	#[serde(rename = "currency")]
+	pub currency: String,
+}
+
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct SizeType {
+	#[serde(rename = "SizeType")]
+	pub code_type: String,
+	#[serde(rename = "SizeType")]
+	pub quantity_type: i32,
+}
+
+
+// CodeType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct CodeType {
+	#[serde(rename = "CodeType")]
+	pub code_type: String,
+}
+
+
+// QuantityType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct QuantityType {
+	#[serde(rename = "QuantityType")]
+	pub quantity_type: i32,
+}
+
+
+// AmountType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct AmountType {
+	#[serde(rename = "AmountType")]
+	pub amount_type: f64,
+}
+
+
+// CurrencyType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct CurrencyType {
+	#[serde(rename = "CurrencyType")]
+	pub currency_type: String,
+}
@@ -0,0 +1,64 @@
+// Code generated by xgen. DO NOT EDIT.
+
+#[macro_use]
+extern crate serde_derive;
+extern crate serde;
+extern crate serde_xml_rs;
+
+use serde_xml_rs::from_reader;
+
+
+// ProductType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct ProductType {
+	#[serde(rename = "sku")]
+	pub sku: String,
+	#[serde(rename = "Title")]
+	pub title: String,
+}
+
+
+// OrderType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct OrderType {
+	#[serde(rename = "id")]
+	pub id: String,
+	#[serde(rename = "product")]
+	pub product: String,
+}
+
+
+// ItemType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct ItemType {
+	#[serde(rename = "sku")]
+	pub sku: Option<String>,
+}
+
+
+// ShelfType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct ShelfType {
+	#[serde(rename = "Item")]
+	pub item: Vec<ItemType>,
+}
+
+
+// CatalogType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct CatalogType {
+	#[serde(rename = "Product")]
+	pub product: Vec<ProductType>,
+	#[serde(rename = "Order")]
+	pub order: Vec<OrderType>,
+	#[serde(rename = "Shelf")]
+	pub shelf: Vec<ShelfType>,
+}
+
+
+// catalog ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct catalog {
+	#[serde(rename = "Catalog")]
+	pub catalog: CatalogType,
+}
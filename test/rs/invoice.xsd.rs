@@ -0,0 +1,18 @@
+// Code generated by xgen. DO NOT EDIT.
+
+#[macro_use]
+extern crate serde_derive;
+extern crate serde;
+extern crate serde_xml_rs;
+
+use serde_xml_rs::from_reader;
+
+
+// InvoiceType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct InvoiceType {
+	#[serde(rename = "total")]
+	pub total: Option<f64>,
+	#[serde(rename = "BillTo")]
+	pub bill_to: AddressType,
+}
@@ -0,0 +1,52 @@
+// Code generated by xgen. DO NOT EDIT.
+
+#[macro_use]
+extern crate serde_derive;
+extern crate serde;
+extern crate serde_xml_rs;
+
+use serde_xml_rs::from_reader;
+
+
+// ParagraphType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct ParagraphType {
+	#[serde(rename = "lang")]
+	pub lang: Option<String>,
+	#[serde(rename = "$value")]
+	pub content: Vec<ParagraphTypeContent>,
+}
+
+
+// ParagraphTypeContent is the character data and the elements of mixed content.
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub enum ParagraphTypeContent {
+	#[serde(rename = "$value")]
+	Text(String),
+	#[serde(rename = "b")]
+	B(String),
+	#[serde(rename = "i")]
+	I(String),
+}
+
+
+// NoteType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct NoteType {
+	#[serde(rename = "author")]
+	pub author: Option<String>,
+	#[serde(flatten)]
+	pub paragraph_type: ParagraphType,
+}
+
+
+// Article ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Article {
+	#[serde(rename = "Title")]
+	pub title: String,
+	#[serde(rename = "Para")]
+	pub para: Vec<ParagraphType>,
+	#[serde(rename = "Note")]
+	pub note: Option<NoteType>,
+}
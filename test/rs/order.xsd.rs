@@ -0,0 +1,18 @@
+// Code generated by xgen. DO NOT EDIT.
+
+#[macro_use]
+extern crate serde_derive;
+extern crate serde;
+extern crate serde_xml_rs;
+
+use serde_xml_rs::from_reader;
+
+
+// PurchaseOrderType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct PurchaseOrderType {
+	#[serde(rename = "quantity")]
+	pub quantity: Option<i32>,
+	#[serde(rename = "ShipTo")]
+	pub ship_to: AddressType,
+}
@@ -0,0 +1,66 @@
+// Code generated by xgen. DO NOT EDIT.
+
+#[macro_use]
+extern crate serde_derive;
+extern crate serde;
+extern crate serde_xml_rs;
+
+use serde_xml_rs::from_reader;
+
+
+// ContactType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct ContactType {
+	#[serde(rename = "id")]
+	pub id: Option<String>,
+	#[serde(rename = "lang")]
+	pub lang: Option<String>,
+	#[serde(rename = "version")]
+	pub version: Option<i32>,
+	#[serde(rename = "Name")]
+	pub name: String,
+	#[serde(rename = "Note")]
+	pub note: Option<String>,
+}
+
+
+// RequiredContactType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct RequiredContactType {
+	#[serde(rename = "id")]
+	pub id: String,
+	#[serde(rename = "version")]
+	pub version: Option<i32>,
+	#[serde(rename = "Name")]
+	pub name: String,
+}
+
+
+// PriceType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct PriceType {
+	#[serde(rename = "currency")]
+	pub currency: Option<String>,
+	#[serde(rename = "$value")]
+	pub value: f64,
+}
+
+
+// EuroPriceType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct EuroPriceType {
+	#[serde(rename = "currency")]
+	pub currency: String,
+	#[serde(rename = "$value")]
+	pub value: f64,
+}
+
+
+// Directory ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Directory {
+	#[serde(rename = "Contact")]
+	pub contact: Vec<RequiredContactType>,
+	#[serde(rename = "Fee")]
+	pub fee: EuroPriceType,
+}
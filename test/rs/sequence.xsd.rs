@@ -0,0 +1,84 @@
+// Code generated by xgen. DO NOT EDIT.
+
+#[macro_use]
+extern crate serde_derive;
+extern crate serde;
+extern crate serde_xml_rs;
+
+use serde_xml_rs::from_reader;
+
+
+// PartyType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct PartyType {
+	#[serde(rename = "Name")]
+	pub name: String,
+	#[serde(rename = "Email")]
+	pub email: Option<String>,
+}
+
+
+// CustomerType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct CustomerType {
+	#[serde(flatten)]
+	pub party_type: PartyType,
+	#[serde(rename = "Account")]
+	pub account: i32,
+}
+
+
+// PostalAddressType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct PostalAddressType {
+	#[serde(rename = "Street")]
+	pub street: String,
+	#[serde(rename = "City")]
+	pub city: String,
+}
+
+
+// RemarkGroup ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct RemarkGroup {
+	#[serde(rename = "Remark")]
+	pub remark: String,
+	#[serde(rename = "Priority")]
+	pub priority: Option<i32>,
+}
+
+
+// WaybillType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct WaybillType {
+	#[serde(rename = "Number")]
+	pub number: String,
+	#[serde(rename = "RemarkGroup")]
+	pub remark_group: Option<RemarkGroup>,
+	#[serde(rename = "Parcel")]
+	pub parcel: String,
+	#[serde(rename = "Weight")]
+	pub weight: f64,
+}
+
+
+// ManifestType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct ManifestType {
+	#[serde(rename = "Code")]
+	pub code: Vec<String>,
+	#[serde(rename = "Note")]
+	pub note: Vec<String>,
+	#[serde(rename = "RemarkGroup")]
+	pub remark_group: Vec<RemarkGroup>,
+}
+
+
+// Shipment ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Shipment {
+	#[serde(rename = "Customer")]
+	pub customer: CustomerType,
+	#[serde(rename = "Address")]
+	pub address: PostalAddressType,
+}
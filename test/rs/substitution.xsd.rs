@@ -0,0 +1,134 @@
+// Code generated by xgen. DO NOT EDIT.
+
+#[macro_use]
+extern crate serde_derive;
+extern crate serde;
+extern crate serde_xml_rs;
+
+use serde_xml_rs::from_reader;
+
+
+// ShapeType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct ShapeType {
+	#[serde(rename = "color")]
+	pub color: Option<String>,
+}
+
+
+// CircleType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct CircleType {
+	#[serde(rename = "radius")]
+	pub radius: Option<i32>,
+	#[serde(flatten)]
+	pub shape_type: ShapeType,
+}
+
+
+// SquareType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct SquareType {
+	#[serde(rename = "side")]
+	pub side: Option<i32>,
+	#[serde(flatten)]
+	pub shape_type: ShapeType,
+}
+
+
+// shape ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct shape {
+	#[serde(rename = "Shape")]
+	pub shape: ShapeType,
+}
+
+
+// circle ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct circle {
+	#[serde(rename = "Circle")]
+	pub circle: CircleType,
+}
+
+
+// square ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct square {
+	#[serde(rename = "Square")]
+	pub square: SquareType,
+}
+
+
+// rounded_square ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct rounded_square {
+	#[serde(rename = "RoundedSquare")]
+	pub rounded_square: SquareType,
+}
+
+
+// label ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct label {
+	#[serde(rename = "Label")]
+	pub label: String,
+}
+
+
+// caption ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct caption {
+	#[serde(rename = "Caption")]
+	pub caption: String,
+}
+
+
+// Gallery ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Gallery {
+	#[serde(rename = "$value")]
+	pub shape: Vec<ShapeSubstitution>,
+	#[serde(rename = "$value")]
+	pub label: Option<LabelSubstitution>,
+}
+
+
+// Drawing ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Drawing {
+	#[serde(rename = "Title")]
+	pub title: String,
+	#[serde(rename = "$value")]
+	pub shape: Vec<ShapeSubstitution>,
+}
+
+
+// ShapeSubstitution is the substitution group of the Shape element.
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub enum ShapeSubstitution {
+	#[serde(rename = "Circle")]
+	Circle(CircleType),
+	#[serde(rename = "Square")]
+	Square(SquareType),
+	#[serde(rename = "RoundedSquare")]
+	RoundedSquare(SquareType),
+}
+
+
+// SquareSubstitution is the substitution group of the Square element.
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub enum SquareSubstitution {
+	#[serde(rename = "Square")]
+	Square(SquareType),
+	#[serde(rename = "RoundedSquare")]
+	RoundedSquare(SquareType),
+}
+
+
+// LabelSubstitution is the substitution group of the Label element.
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub enum LabelSubstitution {
+	#[serde(rename = "Caption")]
+	Caption(String),
+}
@@ -0,0 +1,94 @@
+// Code generated by xgen. DO NOT EDIT.
+
+#[macro_use]
+extern crate serde_derive;
+extern crate serde;
+extern crate serde_xml_rs;
+
+use serde_xml_rs::from_reader;
+
+
+// DimensionTypeMember2 ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct DimensionTypeMember2 {
+	#[serde(rename = "DimensionTypeMember2")]
+	pub dimension_type_member2: String,
+}
+
+
+// DimensionTypeMember3 ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct DimensionTypeMember3 {
+	#[serde(rename = "DimensionTypeMember3")]
+	pub dimension_type_member3: f64,
+}
+
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct DimensionType {
+	#[serde(rename = "DimensionType")]
+	pub dimension_type_member2: String,
+	#[serde(rename = "DimensionType")]
+	pub dimension_type_member3: f64,
+	#[serde(rename = "DimensionType")]
+	pub int: i32,
+}
+
+
+// MarginMember1 ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct MarginMember1 {
+	#[serde(rename = "MarginMember1")]
+	pub margin_member1: String,
+}
+
+
+// MarginMember2 ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct MarginMember2 {
+	#[serde(rename = "MarginMember2")]
+	pub margin_member2: i32,
+}
+
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Margin {
+	#[serde(rename = "Margin")]
+	pub margin_member1: String,
+	#[serde(rename = "Margin")]
+	pub margin_member2: i32,
+}
+
+
+// AlignMember1 ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct AlignMember1 {
+	#[serde(rename = "alignMember1")]
+	pub align_member1: String,
+}
+
+
+// AlignMember2 ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct AlignMember2 {
+	#[serde(rename = "alignMember2")]
+	pub align_member2: i32,
+}
+
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Align {
+	#[serde(rename = "align")]
+	pub align_member1: String,
+	#[serde(rename = "align")]
+	pub align_member2: i32,
+}
+
+
+// BoxType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct BoxType {
+	#[serde(rename = "align")]
+	pub align: Option<Align>,
+	#[serde(rename = "Width")]
+	pub width: DimensionType,
+	#[serde(rename = "Margin")]
+	pub margin: Margin,
+}
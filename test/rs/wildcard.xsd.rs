@@ -0,0 +1,46 @@
+// Code generated by xgen. DO NOT EDIT.
+
+#[macro_use]
+extern crate serde_derive;
+extern crate serde;
+extern crate serde_xml_rs;
+
+use serde_xml_rs::from_reader;
+
+
+// ExtensionType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct ExtensionType {
+	#[serde(rename = "id")]
+	pub id: String,
+	#[serde(flatten)]
+	pub any: std::collections::HashMap<String, String>,
+}
+
+
+// ExtensionGroup ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct ExtensionGroup {
+	#[serde(flatten)]
+	pub any: std::collections::HashMap<String, String>,
+}
+
+
+// ExtensionAttributes ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct ExtensionAttributes {
+	#[serde(rename = "version")]
+	pub version: Option<String>,
+	#[serde(flatten)]
+	pub any_attr: std::collections::HashMap<String, String>,
+}
+
+
+// Envelope ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct Envelope {
+	#[serde(rename = "header")]
+	pub header: ExtensionType,
+	#[serde(flatten)]
+	pub any: std::collections::HashMap<String, String>,
+}
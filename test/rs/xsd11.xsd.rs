@@ -0,0 +1,72 @@
+// Code generated by xgen. DO NOT EDIT.
+
+#[macro_use]
+extern crate serde_derive;
+extern crate serde;
+extern crate serde_xml_rs;
+
+use serde_xml_rs::from_reader;
+
+
+// EvenNumber ...
+// assert: $value mod 2 = 0
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct EvenNumber {
+	#[serde(rename = "EvenNumber")]
+	pub even_number: i32,
+}
+
+
+// RangeType ...
+// assert: @min le @max
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct RangeType {
+	#[serde(rename = "min")]
+	pub min: Option<i32>,
+	#[serde(rename = "max")]
+	pub max: Option<i32>,
+}
+
+
+// VehicleType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct VehicleType {
+	#[serde(rename = "kind")]
+	pub kind: Option<String>,
+	#[serde(flatten)]
+	pub any: std::collections::HashMap<String, String>,
+	#[serde(rename = "Wheels")]
+	pub wheels: i32,
+}
+
+
+// TruckType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct TruckType {
+	#[serde(flatten)]
+	pub any: std::collections::HashMap<String, String>,
+	#[serde(flatten)]
+	pub vehicle_type: VehicleType,
+	#[serde(rename = "Load")]
+	pub load: f64,
+}
+
+
+// GarageType ...
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct GarageType {
+	#[serde(flatten)]
+	pub any: std::collections::HashMap<String, String>,
+	#[serde(rename = "Range")]
+	pub range: RangeType,
+}
+
+
+// vehicle ...
+// alternative: TruckType if @kind = 'truck'
+// alternative: VehicleType
+#[derive(Debug, Deserialize, Serialize, PartialEq)]
+pub struct vehicle {
+	#[serde(rename = "Vehicle")]
+	pub vehicle: VehicleType,
+}
@@ -0,0 +1,15 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// PriorityType is Priority of a note.
+export type PriorityType = number;
+
+// NoteEntryType is A note with a title and a priority.
+// Notes are ordered by priority.
+export class NoteEntryType {
+	IdAttr: string | null;
+	Title: string;
+	Priority: number;
+}
+
+// NoteEntry is The note entry.
+export type NoteEntry = NoteEntryType;
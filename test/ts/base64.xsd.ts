@@ -41,7 +41,9 @@ export class MyType7 {
 export class TopLevel extends MyType6  {
 	CostAttr: number | null;
 	LastUpdatedAttr: string | null;
-	Nested: MyType7;
-	MyType1: Uint8Array;
-	MyType2: Array<MyType2>;
+	Nested: MyType7 | null;
+	MyType1OrMyType2: Array<TopLevelMyType1OrMyType2>;
 }
+
+// TopLevelMyType1OrMyType2 is the choice of the myType1 or myType2 element.
+export type TopLevelMyType1OrMyType2 = { myType1: Uint8Array } | { myType2: MyType2 };
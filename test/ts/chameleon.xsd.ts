@@ -0,0 +1,13 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// ItemCountType ...
+export type ItemCountType = number;
+
+// TotalType ...
+export type TotalType = number;
+
+// AddressType ...
+export class AddressType {
+	Street: string;
+	City: string;
+}
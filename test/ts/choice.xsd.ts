@@ -0,0 +1,28 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// TransferType ...
+export class TransferType {
+	IBAN: string;
+}
+
+// PaymentType ...
+export class PaymentType {
+	Amount: number;
+	CardOrTransfer: PaymentTypeCardOrTransfer;
+}
+
+// PaymentTypeCardOrTransfer is the choice of the Card or Transfer element.
+export type PaymentTypeCardOrTransfer = { Card: string } | { Transfer: TransferType };
+
+// ChannelType ...
+export class ChannelType {
+	EmailOrPhoneOrFax: ChannelTypeEmailOrPhoneOrFax;
+}
+
+// ChannelTypeEmailOrPhoneOrFax is the choice of the Email, Phone or Fax element.
+export type ChannelTypeEmailOrPhoneOrFax = { Email: string } | { Phone: string } | { Fax: string };
+
+// Payments ...
+export class Payments {
+	Payment: Array<PaymentType>;
+}
@@ -0,0 +1,36 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// SettingsType ...
+export class SettingsType {
+	VersionAttr: string | null = "2.0";
+	EnabledAttr: boolean | null = true;
+	RetriesAttr: number | null = 3;
+	Theme: string | null = "light";
+	FontSize: number | null = 12;
+	Scale: number | null = 1.5;
+	Encoding: string | null = "UTF-8";
+	Plugin: string;
+}
+
+// UserSettingsType ...
+export class UserSettingsType extends SettingsType  {
+	UserAttr: string | null;
+	Locale: string | null;
+}
+
+// ShippingType ...
+export class ShippingType {
+	CountryAttr: string | null = "US";
+	City: string;
+}
+
+// ProfileType ...
+export class ProfileType {
+	NameAttr: string;
+	Settings: Array<SettingsType>;
+	UserSettings: UserSettingsType | null;
+	Shipping: ShippingType | null;
+}
+
+// Profile ...
+export type Profile = ProfileType;
@@ -0,0 +1,40 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// ShipmentType ...
+export class ShipmentType {
+	CurrencyAttr: string | null;
+	Code: string;
+	Quantity: number;
+	Size: SizeType;
+	Note: string | null;
+}
+
+// ChargeType ...
+export class ChargeType {
+	CurrencyAttr: string | null;
+	Value: number;
+}
+
+// Note ...
+export type Note = string;
+
+// Currency ...
+export type Currency = string;
+
+// SizeType ...
+export class SizeType {
+	QuantityType: number;
+	CodeType: string;
+}
+
+// CodeType ...
+export type CodeType = string;
+
+// QuantityType ...
+export type QuantityType = number;
+
+// AmountType ...
+export type AmountType = number;
+
+// CurrencyType ...
+export type CurrencyType = string;
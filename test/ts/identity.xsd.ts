@@ -0,0 +1,33 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// ProductType ...
+export class ProductType {
+	SkuAttr: string;
+	Title: string;
+}
+
+// OrderType ...
+export class OrderType {
+	IdAttr: string;
+	ProductAttr: string;
+}
+
+// ItemType ...
+export class ItemType {
+	SkuAttr: string | null;
+}
+
+// ShelfType ...
+export class ShelfType {
+	Item: Array<ItemType>;
+}
+
+// CatalogType ...
+export class CatalogType {
+	Product: Array<ProductType>;
+	Order: Array<OrderType>;
+	Shelf: Array<ShelfType>;
+}
+
+// Catalog ...
+export type Catalog = CatalogType;
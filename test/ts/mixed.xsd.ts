@@ -0,0 +1,19 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// ParagraphType ...
+export class ParagraphType {
+	LangAttr: string | null;
+	Content: Array<string | { b: string } | { i: string }>;
+}
+
+// NoteType ...
+export class NoteType extends ParagraphType  {
+	AuthorAttr: string | null;
+}
+
+// Article ...
+export class Article {
+	Title: string;
+	Para: Array<ParagraphType>;
+	Note: NoteType | null;
+}
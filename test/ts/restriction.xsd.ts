@@ -0,0 +1,35 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// ContactType ...
+export class ContactType {
+	IdAttr: string | null;
+	LangAttr: string | null;
+	VersionAttr: number | null;
+	Name: string;
+	Note: string | null;
+}
+
+// RequiredContactType ...
+export class RequiredContactType {
+	IdAttr: string;
+	VersionAttr: number | null;
+	Name: string;
+}
+
+// PriceType ...
+export class PriceType {
+	CurrencyAttr: string | null;
+	Value: number;
+}
+
+// EuroPriceType ...
+export class EuroPriceType {
+	CurrencyAttr: string;
+	Value: number;
+}
+
+// Directory ...
+export class Directory {
+	Contact: Array<RequiredContactType>;
+	Fee: EuroPriceType;
+}
@@ -0,0 +1,45 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// PartyType ...
+export class PartyType {
+	Name: string;
+	Email: string | null;
+}
+
+// CustomerType ...
+export class CustomerType extends PartyType  {
+	Account: number;
+}
+
+// PostalAddressType ...
+export class PostalAddressType {
+	Street: string;
+	City: string;
+}
+
+// RemarkGroup ...
+export class RemarkGroup {
+	Remark: string;
+	Priority: number | null;
+}
+
+// WaybillType ...
+export class WaybillType {
+	Number: string;
+	RemarkGroup: RemarkGroup | null;
+	Parcel: string;
+	Weight: number;
+}
+
+// ManifestType ...
+export class ManifestType {
+	Code: string;
+	Note: string;
+	RemarkGroup: Array<RemarkGroup>;
+}
+
+// Shipment ...
+export class Shipment {
+	Customer: CustomerType;
+	Address: PostalAddressType;
+}
@@ -0,0 +1,55 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// ShapeType ...
+export class ShapeType {
+	ColorAttr: string | null;
+}
+
+// CircleType ...
+export class CircleType extends ShapeType  {
+	RadiusAttr: number | null;
+}
+
+// SquareType ...
+export class SquareType extends ShapeType  {
+	SideAttr: number | null;
+}
+
+// Shape ...
+export type Shape = ShapeType;
+
+// Circle ...
+export type Circle = CircleType;
+
+// Square ...
+export type Square = SquareType;
+
+// RoundedSquare ...
+export type RoundedSquare = SquareType;
+
+// Label ...
+export type Label = string;
+
+// Caption ...
+export type Caption = string;
+
+// Gallery ...
+export class Gallery {
+	Shape: Array<ShapeSubstitution>;
+	Label: LabelSubstitution | null;
+}
+
+// Drawing ...
+export class Drawing {
+	Title: string;
+	Shape: Array<ShapeSubstitution>;
+}
+
+// ShapeSubstitution is the substitution group of the Shape element.
+export type ShapeSubstitution = { Circle: CircleType } | { Square: SquareType } | { RoundedSquare: SquareType };
+
+// SquareSubstitution is the substitution group of the Square element.
+export type SquareSubstitution = { Square: SquareType } | { RoundedSquare: SquareType };
+
+// LabelSubstitution is the substitution group of the Label element.
+export type LabelSubstitution = { Caption: string };
@@ -0,0 +1,53 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// DimensionTypeMember2 ...
+export enum DimensionTypeMember2 {
+	auto = 'auto',
+	inherit = 'inherit',
+}
+
+// DimensionTypeMember3 ...
+export type DimensionTypeMember3 = number;
+
+// DimensionType ...
+export class DimensionType {
+	Int: number;
+	DimensionTypeMember3: number;
+	DimensionTypeMember2: string;
+}
+
+// MarginMember1 ...
+export enum MarginMember1 {
+	none = 'none',
+}
+
+// MarginMember2 ...
+export type MarginMember2 = number;
+
+// Margin ...
+export class Margin {
+	MarginMember2: number;
+	MarginMember1: string;
+}
+
+// AlignMember1 ...
+export enum AlignMember1 {
+	left = 'left',
+	right = 'right',
+}
+
+// AlignMember2 ...
+export type AlignMember2 = number;
+
+// Align ...
+export class Align {
+	AlignMember2: number;
+	AlignMember1: string;
+}
+
+// BoxType ...
+export class BoxType {
+	AlignAttr: Align | null;
+	Width: DimensionType;
+	Margin: Margin;
+}
@@ -0,0 +1,26 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// ExtensionType ...
+export class ExtensionType {
+	AnyAttr: Record<string, string>;
+	Id: string;
+	Any: Array<string>;
+}
+
+// ExtensionGroup ...
+export class ExtensionGroup {
+	Any: Array<string>;
+}
+
+// ExtensionAttributes ...
+export class ExtensionAttributes {
+	VersionAttr: string | null;
+	AnyAttr: Record<string, string>;
+}
+
+// Envelope ...
+export class Envelope {
+	AnyAttr: Record<string, string>;
+	Header: ExtensionType;
+	Any: Array<string>;
+}
@@ -0,0 +1,36 @@
+// Code generated by xgen. DO NOT EDIT.
+
+// EvenNumber ...
+// assert: $value mod 2 = 0
+export type EvenNumber = number;
+
+// RangeType ...
+// assert: @min le @max
+export class RangeType {
+	MinAttr: number | null;
+	MaxAttr: number | null;
+}
+
+// VehicleType ...
+export class VehicleType {
+	KindAttr: string | null;
+	Any: Array<string>;
+	Wheels: number;
+}
+
+// TruckType ...
+export class TruckType extends VehicleType  {
+	Any: Array<string>;
+	Load: number;
+}
+
+// GarageType ...
+export class GarageType {
+	Any: Array<string>;
+	Range: RangeType;
+}
+
+// Vehicle ...
+// alternative: TruckType if @kind = 'truck'
+// alternative: VehicleType
+export type Vehicle = VehicleType;
@@ -9,15 +9,15 @@
 package xgen
 
 import (
+	"encoding/xml"
 	"fmt"
-	"io/ioutil"
-	"net/http"
+	"math"
 	"net/url"
 	"os"
 	"path/filepath"
-	"reflect"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"unicode"
 	"unicode/utf8"
@@ -144,26 +144,72 @@ func getBuildInTypeByLang(value, lang string) (buildType string, ok bool) {
 	return
 }
 
-func getBasefromSimpleType(name string, XSDSchema []interface{}) string {
+// buildInTypeNames returns the build-in types of the language which the
+// build-in data types of XML schema are mapped to.
+func buildInTypeNames(lang string) map[string]bool {
+	names := make(map[string]bool)
+	for name := range BuildInTypes {
+		if buildType, ok := getBuildInTypeByLang(name, lang); ok {
+			names[buildType] = true
+		}
+	}
+	return names
+}
+
+func getBasefromSimpleType(name xml.Name, XSDSchema []interface{}) string {
+	return getBaseQNamefromSimpleType(name, XSDSchema).Local
+}
+
+// getBaseQNamefromSimpleType returns the qualified name of the base type of
+// the simple type, or the type of the attribute or element with the given
+// qualified name in the proto tree. The given name will be returned if no
+// such component in the proto tree.
+func getBaseQNamefromSimpleType(name xml.Name, XSDSchema []interface{}) xml.Name {
 	for _, ele := range XSDSchema {
 		switch v := ele.(type) {
 		case *SimpleType:
-			if !v.List && !v.Union && v.Name == name {
-				return v.Base
+			if !v.List && !v.Union && v.Name == name.Local && v.TargetNamespace == name.Space {
+				return xml.Name{Space: v.BaseNamespace, Local: v.Base}
 			}
 		case *Attribute:
-			if v.Name == name {
-				return v.Type
+			if v.Name == name.Local && v.TargetNamespace == name.Space {
+				return xml.Name{Space: v.TypeNamespace, Local: v.Type}
 			}
 		case *Element:
-			if v.Name == name {
-				return v.Type
+			if v.Name == name.Local && v.TargetNamespace == name.Space {
+				return xml.Name{Space: v.TypeNamespace, Local: v.Type}
 			}
 		}
 	}
 	return name
 }
 
+// toQName returns the qualified name for the given namespace and the name
+// which may have a namespace prefix.
+func toQName(ns, name string) xml.Name {
+	return xml.Name{Space: ns, Local: trimNSPrefix(name)}
+}
+
+// parseOccurs parses the value of the minOccurs or maxOccurs attributes,
+// "unbounded" is parsed as Unbounded and negative numbers are rejected.
+func parseOccurs(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "unbounded" {
+		return Unbounded, nil
+	}
+	occurs, err := strconv.Atoi(value)
+	if err == nil && occurs < 0 {
+		err = fmt.Errorf("negative occurrence %d", occurs)
+	}
+	return occurs, err
+}
+
+// isPlural returns whether a particle with the given maximum occurrence may
+// occur more than once.
+func isPlural(maxOccurs int) bool {
+	return maxOccurs == Unbounded || maxOccurs > 1
+}
+
 func getNSPrefix(str string) (ns string) {
 	split := strings.Split(str, ":")
 	if len(split) == 2 {
@@ -202,23 +248,6 @@ func ToTitle(val string) string {
 	return buf.String()
 }
 
-// callFuncByName calls the no error or only error return function with
-// reflect by given receiver, name and parameters.
-func callFuncByName(receiver interface{}, name string, params []reflect.Value) (err error) {
-	function := reflect.ValueOf(receiver).MethodByName(name)
-	if function.IsValid() {
-		rt := function.Call(params)
-		if len(rt) == 0 {
-			return
-		}
-		if !rt[0].IsNil() {
-			err = rt[0].Interface().(error)
-			return
-		}
-	}
-	return
-}
-
 // isValidUrl tests a string to determine if it is a well-structured url or
 // not.
 func isValidURL(toTest string) bool {
@@ -235,31 +264,164 @@ func isValidURL(toTest string) bool {
 	return true
 }
 
-func fetchSchema(URL string) ([]byte, error) {
-	var body []byte
-	var client http.Client
-	var err error
-	resp, err := client.Get(URL)
-	if err != nil {
-		return body, err
+func genFieldComment(name, doc, prefix string) string {
+	docReplacer := strings.NewReplacer("\n", fmt.Sprintf("\r\n%s ", prefix), "\t", "")
+	if doc == "" {
+		return fmt.Sprintf("\r\n%s %s ...\r\n", prefix, name)
 	}
-	defer resp.Body.Close()
+	return fmt.Sprintf("\r\n%s %s is %s\r\n", prefix, name, docReplacer.Replace(doc))
+}
 
-	if resp.StatusCode == http.StatusOK {
-		body, err = ioutil.ReadAll(resp.Body)
-		if err != nil {
-			return body, err
+// contentBase returns the base type of the complex type which the generated
+// code of the type embeds or inherits. A complex type derived by restriction
+// restates its content, so only the value type of its simple content is kept
+// as the base type.
+func contentBase(v *ComplexType) (base, baseNamespace string) {
+	if v.Derivation == DerivationRestriction {
+		return v.ValueType, ""
+	}
+	return v.Base, v.BaseNamespace
+}
+
+// orderedContent returns the group references and elements of a complex
+// type or model group definition in the order they're declared by the
+// particle tree, followed by the ones not in the particle tree. The item of
+// the returned content is *Group, *Element or the *Choice of the union, which
+// takes the place of its alternatives. All element wildcards are captured by
+// the single element wildcard.
+func orderedContent(particle *Particle, groups []Group, elements []Element, union *Choice) (content []interface{}) {
+	added := make(map[interface{}]bool)
+	add := func(item interface{}) {
+		if !added[item] {
+			added[item] = true
+			content = append(content, item)
 		}
 	}
-	return body, err
+	var walk func(particle *Particle)
+	walk = func(particle *Particle) {
+		if particle == nil {
+			return
+		}
+		switch particle.Kind {
+		case ParticleChoice:
+			if union == nil || particle.Name != union.Name {
+				break
+			}
+			alternatives, _ := choiceParticleElements(particle)
+			for _, alternative := range alternatives {
+				for i := range elements {
+					if elements[i].Wildcard == nil && elements[i].Name == alternative.Name {
+						added[&elements[i]] = true
+					}
+				}
+			}
+			add(union)
+			return
+		case ParticleGroup:
+			for i := range groups {
+				if groups[i].Name == particle.Name {
+					add(&groups[i])
+					break
+				}
+			}
+		case ParticleElement:
+			for i := range elements {
+				if elements[i].Wildcard == nil && elements[i].Name == particle.Name {
+					add(&elements[i])
+					break
+				}
+			}
+		case ParticleAny:
+			if _, i := findWildcard(elements); i != -1 {
+				add(&elements[i])
+			}
+		}
+		for _, child := range particle.Particles {
+			walk(child)
+		}
+	}
+	walk(particle)
+	for i := range groups {
+		add(&groups[i])
+	}
+	for i := range elements {
+		add(&elements[i])
+	}
+	return
 }
 
-func genFieldComment(name, doc, prefix string) string {
-	docReplacer := strings.NewReplacer("\n", fmt.Sprintf("\r\n%s ", prefix), "\t", "")
-	if doc == "" {
-		return fmt.Sprintf("\r\n%s %s ...\r\n", prefix, name)
+// choiceAlternatives returns the names of the alternatives of the choice for
+// the comment of the generated union.
+func choiceAlternatives(choice *Choice) string {
+	var names []string
+	for _, element := range choice.Elements {
+		names = append(names, element.Name)
 	}
-	return fmt.Sprintf("\r\n%s %s is %s\r\n", prefix, name, docReplacer.Replace(doc))
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+	return strings.Join(names[:len(names)-1], ", ") + " or " + names[len(names)-1]
+}
+
+// valueConstraint returns the default value, or the fixed value when there
+// is no default value, which the generated code initializes the field of an
+// element or attribute with.
+func valueConstraint(defaultValue, fixed string) string {
+	if defaultValue != "" {
+		return defaultValue
+	}
+	return fixed
+}
+
+// genBooleanLiteral returns the boolean literal of the lexical representation
+// of a boolean value.
+func genBooleanLiteral(value string) (string, bool) {
+	switch strings.TrimSpace(value) {
+	case "true", "1":
+		return "true", true
+	case "false", "0":
+		return "false", true
+	}
+	return "", false
+}
+
+// genIntegerLiteral returns the decimal literal of an integer value, it
+// returns false if the value isn't an integer fitting in the bit size.
+func genIntegerLiteral(value string, bitSize int, unsigned bool) (string, bool) {
+	value = strings.TrimSpace(value)
+	if unsigned {
+		n, err := strconv.ParseUint(strings.TrimPrefix(value, "+"), 10, bitSize)
+		return strconv.FormatUint(n, 10), err == nil
+	}
+	n, err := strconv.ParseInt(value, 10, bitSize)
+	return strconv.FormatInt(n, 10), err == nil
+}
+
+// genFloatLiteral returns the literal of a decimal number, it returns false
+// if the value isn't a finite decimal number.
+func genFloatLiteral(value string) (string, bool) {
+	value = strings.TrimSpace(value)
+	f, err := strconv.ParseFloat(value, 64)
+	if err != nil || math.IsInf(f, 0) || math.IsNaN(f) || strings.ContainsAny(value, "xX_") {
+		return "", false
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64), true
+}
+
+// genConstraintComment returns the comment lines for the assertions and type
+// alternatives of a component, which are not expressed by the generated code.
+func genConstraintComment(assertions []Assertion, alternatives []Alternative, prefix string) (comment string) {
+	for _, assertion := range assertions {
+		comment += fmt.Sprintf("%s assert: %s\r\n", prefix, assertion.Test)
+	}
+	for _, alternative := range alternatives {
+		if alternative.Test == "" {
+			comment += fmt.Sprintf("%s alternative: %s\r\n", prefix, alternative.Type)
+			continue
+		}
+		comment += fmt.Sprintf("%s alternative: %s if %s\r\n", prefix, alternative.Type, alternative.Test)
+	}
+	return
 }
 
 type kvPair struct {
@@ -0,0 +1,26 @@
+// Copyright 2020 - 2021 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnAll handles parsing event on the all start elements. The all element
+// specifies that the child elements can appear in any order and that each
+// child element can occur zero or one time.
+func (opt *Options) OnAll(ele xml.StartElement, protoTree []interface{}) (err error) {
+	minOccurs, maxOccurs := opt.particleOccurs(ele)
+	opt.onCompositor(ParticleAll, minOccurs, maxOccurs)
+	return
+}
+
+// EndAll handles parsing event on the all end elements.
+func (opt *Options) EndAll(ele xml.EndElement, protoTree []interface{}) (err error) {
+	opt.endCompositor()
+	return
+}
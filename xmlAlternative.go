@@ -0,0 +1,41 @@
+// Copyright 2020 - 2021 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnAlternative handles parsing event on the alternative start elements. The
+// alternative element assigns the type of an element declaration on the
+// condition of an XPath expression, it's introduced by XML Schema 1.1.
+// Alternatives with an anonymous type definition are not supported and
+// skipped.
+func (opt *Options) OnAlternative(ele xml.StartElement, protoTree []interface{}) (err error) {
+	var alternative Alternative
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "test" {
+			alternative.Test = attr.Value
+		}
+		if attr.Name.Local == "type" {
+			alternative.Type, alternative.TypeNamespace, err = opt.getValueType(attr.Value)
+			if err != nil {
+				return
+			}
+		}
+	}
+	if alternative.Type == "" {
+		opt.report(DiagnosticUnsupported, "alternative with an anonymous type definition is not supported")
+		return SkipElement
+	}
+	if elementDecl, ok := opt.ElementDecl.Peek().(func() *Element); ok {
+		if e := elementDecl(); e != nil {
+			e.Alternatives = append(e.Alternatives, alternative)
+		}
+	}
+	return
+}
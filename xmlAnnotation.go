@@ -0,0 +1,135 @@
+// Copyright 2020 - 2021 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// OnAnnotation handles parsing event on the annotation start elements. The
+// annotation element holds the documentation and application information of
+// the schema component containing it.
+func (opt *Options) OnAnnotation(ele xml.StartElement, protoTree []interface{}) (err error) {
+	opt.Annotation = &Annotation{}
+	return
+}
+
+// EndAnnotation handles parsing event on the annotation end elements. The
+// annotation is attached to the schema component containing it.
+func (opt *Options) EndAnnotation(ele xml.EndElement, protoTree []interface{}) (err error) {
+	annotation := opt.Annotation
+	opt.Annotation = nil
+	if annotation == nil || len(opt.ancestors) < 2 {
+		return
+	}
+	doc := annotation.Doc("")
+	switch opt.ancestors[len(opt.ancestors)-2].Name.Local {
+	case "schema":
+		opt.Annotations = append(opt.Annotations, annotation)
+	case "element":
+		if opt.ElementDecl.Len() > 0 {
+			if e := opt.ElementDecl.Peek().(func() *Element)(); e != nil {
+				e.Annotation, e.Doc = annotation, doc
+			}
+		}
+	case "attribute":
+		if opt.Attribute.Len() > 0 {
+			attribute := opt.Attribute.Peek().(*Attribute)
+			attribute.Annotation, attribute.Doc = annotation, doc
+		}
+	case "simpleType":
+		if opt.SimpleType.Len() > 0 {
+			simpleType := opt.SimpleType.Peek().(*SimpleType)
+			simpleType.Annotation, simpleType.Doc = annotation, doc
+		}
+	case "complexType":
+		if opt.ComplexType.Len() > 0 {
+			complexType := opt.ComplexType.Peek().(*ComplexType)
+			complexType.Annotation, complexType.Doc = annotation, doc
+		}
+	case "group":
+		// the annotation of a group reference in the definition isn't kept
+		if opt.InGroup == 1 && opt.ComplexType.Len() == 0 && opt.Group.Len() > 0 {
+			group := opt.Group.Peek().(*Group)
+			group.Annotation, group.Doc = annotation, doc
+		}
+	case "attributeGroup":
+		if opt.AttributeGroup.Len() == 1 && opt.ComplexType.Len() == 0 {
+			attributeGroup := opt.AttributeGroup.Peek().(*AttributeGroup)
+			attributeGroup.Annotation, attributeGroup.Doc = annotation, doc
+		}
+	}
+	return
+}
+
+// Doc returns the text of the documentation entries in the given language,
+// the entries are separated by line breaks. A language matches the language
+// of an entry or a prefix of it, such as "en" matches "en-US", and a language
+// with subtags is looked up with fewer subtags when it doesn't match, such as
+// "de-DE" is looked up as "de". The entries without language are returned
+// when no entry is in the given language or the language is empty, or else
+// the entries in the language of the first entry.
+func (annotation *Annotation) Doc(lang string) string {
+	if annotation == nil || len(annotation.Documentation) == 0 {
+		return ""
+	}
+	var languages []string
+	for lang = strings.ToLower(lang); lang != ""; {
+		languages = append(languages, lang)
+		i := strings.LastIndex(lang, "-")
+		if i < 0 {
+			break
+		}
+		lang = lang[:i]
+	}
+	for _, language := range append(languages, "", strings.ToLower(annotation.Documentation[0].Lang)) {
+		var texts []string
+		for _, documentation := range annotation.Documentation {
+			entry := strings.ToLower(documentation.Lang)
+			if documentation.Text != "" && (entry == language || language != "" && strings.HasPrefix(entry, language+"-")) {
+				texts = append(texts, documentation.Text)
+			}
+		}
+		if len(texts) > 0 {
+			return strings.Join(texts, "\n")
+		}
+	}
+	return ""
+}
+
+// xmlLang returns the xml:lang in scope of the current element.
+func (opt *Options) xmlLang() string {
+	for i := len(opt.ancestors) - 1; i >= 0; i-- {
+		for _, attr := range opt.ancestors[i].Attr {
+			if attr.Name.Space == xmlNamespace && attr.Name.Local == "lang" {
+				return attr.Value
+			}
+		}
+	}
+	return ""
+}
+
+// charData returns the character data of the raw XML content without the
+// markup.
+func charData(content string) string {
+	var text strings.Builder
+	decoder := xml.NewDecoder(strings.NewReader(content))
+	decoder.Strict = false
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		if data, ok := token.(xml.CharData); ok {
+			text.Write(data)
+		}
+	}
+	return strings.TrimSpace(text.String())
+}
@@ -0,0 +1,69 @@
+// Copyright 2020 - 2021 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnAny handles parsing event on the any start elements. The any element
+// enables the author to extend the XML document with elements not specified
+// by the schema.
+func (opt *Options) OnAny(ele xml.StartElement, protoTree []interface{}) (err error) {
+	if opt.OpenContent != nil {
+		opt.OpenContent.Wildcard = parseWildcard(ele)
+		return
+	}
+	e := Element{Pos: opt.pos, TargetNamespace: opt.TargetNamespace, Wildcard: parseWildcard(ele)}
+	minOccurs, maxOccurs := opt.particleOccurs(ele)
+	opt.addParticle(ParticleAny, "", minOccurs, maxOccurs)
+	e.MinOccurs, e.MaxOccurs = opt.effectiveOccurs(minOccurs, maxOccurs)
+	e.Optional, e.Plural = e.MinOccurs == 0, isPlural(e.MaxOccurs)
+
+	if opt.ComplexType.Len() > 0 {
+		if element, i := findWildcard(opt.ComplexType.Peek().(*ComplexType).Elements); element != nil {
+			element.MinOccurs, element.MaxOccurs = mergeOccurs(element.MinOccurs, element.MaxOccurs, e.MinOccurs, e.MaxOccurs)
+			element.Plural = element.Plural || e.Plural
+			element.Optional = element.Optional || e.Optional
+			opt.ComplexType.Peek().(*ComplexType).Elements[i] = *element
+			return
+		}
+		opt.ComplexType.Peek().(*ComplexType).Elements = append(opt.ComplexType.Peek().(*ComplexType).Elements, e)
+		return
+	}
+
+	if opt.InGroup > 0 && opt.Group.Len() > 0 {
+		opt.Group.Peek().(*Group).Elements = append(opt.Group.Peek().(*Group).Elements, e)
+	}
+	return
+}
+
+// parseWildcard returns the wildcard declared by the any or anyAttribute
+// element.
+func parseWildcard(ele xml.StartElement) *Wildcard {
+	wildcard := Wildcard{Namespace: "##any", ProcessContents: "strict"}
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "namespace" {
+			wildcard.Namespace = attr.Value
+		}
+		if attr.Name.Local == "processContents" {
+			wildcard.ProcessContents = attr.Value
+		}
+	}
+	return &wildcard
+}
+
+// findWildcard returns the element wildcard in the elements, all element
+// wildcards of a complex type are captured by a single field.
+func findWildcard(elements []Element) (existing *Element, index int) {
+	for i, ele := range elements {
+		if ele.Wildcard != nil {
+			return &ele, i
+		}
+	}
+	return nil, -1
+}
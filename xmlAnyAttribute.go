@@ -0,0 +1,25 @@
+// Copyright 2020 - 2021 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnAnyAttribute handles parsing event on the anyAttribute start elements.
+// The anyAttribute element enables the author to extend the XML document
+// with attributes not specified by the schema.
+func (opt *Options) OnAnyAttribute(ele xml.StartElement, protoTree []interface{}) (err error) {
+	if opt.AttributeGroup.Len() > 0 {
+		opt.AttributeGroup.Peek().(*AttributeGroup).AnyAttribute = parseWildcard(ele)
+		return
+	}
+	if opt.ComplexType.Len() > 0 {
+		opt.ComplexType.Peek().(*ComplexType).AnyAttribute = parseWildcard(ele)
+	}
+	return
+}
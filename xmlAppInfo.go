@@ -0,0 +1,37 @@
+// Copyright 2020 - 2021 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// OnAppInfo handles parsing event on the appinfo start elements. The appinfo
+// element specifies information to be used by applications within an
+// annotation element, its content is kept as raw XML.
+func (opt *Options) OnAppInfo(ele xml.StartElement, protoTree []interface{}) (err error) {
+	var content struct {
+		InnerXML string `xml:",innerxml"`
+	}
+	if err = opt.decoder.DecodeElement(&content, &ele); err != nil {
+		return
+	}
+	if opt.Annotation == nil {
+		return errElementDecoded
+	}
+	appInfo := AppInfo{InnerXML: strings.TrimSpace(content.InnerXML)}
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "source" {
+			appInfo.Source = attr.Value
+		}
+	}
+	opt.Annotation.AppInfo = append(opt.Annotation.AppInfo, appInfo)
+	return errElementDecoded
+}
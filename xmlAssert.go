@@ -0,0 +1,35 @@
+// Copyright 2020 - 2021 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnAssert handles parsing event on the assert start elements. The assert
+// element constrains the content of a complex type by an XPath expression,
+// it's introduced by XML Schema 1.1.
+func (opt *Options) OnAssert(ele xml.StartElement, protoTree []interface{}) (err error) {
+	if opt.ComplexType.Len() > 0 {
+		complexType := opt.ComplexType.Peek().(*ComplexType)
+		complexType.Assertions = append(complexType.Assertions, parseAssertion(ele))
+	}
+	return
+}
+
+// parseAssertion returns the assertion of the assert or assertion element.
+func parseAssertion(ele xml.StartElement) (assertion Assertion) {
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "test" {
+			assertion.Test = attr.Value
+		}
+		if attr.Name.Local == "xpathDefaultNamespace" {
+			assertion.XPathDefaultNamespace = attr.Value
+		}
+	}
+	return
+}
@@ -0,0 +1,22 @@
+// Copyright 2020 - 2021 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnAssertion handles parsing event on the assertion start elements. The
+// assertion facet constrains the value of a simple type by an XPath
+// expression, it's introduced by XML Schema 1.1.
+func (opt *Options) OnAssertion(ele xml.StartElement, protoTree []interface{}) (err error) {
+	if opt.SimpleType.Len() > 0 {
+		restriction := &opt.SimpleType.Peek().(*SimpleType).Restriction
+		restriction.Assertions = append(restriction.Assertions, parseAssertion(ele))
+	}
+	return
+}
@@ -14,12 +14,16 @@ import "encoding/xml"
 // attributes are declared as simple types.
 func (opt *Options) OnAttribute(ele xml.StartElement, protoTree []interface{}) (err error) {
 	attribute := Attribute{
-		Optional: true,
+		Pos:             opt.pos,
+		TargetNamespace: opt.TargetNamespace,
+		Optional:        true,
 	}
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "ref" {
 			attribute.Name = attr.Value
-			attribute.Type, err = opt.GetValueType(attr.Value, protoTree)
+			ref := opt.resolveQName(attr.Value)
+			attribute.Ref, attribute.RefNamespace = ref.Local, ref.Space
+			attribute.Type, attribute.TypeNamespace, err = opt.getValueType(attr.Value)
 			if err != nil {
 				return
 			}
@@ -28,7 +32,7 @@ func (opt *Options) OnAttribute(ele xml.StartElement, protoTree []interface{}) (
 			attribute.Name = attr.Value
 		}
 		if attr.Name.Local == "type" {
-			attribute.Type, err = opt.GetValueType(attr.Value, protoTree)
+			attribute.Type, attribute.TypeNamespace, err = opt.getValueType(attr.Value)
 			if err != nil {
 				return
 			}
@@ -37,6 +41,13 @@ func (opt *Options) OnAttribute(ele xml.StartElement, protoTree []interface{}) (
 			if attr.Value == "required" {
 				attribute.Optional = false
 			}
+			attribute.Prohibited = attr.Value == "prohibited"
+		}
+		if attr.Name.Local == "default" {
+			attribute.Default = attr.Value
+		}
+		if attr.Name.Local == "fixed" {
+			attribute.Fixed = attr.Value
 		}
 	}
 	opt.Attribute.Push(&attribute)
@@ -15,14 +15,14 @@ import "encoding/xml"
 // declarations so that they can be incorporated as a group into complex type
 // definitions.
 func (opt *Options) OnAttributeGroup(ele xml.StartElement, protoTree []interface{}) (err error) {
-	attributeGroup := AttributeGroup{}
+	attributeGroup := AttributeGroup{Pos: opt.pos, TargetNamespace: opt.TargetNamespace}
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "name" {
 			attributeGroup.Name = attr.Value
 		}
 		if attr.Name.Local == "ref" {
 			attributeGroup.Name = attr.Value
-			attributeGroup.Ref, err = opt.GetValueType(attr.Value, protoTree)
+			attributeGroup.Ref, attributeGroup.RefNamespace, err = opt.getValueType(attr.Value)
 			if err != nil {
 				return
 			}
@@ -44,6 +44,20 @@ func (opt *Options) OnAttributeGroup(ele xml.StartElement, protoTree []interface
 
 // EndAttributeGroup handles parsing event on the attributeGroup end elements.
 func (opt *Options) EndAttributeGroup(ele xml.EndElement, protoTree []interface{}) (err error) {
+	if opt.AttributeGroup.Len() > 1 {
+		// reference to an attribute group in an attribute group definition,
+		// a reference to the definition itself marks the redefinition of an
+		// attribute group which includes the attributes of the original one.
+		ref, attributeGroup := opt.AttributeGroup.Pop().(*AttributeGroup), opt.AttributeGroup.Peek().(*AttributeGroup)
+		if ref.Ref == attributeGroup.Name && ref.RefNamespace == attributeGroup.TargetNamespace {
+			attributeGroup.Ref, attributeGroup.RefNamespace = ref.Ref, ref.RefNamespace
+			return
+		}
+		if definition := findAttributeGroup(toQName(ref.RefNamespace, ref.Ref), protoTree); definition != nil {
+			attributeGroup.Attributes = append(attributeGroup.Attributes, definition.Attributes...)
+		}
+		return
+	}
 	if opt.AttributeGroup.Len() > 0 {
 		opt.ProtoTree = append(opt.ProtoTree, opt.AttributeGroup.Pop())
 		opt.CurrentEle = ""
@@ -51,3 +65,14 @@ func (opt *Options) EndAttributeGroup(ele xml.EndElement, protoTree []interface{
 	}
 	return
 }
+
+// findAttributeGroup returns the attribute group definition by given
+// qualified name in the proto tree, or nil if not found.
+func findAttributeGroup(name xml.Name, protoTree []interface{}) *AttributeGroup {
+	for _, ele := range protoTree {
+		if attributeGroup, ok := ele.(*AttributeGroup); ok && attributeGroup.Name == name.Local && attributeGroup.TargetNamespace == name.Space {
+			return attributeGroup
+		}
+	}
+	return nil
+}
@@ -8,42 +8,27 @@
 
 package xgen
 
-import (
-	"encoding/xml"
-	"strconv"
-)
+import "encoding/xml"
 
 // OnChoice handles parsing event on the choice start elements. The
 // choice element defines that one and only one of the contained element can be present within
 // the contained element.
 func (opt *Options) OnChoice(ele xml.StartElement, protoTree []interface{}) (err error) {
-	choice := Choice{}
-	for _, attr := range ele.Attr {
-		if attr.Name.Local == "maxOccurs" {
-			var maxOccurs int
-			if maxOccurs, err = strconv.Atoi(attr.Value); attr.Value != "unbounded" && err != nil {
-				return
-			}
-			if attr.Value == "unbounded" || maxOccurs > 1 {
-				choice.Plural, err = true, nil
-			} else {
-				choice.Plural, err = false, nil
-			}
-		}
-	}
-	// Handle a case of a parent choice having plurality that children should inherit
-	if opt.Choice.Len() > 0 {
-		choice.Plural = choice.Plural || opt.Choice.Peek().(*Choice).Plural
-	}
+	var choice Choice
+	minOccurs, maxOccurs := opt.particleOccurs(ele)
+	choice.MinOccurs, choice.MaxOccurs = opt.effectiveOccurs(minOccurs, maxOccurs)
+	choice.Optional, choice.Plural = choice.MinOccurs == 0, isPlural(choice.MaxOccurs)
 
 	opt.Choice.Push(&choice)
-
+	opt.onCompositor(ParticleChoice, minOccurs, maxOccurs)
+	opt.Particle.Peek().(*compositor).choice = &choice
 	return
 }
 
 // EndChoice handles parsing event on the choice end elements.
 func (opt *Options) EndChoice(ele xml.EndElement, protoTree []interface{}) (err error) {
 	opt.Choice.Pop()
+	opt.endCompositor()
 
 	return
 }
\ No newline at end of file
@@ -0,0 +1,27 @@
+// Copyright 2020 - 2021 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnComplexContent handles parsing event on the complexContent start
+// elements. The complexContent element defines extensions or restrictions on
+// a complex type that contains mixed content or elements only, the mixed
+// attribute of the element overrides the mixed attribute of the complex type.
+func (opt *Options) OnComplexContent(ele xml.StartElement, protoTree []interface{}) (err error) {
+	if opt.ComplexType.Len() == 0 {
+		return
+	}
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "mixed" {
+			opt.ComplexType.Peek().(*ComplexType).Mixed = isMixed(ele)
+		}
+	}
+	return
+}
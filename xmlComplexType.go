@@ -11,17 +11,19 @@ package xgen
 import "encoding/xml"
 
 // OnComplexType handles parsing event on the complex start elements. A
-// complex element contains other elements and/or attributes.
+// complex element contains other elements and/or attributes, the element
+// contains character data between its child elements if it's mixed.
 func (opt *Options) OnComplexType(ele xml.StartElement, protoTree []interface{}) (err error) {
 	if opt.ComplexType.Len() > 0 {
-		e := opt.Element.Pop().(*Element)
-		opt.ComplexType.Push(&ComplexType{
-			Name: e.Name,
-		})
+		opt.Element.Pop()
+		c := ComplexType{Pos: opt.pos, TargetNamespace: opt.TargetNamespace, Anonymous: true, Mixed: isMixed(ele)}
+		c.Name, c.Parent = opt.anonymousTypeName()
+		opt.typeElementDecl(c.Name)
+		opt.ComplexType.Push(&c)
 	}
 
 	if opt.ComplexType.Len() == 0 {
-		c := ComplexType{}
+		c := ComplexType{Pos: opt.pos, TargetNamespace: opt.TargetNamespace, Mixed: isMixed(ele)}
 		opt.CurrentEle = opt.InElement
 		for _, attr := range ele.Attr {
 			if attr.Name.Local == "name" {
@@ -29,17 +31,44 @@ func (opt *Options) OnComplexType(ele xml.StartElement, protoTree []interface{})
 			}
 		}
 		if c.Name == "" {
-			e := opt.Element.Pop().(*Element)
-			c.Name = e.Name
+			opt.Element.Pop()
+			c.Anonymous = true
+			c.Name, c.Parent = opt.anonymousTypeName()
+			opt.typeElementDecl(c.Name)
 		}
 		opt.ComplexType.Push(&c)
 	}
 	return
 }
 
+// isMixed reports whether the complexType or complexContent element declares
+// mixed content.
+func isMixed(ele xml.StartElement) bool {
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "mixed" {
+			return attr.Value == "true" || attr.Value == "1"
+		}
+	}
+	return false
+}
+
+// typeElementDecl sets the type of the element declaration being parsed to
+// the anonymous type defined by the declaration.
+func (opt *Options) typeElementDecl(name string) {
+	if decl, ok := opt.ElementDecl.Peek().(func() *Element); ok {
+		if e := decl(); e != nil {
+			e.Type, e.TypeNamespace = name, opt.TargetNamespace
+		}
+	}
+}
+
 // EndComplexType handles parsing event on the complex end elements.
 func (opt *Options) EndComplexType(ele xml.EndElement, protoTree []interface{}) (err error) {
-	opt.ProtoTree = append(opt.ProtoTree, opt.ComplexType.Pop())
+	complexType := opt.ComplexType.Pop()
+	if c, ok := complexType.(*ComplexType); ok {
+		opt.defaultOpenContent(c)
+	}
+	opt.ProtoTree = append(opt.ProtoTree, complexType)
 	opt.CurrentEle = ""
 	return
 }
@@ -0,0 +1,35 @@
+// Copyright 2020 - 2021 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnDocumentation handles parsing event on the documentation start elements.
+// The documentation element specifies information to be read or used by users
+// within an annotation element. The content of the element is decoded by the
+// handler, the markup in the content is dropped from the text.
+func (opt *Options) OnDocumentation(ele xml.StartElement, protoTree []interface{}) (err error) {
+	var content struct {
+		InnerXML string `xml:",innerxml"`
+	}
+	if err = opt.decoder.DecodeElement(&content, &ele); err != nil {
+		return
+	}
+	if opt.Annotation == nil {
+		return errElementDecoded
+	}
+	documentation := Documentation{Lang: opt.xmlLang(), Text: charData(content.InnerXML)}
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "source" {
+			documentation.Source = attr.Value
+		}
+	}
+	opt.Annotation.Documentation = append(opt.Annotation.Documentation, documentation)
+	return errElementDecoded
+}
@@ -8,18 +8,16 @@
 
 package xgen
 
-import (
-	"encoding/xml"
-	"strconv"
-)
+import "encoding/xml"
 
 // OnElement handles parsing event on the element start elements.
 func (opt *Options) OnElement(ele xml.StartElement, protoTree []interface{}) (err error) {
-	e := Element{}
+	e := Element{Pos: opt.pos, TargetNamespace: opt.TargetNamespace}
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "ref" {
-			e.Name = attr.Value
-			e.Type, err = opt.GetValueType(attr.Value, protoTree)
+			ref := opt.resolveQName(attr.Value)
+			e.Name, e.Ref, e.RefNamespace = ref.Local, ref.Local, ref.Space
+			e.Type, e.TypeNamespace, err = opt.getValueType(attr.Value)
 			if err != nil {
 				return
 			}
@@ -29,39 +27,42 @@ func (opt *Options) OnElement(ele xml.StartElement, protoTree []interface{}) (er
 			e.Name = attr.Value
 		}
 		if attr.Name.Local == "type" {
-			e.Type, err = opt.GetValueType(attr.Value, protoTree)
+			e.Type, e.TypeNamespace, err = opt.getValueType(attr.Value)
 			if err != nil {
 				return
 			}
 		}
-		if attr.Name.Local == "maxOccurs" {
-			var maxOccurs int
-			if maxOccurs, err = strconv.Atoi(attr.Value); attr.Value != "unbounded" && err != nil {
-				return
-			}
-			if attr.Value == "unbounded" || maxOccurs > 1 {
-				e.Plural, err = true, nil
-			}
+		if attr.Name.Local == "default" {
+			e.Default = attr.Value
 		}
-		if attr.Name.Local == "unbounded" {
-			if attr.Value != "0" {
-				e.Plural = true
-			}
+		if attr.Name.Local == "fixed" {
+			e.Fixed = attr.Value
+		}
+		if attr.Name.Local == "abstract" {
+			e.Abstract = attr.Value == "true" || attr.Value == "1"
 		}
+		if attr.Name.Local == "substitutionGroup" {
+			head := opt.resolveQName(attr.Value)
+			e.SubstitutionGroup, e.SubstitutionGroupNamespace = head.Local, head.Space
+		}
+	}
+	minOccurs, maxOccurs := opt.particleOccurs(ele)
+	opt.addParticle(ParticleElement, e.Name, minOccurs, maxOccurs)
+	e.MinOccurs, e.MaxOccurs = opt.effectiveOccurs(minOccurs, maxOccurs)
+	e.Optional, e.Plural = e.MinOccurs == 0, isPlural(e.MaxOccurs)
+	if opt.ComplexType.Len() == 0 && opt.InGroup == 0 {
+		opt.GlobalElements = append(opt.GlobalElements, &e)
 	}
 
 	if e.Type == "" {
-		e.Type, err = opt.GetValueType(e.Name, protoTree)
+		e.Type, e.TypeNamespace, err = opt.getValueType(e.Name)
 		if err != nil {
 			return
 		}
+		e.AnonymousType = true
 		opt.Element.Push(&e)
 	}
 
-	if opt.Choice.Len() > 0 {
-		e.Plural = e.Plural || opt.Choice.Peek().(*Choice).Plural
-	}
-
 	if opt.ComplexType.Len() > 0 {
 		element, i := findElement(&e, opt.ComplexType.Peek().(*ComplexType).Elements)
 		// Handle a case where two elements with the same name and type are present in the same complex type
@@ -71,28 +72,40 @@ func (opt *Options) OnElement(ele xml.StartElement, protoTree []interface{}) (er
 		// In this situation, the version of the element that's preserved is the one with the highest plurality
 		// since generated code for an array of a type should be compatible to unmarshal/marshal arrays of a single
 		// element
+		complexType := opt.ComplexType.Peek().(*ComplexType)
 		if element != nil && element.Type == e.Type {
+			element.MinOccurs, element.MaxOccurs = mergeOccurs(element.MinOccurs, element.MaxOccurs, e.MinOccurs, e.MaxOccurs)
 			element.Plural = element.Plural || e.Plural
-			opt.ComplexType.Peek().(*ComplexType).Elements[i] = *element
+			element.Optional = element.Optional || e.Optional
+			complexType.Elements[i] = *element
 		} else {
-			opt.ComplexType.Peek().(*ComplexType).Elements = append(opt.ComplexType.Peek().(*ComplexType).Elements, e)
+			complexType.Elements = append(complexType.Elements, e)
+			i = len(complexType.Elements) - 1
 		}
+		opt.ElementDecl.Push(func() *Element { return &complexType.Elements[i] })
 		return
 	}
 
 	if opt.InGroup > 0 {
-		if opt.Group.Len() > 0 {
-			opt.Group.Peek().(*Group).Elements = append(opt.Group.Peek().(*Group).Elements, e)
+		if opt.Group.Len() == 0 {
+			opt.ElementDecl.Push(func() *Element { return nil })
+			return
 		}
+		group := opt.Group.Peek().(*Group)
+		group.Elements = append(group.Elements, e)
+		i := len(group.Elements) - 1
+		opt.ElementDecl.Push(func() *Element { return &group.Elements[i] })
 		return
 	}
 
+	opt.ElementDecl.Push(func() *Element { return &e })
 	opt.Element.Push(&e)
 	return
 }
 
 // EndElement handles parsing event on the element end elements.
 func (opt *Options) EndElement(ele xml.EndElement, protoTree []interface{}) (err error) {
+	opt.ElementDecl.Pop()
 	if opt.Element.Len() > 0 && opt.ComplexType.Len() == 0 {
 		opt.ProtoTree = append(opt.ProtoTree, opt.Element.Pop())
 	}
@@ -107,3 +120,15 @@ func findElement(element *Element, elements []Element) (existing *Element, index
 	}
 	return nil, -1
 }
+
+// mergeOccurs returns the occurrence bounds which allow both of the given
+// occurrence bounds.
+func mergeOccurs(minOccurs, maxOccurs, otherMinOccurs, otherMaxOccurs int) (int, int) {
+	if otherMinOccurs < minOccurs {
+		minOccurs = otherMinOccurs
+	}
+	if maxOccurs != Unbounded && (otherMaxOccurs == Unbounded || otherMaxOccurs > maxOccurs) {
+		maxOccurs = otherMaxOccurs
+	}
+	return minOccurs, maxOccurs
+}
@@ -25,14 +25,16 @@ func (opt *Options) OnEnumeration(ele xml.StartElement, protoTree []interface{})
 // EndEnumeration handles parsing event on the enumeration end elements.
 // Enumeration defines a list of acceptable values.
 func (opt *Options) EndEnumeration(ele xml.EndElement, protoTree []interface{}) (err error) {
-	if opt.Attribute.Len() > 0 && opt.SimpleType.Peek() != nil {
-		if opt.Attribute.Peek().(*Attribute).Type, err = opt.GetValueType(opt.SimpleType.Peek().(*SimpleType).Base, opt.ProtoTree); err != nil {
+	if opt.Attribute.Len() > 0 && opt.SimpleType.Peek() != nil && !opt.InUnion {
+		attribute, simpleType := opt.Attribute.Peek().(*Attribute), opt.SimpleType.Peek().(*SimpleType)
+		if attribute.Type, attribute.TypeNamespace, err = opt.lookupValueType(toQName(simpleType.BaseNamespace, simpleType.Base)); err != nil {
 			return
 		}
 		opt.CurrentEle = ""
 	}
-	if opt.SimpleType.Len() > 0 && opt.Element.Len() > 0 {
-		if opt.Element.Peek().(*Element).Type, err = opt.GetValueType(opt.SimpleType.Peek().(*SimpleType).Base, opt.ProtoTree); err != nil {
+	if opt.SimpleType.Len() > 0 && opt.Element.Len() > 0 && !opt.InUnion {
+		element, simpleType := opt.Element.Peek().(*Element), opt.SimpleType.Peek().(*SimpleType)
+		if element.Type, element.TypeNamespace, err = opt.lookupValueType(toQName(simpleType.BaseNamespace, simpleType.Base)); err != nil {
 			return
 		}
 		opt.CurrentEle = ""
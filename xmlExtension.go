@@ -15,20 +15,8 @@ import "encoding/xml"
 func (opt *Options) OnExtension(ele xml.StartElement, protoTree []interface{}) (err error) {
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "base" {
-			var valueType string
-			valueType, err = opt.GetValueType(attr.Value, protoTree)
-			if err != nil {
-				return
-			}
 			if opt.ComplexType.Peek() != nil {
-				var complexType = opt.ComplexType.Peek().(*ComplexType)
-				complexType.Base, err = opt.GetValueType(valueType, protoTree)
-				if err != nil {
-					return
-				}
-				if complexType.Name == "" {
-					complexType.Name = attr.Value
-				}
+				err = opt.deriveComplexType(DerivationExtension, attr.Value, protoTree)
 			}
 		}
 	}
@@ -37,12 +25,33 @@ func (opt *Options) OnExtension(ele xml.StartElement, protoTree []interface{}) (
 
 // EndExtension handles parsing event on the extension end elements.
 func (opt *Options) EndExtension(ele xml.EndElement, protoTree []interface{}) (err error) {
-	if opt.Attribute.Len() > 0 && opt.SimpleType.Peek() != nil {
-		opt.Attribute.Peek().(*Attribute).Type, err = opt.GetValueType(opt.SimpleType.Pop().(*SimpleType).Base, opt.ProtoTree)
+	if opt.Attribute.Len() > 0 && opt.SimpleType.Peek() != nil && !opt.InUnion {
+		attribute, simpleType := opt.Attribute.Peek().(*Attribute), opt.SimpleType.Pop().(*SimpleType)
+		attribute.Type, attribute.TypeNamespace, err = opt.lookupValueType(toQName(simpleType.BaseNamespace, simpleType.Base))
 		if err != nil {
 			return
 		}
 		opt.CurrentEle = ""
 	}
 	return
-}
\ No newline at end of file
+}
+
+// deriveComplexType sets the base type and the derivation method of the
+// complex type being parsed. A build-in base type is the value type of the
+// complex type, the value type of a base simple type is set once the base
+// type has been resolved.
+func (opt *Options) deriveComplexType(derivation, value string, protoTree []interface{}) (err error) {
+	complexType, name := opt.ComplexType.Peek().(*ComplexType), opt.resolveQName(value)
+	complexType.Base, complexType.BaseNamespace, err = opt.lookupValueType(name)
+	if err != nil {
+		return
+	}
+	complexType.Derivation = derivation
+	if toQName(complexType.BaseNamespace, complexType.Base) != name {
+		complexType.ValueType = complexType.Base
+	}
+	if complexType.Name == "" {
+		complexType.Name = value
+	}
+	return
+}
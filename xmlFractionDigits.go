@@ -10,16 +10,16 @@ package xgen
 
 import "encoding/xml"
 
-// EndFractionDigits handles parsing event on the fractionDigits end elements.
-// Enumeration Defines a list of acceptable values. FractionDigits specifies
-// the maximum number of decimal places allowed. Must be equal to or greater
-// than zero.
-func (opt *Options) EndFractionDigits(ele xml.EndElement, protoTree []interface{}) (err error) {
-	if opt.SimpleType.Len() > 0 && opt.Element.Len() > 0 {
-		if opt.Element.Peek().(*Element).Type, err = opt.GetValueType(opt.SimpleType.Pop().(*SimpleType).Base, opt.ProtoTree); err != nil {
-			return
-		}
-		opt.CurrentEle = ""
+// OnFractionDigits handles parsing event on the fractionDigits start
+// elements. FractionDigits specifies the maximum number of decimal places
+// allowed. Must be equal to or greater than zero. The value is stored as the
+// precision of the restriction.
+func (opt *Options) OnFractionDigits(ele xml.StartElement, protoTree []interface{}) (err error) {
+	if opt.SimpleType.Peek() == nil {
+		return
+	}
+	if value := opt.facetValue(ele); value != nil {
+		opt.SimpleType.Peek().(*SimpleType).Restriction.Precision = value
 	}
 	return
 }
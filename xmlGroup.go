@@ -14,27 +14,26 @@ import "encoding/xml"
 // element is used to define a group of elements to be used in complex type
 // definitions.
 func (opt *Options) OnGroup(ele xml.StartElement, protoTree []interface{}) (err error) {
-	group := Group{}
+	group := Group{Pos: opt.pos, TargetNamespace: opt.TargetNamespace}
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "name" {
 			group.Name = attr.Value
 		}
 		if attr.Name.Local == "ref" {
-			group.Name = attr.Value
-			group.Ref, err = opt.GetValueType(attr.Value, protoTree)
+			group.Name = trimNSPrefix(attr.Value)
+			group.Ref, group.RefNamespace, err = opt.getValueType(attr.Value)
 			if err != nil {
 				return
 			}
 		}
-		if attr.Name.Local == "maxOccurs" {
-			if attr.Value != "0" {
-				group.Plural = true
-			}
-		}
 	}
-	if opt.Choice.Len() > 0 {
-		group.Plural = group.Plural || opt.Choice.Peek().(*Choice).Plural
+	minOccurs, maxOccurs := opt.particleOccurs(ele)
+	if group.Ref != "" {
+		opt.addParticle(ParticleGroup, group.Name, minOccurs, maxOccurs)
+		minOccurs, maxOccurs = opt.effectiveOccurs(minOccurs, maxOccurs)
 	}
+	group.MinOccurs, group.MaxOccurs = minOccurs, maxOccurs
+	group.Optional, group.Plural = group.MinOccurs == 0, isPlural(group.MaxOccurs)
 
 	if opt.ComplexType.Len() == 0 {
 		if opt.InGroup == 0 {
@@ -14,6 +14,17 @@ import "encoding/xml"
 // element defines a simple type element as a list of values of a specified
 // data type.
 func (opt *Options) OnImport(ele xml.StartElement, protoTree []interface{}) (err error) {
-	opt.prepareNSSchemaLocationMap(ele)
-	return
+	reference := SchemaReference{Kind: ele.Name.Local}
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "namespace" {
+			reference.Namespace = attr.Value
+		}
+		if attr.Name.Local == "schemaLocation" {
+			reference.SchemaLocation = attr.Value
+		}
+	}
+	if reference.SchemaLocation == "" && opt.Catalog != nil {
+		reference.SchemaLocation, _ = opt.Catalog.ResolveURI(reference.Namespace)
+	}
+	return opt.loadReference(&reference)
 }
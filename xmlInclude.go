@@ -14,13 +14,11 @@ import "encoding/xml"
 // element defines a simple type element as a list of values of a specified
 // data type.
 func (opt *Options) OnInclude(ele xml.StartElement, protoTree []interface{}) (err error) {
-	for _, ele := range ele.Attr {
-		if ele.Name.Local == "schemaLocation" {
-			if _, ok := opt.IncludeMap[ele.Value]; ok {
-				continue
-			}
-			opt.IncludeMap[ele.Value] = true
+	reference := SchemaReference{Kind: ele.Name.Local}
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "schemaLocation" {
+			reference.SchemaLocation = attr.Value
 		}
 	}
-	return
+	return opt.loadReference(&reference)
 }
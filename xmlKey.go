@@ -0,0 +1,55 @@
+// Copyright 2020 - 2021 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnKey handles parsing event on the key start elements. The key element
+// specifies that an attribute or element value (or set of values) must be a
+// key within the specified scope.
+func (opt *Options) OnKey(ele xml.StartElement, protoTree []interface{}) (err error) {
+	opt.onIdentityConstraint("key", ele)
+	return
+}
+
+// EndKey handles parsing event on the key end elements.
+func (opt *Options) EndKey(ele xml.EndElement, protoTree []interface{}) (err error) {
+	opt.endIdentityConstraint()
+	return
+}
+
+// onIdentityConstraint pushes the key, keyref or unique identity constraint
+// declared by the element.
+func (opt *Options) onIdentityConstraint(category string, ele xml.StartElement) {
+	constraint := IdentityConstraint{Category: category, TargetNamespace: opt.TargetNamespace}
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "name" {
+			constraint.Name = attr.Value
+		}
+		if attr.Name.Local == "refer" {
+			refer := opt.resolveQName(attr.Value)
+			constraint.Refer, constraint.ReferNamespace = refer.Local, refer.Space
+		}
+	}
+	opt.IdentityConstraint.Push(&constraint)
+}
+
+// endIdentityConstraint pops the identity constraint and adds it to the
+// element declaration which the constraint is scoped to.
+func (opt *Options) endIdentityConstraint() {
+	constraint, ok := opt.IdentityConstraint.Pop().(*IdentityConstraint)
+	if !ok {
+		return
+	}
+	if elementDecl, ok := opt.ElementDecl.Peek().(func() *Element); ok {
+		if e := elementDecl(); e != nil {
+			e.IdentityConstraints = append(e.IdentityConstraints, *constraint)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright 2020 - 2021 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnKeyref handles parsing event on the keyref start elements. The keyref
+// element specifies that an attribute or element value (or set of values)
+// correspond to those of the specified key or unique element.
+func (opt *Options) OnKeyref(ele xml.StartElement, protoTree []interface{}) (err error) {
+	opt.onIdentityConstraint("keyref", ele)
+	return
+}
+
+// EndKeyref handles parsing event on the keyref end elements.
+func (opt *Options) EndKeyref(ele xml.EndElement, protoTree []interface{}) (err error) {
+	opt.endIdentityConstraint()
+	return
+}
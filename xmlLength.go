@@ -10,15 +10,15 @@ package xgen
 
 import "encoding/xml"
 
-// EndLength handles parsing event on the length end elements. Length
+// OnLength handles parsing event on the length start elements. Length
 // specifies the exact number of characters or list items allowed. Must be
 // equal to or greater than zero.
-func (opt *Options) EndLength(ele xml.EndElement, protoTree []interface{}) (err error) {
-	if opt.SimpleType.Len() > 0 && opt.Element.Len() > 0 {
-		if opt.Element.Peek().(*Element).Type, err = opt.GetValueType(opt.SimpleType.Pop().(*SimpleType).Base, opt.ProtoTree); err != nil {
-			return
-		}
-		opt.CurrentEle = ""
+func (opt *Options) OnLength(ele xml.StartElement, protoTree []interface{}) (err error) {
+	if opt.SimpleType.Peek() == nil {
+		return
+	}
+	if value := opt.facetValue(ele); value != nil {
+		opt.SimpleType.Peek().(*SimpleType).Restriction.Length = value
 	}
 	return
 }
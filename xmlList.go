@@ -20,7 +20,8 @@ func (opt *Options) OnList(ele xml.StartElement, protoTree []interface{}) (err e
 	opt.SimpleType.Peek().(*SimpleType).List = true
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "itemType" {
-			if opt.SimpleType.Peek().(*SimpleType).Base, err = opt.GetValueType(attr.Value, protoTree); err != nil {
+			simpleType := opt.SimpleType.Peek().(*SimpleType)
+			if simpleType.Base, simpleType.BaseNamespace, err = opt.getValueType(attr.Value); err != nil {
 				return
 			}
 		}
@@ -10,15 +10,17 @@ package xgen
 
 import "encoding/xml"
 
-// EndMaxExclusive handles parsing event on the maxExclusive end elements.
+// OnMaxExclusive handles parsing event on the maxExclusive start elements.
 // MaxExclusive specifies the upper bounds for numeric values (the value must
 // be less than this value).
-func (opt *Options) EndMaxExclusive(ele xml.EndElement, protoTree []interface{}) (err error) {
-	if opt.SimpleType.Len() > 0 && opt.Element.Len() > 0 {
-		if opt.Element.Peek().(*Element).Type, err = opt.GetValueType(opt.SimpleType.Pop().(*SimpleType).Base, opt.ProtoTree); err != nil {
-			return
-		}
-		opt.CurrentEle = ""
+func (opt *Options) OnMaxExclusive(ele xml.StartElement, protoTree []interface{}) (err error) {
+	if opt.SimpleType.Peek() == nil {
+		return
+	}
+	simpleType := opt.SimpleType.Peek().(*SimpleType)
+	if value, number, ok := opt.boundValue(ele, simpleType); ok {
+		restriction := &simpleType.Restriction
+		restriction.MaxValue, restriction.Max, restriction.MaxExclusive = value, number, true
 	}
 	return
 }
@@ -10,15 +10,15 @@ package xgen
 
 import "encoding/xml"
 
-// EndMaxLength handles parsing event on the maxLength end elements. MaxLength
-// specifies the maximum number of characters or list items allowed. Must be
-// equal to or greater than zero.
-func (opt *Options) EndMaxLength(ele xml.EndElement, protoTree []interface{}) (err error) {
-	if opt.SimpleType.Len() > 0 && opt.Element.Len() > 0 {
-		if opt.Element.Peek().(*Element).Type, err = opt.GetValueType(opt.SimpleType.Pop().(*SimpleType).Base, opt.ProtoTree); err != nil {
-			return
-		}
-		opt.CurrentEle = ""
+// OnMaxLength handles parsing event on the maxLength start elements.
+// MaxLength specifies the maximum number of characters or list items allowed.
+// Must be equal to or greater than zero.
+func (opt *Options) OnMaxLength(ele xml.StartElement, protoTree []interface{}) (err error) {
+	if opt.SimpleType.Peek() == nil {
+		return
+	}
+	if value := opt.facetValue(ele); value != nil {
+		opt.SimpleType.Peek().(*SimpleType).Restriction.MaxLength = value
 	}
 	return
 }
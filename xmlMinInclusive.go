@@ -10,15 +10,17 @@ package xgen
 
 import "encoding/xml"
 
-// EndMinInclusive handles parsing event on the minInclusive end elements.
+// OnMinInclusive handles parsing event on the minInclusive start elements.
 // MinInclusive specifies the lower bounds for numeric values (the value must
 // be greater than or equal to this value).
-func (opt *Options) EndMinInclusive(ele xml.EndElement, protoTree []interface{}) (err error) {
-	if opt.SimpleType.Len() > 0 && opt.Element.Len() > 0 {
-		if opt.Element.Peek().(*Element).Type, err = opt.GetValueType(opt.SimpleType.Pop().(*SimpleType).Base, opt.ProtoTree); err != nil {
-			return
-		}
-		opt.CurrentEle = ""
+func (opt *Options) OnMinInclusive(ele xml.StartElement, protoTree []interface{}) (err error) {
+	if opt.SimpleType.Peek() == nil {
+		return
+	}
+	simpleType := opt.SimpleType.Peek().(*SimpleType)
+	if value, number, ok := opt.boundValue(ele, simpleType); ok {
+		restriction := &simpleType.Restriction
+		restriction.MinValue, restriction.Min, restriction.MinExclusive = value, number, false
 	}
 	return
 }
@@ -10,15 +10,15 @@ package xgen
 
 import "encoding/xml"
 
-// EndMinLength handles parsing event on the minLength end elements. MinLength
-// specifies the minimum number of characters or list items allowed. Must be
-// equal to or greater than zero.
-func (opt *Options) EndMinLength(ele xml.EndElement, protoTree []interface{}) (err error) {
-	if opt.SimpleType.Len() > 0 && opt.Element.Len() > 0 {
-		if opt.Element.Peek().(*Element).Type, err = opt.GetValueType(opt.SimpleType.Pop().(*SimpleType).Base, opt.ProtoTree); err != nil {
-			return
-		}
-		opt.CurrentEle = ""
+// OnMinLength handles parsing event on the minLength start elements.
+// MinLength specifies the minimum number of characters or list items allowed.
+// Must be equal to or greater than zero.
+func (opt *Options) OnMinLength(ele xml.StartElement, protoTree []interface{}) (err error) {
+	if opt.SimpleType.Peek() == nil {
+		return
+	}
+	if value := opt.facetValue(ele); value != nil {
+		opt.SimpleType.Peek().(*SimpleType).Restriction.MinLength = value
 	}
 	return
 }
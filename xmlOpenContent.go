@@ -0,0 +1,88 @@
+// Copyright 2020 - 2021 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnOpenContent handles parsing event on the openContent start elements. The
+// openContent element allows elements matched by a wildcard in the content
+// of a complex type, it's introduced by XML Schema 1.1.
+func (opt *Options) OnOpenContent(ele xml.StartElement, protoTree []interface{}) (err error) {
+	opt.OpenContent = parseOpenContent(ele)
+	return
+}
+
+// EndOpenContent handles parsing event on the openContent end elements.
+func (opt *Options) EndOpenContent(ele xml.EndElement, protoTree []interface{}) (err error) {
+	if opt.ComplexType.Len() > 0 {
+		opt.ComplexType.Peek().(*ComplexType).OpenContent = opt.OpenContent
+	}
+	opt.OpenContent = nil
+	return
+}
+
+// OnDefaultOpenContent handles parsing event on the defaultOpenContent start
+// elements. The defaultOpenContent element specifies the open content of the
+// complex types in the schema document without their own open content.
+func (opt *Options) OnDefaultOpenContent(ele xml.StartElement, protoTree []interface{}) (err error) {
+	opt.OpenContent = parseOpenContent(ele)
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "appliesToEmpty" {
+			opt.OpenContent.AppliesToEmpty = attr.Value == "true" || attr.Value == "1"
+		}
+	}
+	return
+}
+
+// EndDefaultOpenContent handles parsing event on the defaultOpenContent end
+// elements.
+func (opt *Options) EndDefaultOpenContent(ele xml.EndElement, protoTree []interface{}) (err error) {
+	opt.DefaultOpenContent, opt.OpenContent = opt.OpenContent, nil
+	return
+}
+
+// parseOpenContent returns the open content of the openContent or
+// defaultOpenContent element, the mode defaults to "interleave". The wildcard
+// of the open content is set by the any element inside it.
+func parseOpenContent(ele xml.StartElement) *OpenContent {
+	openContent := OpenContent{Mode: "interleave"}
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "mode" {
+			openContent.Mode = attr.Value
+		}
+	}
+	return &openContent
+}
+
+// defaultOpenContent sets the default open content of the schema document to
+// the complex type without open content.
+func (opt *Options) defaultOpenContent(complexType *ComplexType) {
+	if complexType.OpenContent != nil || opt.DefaultOpenContent == nil {
+		return
+	}
+	empty := len(complexType.Elements) == 0 && len(complexType.Groups) == 0 && len(complexType.Choice) == 0
+	if !empty || opt.DefaultOpenContent.AppliesToEmpty {
+		complexType.OpenContent = opt.DefaultOpenContent
+	}
+}
+
+// hasOpenContent reports whether the complex type allows the elements matched
+// by the wildcard of its open content, which are not matched by an element
+// wildcard of the content model.
+func hasOpenContent(complexType *ComplexType) bool {
+	if complexType.OpenContent == nil || complexType.OpenContent.Mode == "none" || complexType.OpenContent.Wildcard == nil {
+		return false
+	}
+	for _, element := range complexType.Elements {
+		if element.Wildcard != nil {
+			return false
+		}
+	}
+	return true
+}
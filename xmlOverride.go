@@ -0,0 +1,24 @@
+// Copyright 2020 - 2021 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnOverride handles parsing event on the override start elements. The
+// override element replaces the components with the same name in an
+// external schema, it's introduced by XML Schema 1.1.
+func (opt *Options) OnOverride(ele xml.StartElement, protoTree []interface{}) (err error) {
+	return opt.onRedefine(ele, true)
+}
+
+// EndOverride handles parsing event on the override end elements.
+func (opt *Options) EndOverride(ele xml.EndElement, protoTree []interface{}) (err error) {
+	opt.endRedefine()
+	return
+}
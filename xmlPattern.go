@@ -8,23 +8,39 @@
 
 package xgen
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strings"
+)
 
-// EndPattern handles parsing event on the pattern end elements. Pattern
-// defines the exact sequence of characters that are acceptable.
-func (opt *Options) EndPattern(ele xml.EndElement, protoTree []interface{}) (err error) {
-	if opt.Attribute.Len() > 0 && opt.SimpleType.Peek() != nil {
-		opt.Attribute.Peek().(*Attribute).Type, err = opt.GetValueType(opt.SimpleType.Pop().(*SimpleType).Base, opt.ProtoTree)
-		if err != nil {
-			return
-		}
-		opt.CurrentEle = ""
+// OnPattern handles parsing event on the pattern start elements. Pattern
+// defines the exact sequence of characters that are acceptable. Multiple
+// patterns in the same restriction are combined as alternative branches of
+// a single regular expression.
+func (opt *Options) OnPattern(ele xml.StartElement, protoTree []interface{}) (err error) {
+	if opt.SimpleType.Peek() == nil {
+		return
 	}
-	if opt.SimpleType.Len() > 0 && opt.Element.Len() > 0 {
-		if opt.Element.Peek().(*Element).Type, err = opt.GetValueType(opt.SimpleType.Pop().(*SimpleType).Base, opt.ProtoTree); err != nil {
-			return
+	restriction := &opt.SimpleType.Peek().(*SimpleType).Restriction
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "value" {
+			restriction.Patterns = append(restriction.Patterns, attr.Value)
+			restriction.Pattern = compilePattern(restriction.Patterns)
 		}
-		opt.CurrentEle = ""
 	}
 	return
 }
+
+// compilePattern compiles the XSD regular expressions as a single Go regular
+// expression. XSD regular expressions are implicitly anchored at both ends.
+// A nil value will be returned if any expression uses XSD specific syntax
+// such as character class subtraction or the \i and \c escapes.
+func compilePattern(patterns []string) *regexp.Regexp {
+	re, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", strings.Join(patterns, "|")))
+	if err != nil {
+		return nil
+	}
+	return re
+}
@@ -0,0 +1,49 @@
+// Copyright 2020 - 2021 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnRedefine handles parsing event on the redefine start elements. The
+// redefine element redefines simple and complex types, groups, and attribute
+// groups from an external schema.
+func (opt *Options) OnRedefine(ele xml.StartElement, protoTree []interface{}) (err error) {
+	return opt.onRedefine(ele, false)
+}
+
+// EndRedefine handles parsing event on the redefine end elements.
+func (opt *Options) EndRedefine(ele xml.EndElement, protoTree []interface{}) (err error) {
+	opt.endRedefine()
+	return
+}
+
+// onRedefine starts collecting the components of a redefine or override
+// element, the schema document at the schema location is loaded as a
+// document included by the document being parsed.
+func (opt *Options) onRedefine(ele xml.StartElement, override bool) error {
+	redefine := Redefine{Override: override}
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "schemaLocation" {
+			redefine.SchemaLocation = attr.Value
+		}
+	}
+	opt.Redefine = &redefine
+	return opt.loadReference(&SchemaReference{Kind: ele.Name.Local, SchemaLocation: redefine.SchemaLocation})
+}
+
+// endRedefine moves the components defined in the redefine or override
+// element out of the proto tree. The redefine and override elements precede
+// all the definitions of a schema document, so every component of the proto
+// tree belongs to the element.
+func (opt *Options) endRedefine() {
+	opt.Redefine.Components = append(opt.Redefine.Components, opt.ProtoTree...)
+	opt.Redefines = append(opt.Redefines, opt.Redefine)
+	opt.ProtoTree = make([]interface{}, 0)
+	opt.Redefine = nil
+}
@@ -8,7 +8,11 @@
 
 package xgen
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"strconv"
+	"strings"
+)
 
 // OnRestriction handles parsing event on the restriction start elements. The
 // restriction element defines restrictions on a simpleType, simpleContent, or
@@ -16,32 +20,47 @@ import "encoding/xml"
 func (opt *Options) OnRestriction(ele xml.StartElement, protoTree []interface{}) (err error) {
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "base" {
-			var valueType string
-			valueType, err = opt.GetValueType(attr.Value, protoTree)
+			var valueType, ns string
+			valueType, ns, err = opt.getValueType(attr.Value)
 			if err != nil {
 				return
 			}
 			if opt.SimpleType.Peek() != nil {
-				opt.SimpleType.Peek().(*SimpleType).Base, err = opt.GetValueType(valueType, protoTree)
+				simpleType := opt.SimpleType.Peek().(*SimpleType)
+				simpleType.Base, simpleType.BaseNamespace, err = opt.lookupValueType(xml.Name{Space: ns, Local: valueType})
 				if err != nil {
 					return
 				}
 				if opt.SimpleType.Peek().(*SimpleType).Name == "" {
 					opt.SimpleType.Peek().(*SimpleType).Name = attr.Value
 				}
+			} else if opt.ComplexType.Peek() != nil {
+				err = opt.deriveComplexType(DerivationRestriction, attr.Value, protoTree)
 			}
 		}
 	}
 	return
 }
 
-// EndRestriction handles parsing event on the restriction end elements.
+// EndRestriction handles parsing event on the restriction end elements. The
+// anonymous simple type of an element or attribute derived by restriction is
+// replaced by its base type, and its facets are kept by the declaration.
 func (opt *Options) EndRestriction(ele xml.EndElement, protoTree []interface{}) (err error) {
-	if opt.Attribute.Len() > 0 && opt.SimpleType.Peek() != nil {
-		opt.Attribute.Peek().(*Attribute).Type, err = opt.GetValueType(opt.SimpleType.Pop().(*SimpleType).Base, opt.ProtoTree)
+	if opt.Attribute.Len() > 0 && opt.SimpleType.Peek() != nil && !opt.InUnion {
+		attribute, simpleType := opt.Attribute.Peek().(*Attribute), opt.SimpleType.Pop().(*SimpleType)
+		attribute.Type, attribute.TypeNamespace, err = opt.lookupValueType(toQName(simpleType.BaseNamespace, simpleType.Base))
 		if err != nil {
 			return
 		}
+		attribute.Restriction = &simpleType.Restriction
+		opt.CurrentEle = ""
+	}
+	if opt.Element.Len() > 0 && opt.SimpleType.Peek() != nil && opt.SimpleType.Peek().(*SimpleType).Anonymous && !opt.InUnion {
+		element, simpleType := opt.Element.Peek().(*Element), opt.SimpleType.Pop().(*SimpleType)
+		if element.Type, element.TypeNamespace, err = opt.lookupValueType(toQName(simpleType.BaseNamespace, simpleType.Base)); err != nil {
+			return
+		}
+		element.Restriction = &simpleType.Restriction
 		opt.CurrentEle = ""
 	}
 	if !opt.Element.Empty() {
@@ -51,3 +70,51 @@ func (opt *Options) EndRestriction(ele xml.EndElement, protoTree []interface{})
 	}
 	return
 }
+
+// boundValue returns the lexical value of the bound facet element being
+// parsed, and the numeric value of it if the value is a number. An empty
+// value, or a value of a build-in numeric base type which isn't a number, is
+// reported and the facet is ignored.
+func (opt *Options) boundValue(ele xml.StartElement, simpleType *SimpleType) (value string, number float64, ok bool) {
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "value" {
+			var err error
+			value = strings.TrimSpace(attr.Value)
+			number, err = strconv.ParseFloat(value, 64)
+			if value == "" || err != nil && simpleType.BaseNamespace == "" && opt.isNumericBuildInType(simpleType.Base) {
+				opt.report(DiagnosticInvalid, "invalid %s facet value %q", ele.Name.Local, attr.Value)
+				return "", 0, false
+			}
+			return value, number, true
+		}
+	}
+	return
+}
+
+// isNumericBuildInType returns whether the build-in type of the language is
+// mapped from a numeric data type of the XML schema.
+func (opt *Options) isNumericBuildInType(buildType string) bool {
+	for _, name := range []string{"decimal", "float", "double", "integer", "long", "int", "short", "byte", "unsignedLong", "unsignedInt", "unsignedShort", "unsignedByte", "positiveInteger", "negativeInteger", "nonPositiveInteger", "nonNegativeInteger"} {
+		if numeric, ok := getBuildInTypeByLang(name, opt.Lang); ok && numeric == buildType {
+			return true
+		}
+	}
+	return false
+}
+
+// facetValue returns the non-negative integer value of the length or digits
+// facet element being parsed, or nil if the facet has no valid value. An
+// invalid value is reported and the facet is ignored.
+func (opt *Options) facetValue(ele xml.StartElement) *int {
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "value" {
+			value, err := strconv.Atoi(strings.TrimSpace(attr.Value))
+			if err != nil || value < 0 {
+				opt.report(DiagnosticInvalid, "invalid %s facet value %q", ele.Name.Local, attr.Value)
+				return nil
+			}
+			return &value
+		}
+	}
+	return nil
+}
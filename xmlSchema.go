@@ -13,6 +13,10 @@ import "encoding/xml"
 // OnSchema handles parsing event on the schema start elements. Schema is the
 // root element of every XML Schema.
 func (opt *Options) OnSchema(ele xml.StartElement, protoTree []interface{}) (err error) {
-	opt.prepareLocalNameNSMap(ele)
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "targetNamespace" {
+			opt.TargetNamespace = attr.Value
+		}
+	}
 	return
 }
@@ -0,0 +1,192 @@
+// Copyright 2020 - 2021 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import (
+	"encoding/xml"
+	"strings"
+)
+
+// compositor holds a sequence, all or choice particle being parsed with the
+// complex type or model group definition containing it, and the choice
+// definition of a choice particle. The minOccurs and maxOccurs are the
+// occurrence bounds of the compositor multiplied by the bounds of the
+// compositors containing it.
+type compositor struct {
+	particle  *Particle
+	owner     interface{}
+	choice    *Choice
+	minOccurs int
+	maxOccurs int
+}
+
+// OnSequence handles parsing event on the sequence start elements. The
+// sequence element specifies that the child elements must appear in a
+// sequence.
+func (opt *Options) OnSequence(ele xml.StartElement, protoTree []interface{}) (err error) {
+	minOccurs, maxOccurs := opt.particleOccurs(ele)
+	opt.onCompositor(ParticleSequence, minOccurs, maxOccurs)
+	return
+}
+
+// EndSequence handles parsing event on the sequence end elements.
+func (opt *Options) EndSequence(ele xml.EndElement, protoTree []interface{}) (err error) {
+	opt.endCompositor()
+	return
+}
+
+// onCompositor starts a particle of the sequence, all or choice compositor
+// with the given declared occurrence bounds.
+func (opt *Options) onCompositor(kind string, minOccurs, maxOccurs int) {
+	c := compositor{
+		particle: &Particle{Kind: kind, MinOccurs: minOccurs, MaxOccurs: maxOccurs},
+		owner:    opt.particleOwner(),
+	}
+	c.minOccurs, c.maxOccurs = opt.effectiveOccurs(minOccurs, maxOccurs)
+	opt.Particle.Push(&c)
+}
+
+// effectiveOccurs returns the occurrence bounds of a particle of the
+// compositor being parsed with the given declared bounds, multiplied by the
+// bounds of the compositor. An alternative of a choice is optional.
+func (opt *Options) effectiveOccurs(minOccurs, maxOccurs int) (int, int) {
+	c, ok := opt.Particle.Peek().(*compositor)
+	if !ok || c.owner != opt.particleOwner() {
+		return minOccurs, maxOccurs
+	}
+	if c.choice != nil {
+		minOccurs = 0
+	}
+	minOccurs *= c.minOccurs
+	switch {
+	case maxOccurs == 0 || c.maxOccurs == 0:
+		maxOccurs = 0
+	case maxOccurs == Unbounded || c.maxOccurs == Unbounded:
+		maxOccurs = Unbounded
+	default:
+		maxOccurs *= c.maxOccurs
+	}
+	return minOccurs, maxOccurs
+}
+
+// endCompositor adds the particle of the compositor to its parent
+// compositor, the particle of the outermost compositor is the root of the
+// particle tree of the complex type or model group definition.
+func (opt *Options) endCompositor() {
+	c, ok := opt.Particle.Pop().(*compositor)
+	if !ok {
+		return
+	}
+	parent, ok := opt.Particle.Peek().(*compositor)
+	if ok && parent.owner != c.owner {
+		parent = nil
+	}
+	if c.choice != nil {
+		endChoice(c, parent)
+	}
+	if parent != nil {
+		parent.particle.Particles = append(parent.particle.Particles, c.particle)
+		return
+	}
+	switch owner := c.owner.(type) {
+	case *ComplexType:
+		owner.Particle = c.particle
+	case *Group:
+		owner.Particle = c.particle
+	}
+}
+
+// endChoice names the choice by its alternatives and adds the choice to the
+// choice containing it, or to the complex type or model group definition.
+func endChoice(c, parent *compositor) {
+	var elements []Element
+	switch owner := c.owner.(type) {
+	case *ComplexType:
+		elements = owner.Elements
+	case *Group:
+		elements = owner.Elements
+	}
+	var names []string
+	for _, particle := range c.particle.Particles {
+		if particle.Kind == ParticleSequence || particle.Kind == ParticleAll || particle.Kind == ParticleAny {
+			continue
+		}
+		names = append(names, MakeFirstUpperCase(trimNSPrefix(particle.Name)))
+		if particle.Kind != ParticleElement {
+			continue
+		}
+		for _, element := range elements {
+			if element.Wildcard == nil && element.Name == particle.Name {
+				c.choice.Elements = append(c.choice.Elements, element)
+				break
+			}
+		}
+	}
+	c.choice.Name = "Choice"
+	if len(names) > 0 {
+		c.choice.Name = strings.Join(names, "Or")
+	}
+	c.particle.Name = c.choice.Name
+	if parent != nil && parent.choice != nil {
+		parent.choice.Choice = append(parent.choice.Choice, *c.choice)
+		return
+	}
+	switch owner := c.owner.(type) {
+	case *ComplexType:
+		owner.Choice = append(owner.Choice, *c.choice)
+	case *Group:
+		owner.Choice = append(owner.Choice, *c.choice)
+	}
+}
+
+// addParticle adds the particle of an element, group reference or element
+// wildcard with the given declared occurrence bounds to the compositor being
+// parsed.
+func (opt *Options) addParticle(kind, name string, minOccurs, maxOccurs int) {
+	c, ok := opt.Particle.Peek().(*compositor)
+	if !ok || c.owner != opt.particleOwner() {
+		return
+	}
+	c.particle.Particles = append(c.particle.Particles, &Particle{Kind: kind, Name: name, MinOccurs: minOccurs, MaxOccurs: maxOccurs})
+}
+
+// particleOwner returns the complex type or model group definition being
+// parsed, which contains the particles being parsed.
+func (opt *Options) particleOwner() interface{} {
+	if opt.ComplexType.Len() > 0 {
+		return opt.ComplexType.Peek()
+	}
+	if opt.InGroup > 0 && opt.Group.Len() > 0 {
+		return opt.Group.Peek()
+	}
+	return nil
+}
+
+// particleOccurs returns the occurrence bounds of a particle declared by the
+// minOccurs and maxOccurs attributes, both of them default to 1. An invalid
+// value is reported and the default is used instead.
+func (opt *Options) particleOccurs(ele xml.StartElement) (minOccurs, maxOccurs int) {
+	minOccurs, maxOccurs = 1, 1
+	for _, attr := range ele.Attr {
+		if attr.Name.Local != "minOccurs" && attr.Name.Local != "maxOccurs" {
+			continue
+		}
+		value, err := parseOccurs(attr.Value)
+		if err != nil || (value == Unbounded && attr.Name.Local == "minOccurs") {
+			opt.report(DiagnosticInvalid, "invalid %s value %q", attr.Name.Local, attr.Value)
+			continue
+		}
+		if attr.Name.Local == "minOccurs" {
+			minOccurs = value
+			continue
+		}
+		maxOccurs = value
+	}
+	return
+}
@@ -8,14 +8,31 @@
 
 package xgen
 
-import "encoding/xml"
+import (
+	"encoding/xml"
+	"fmt"
+)
 
 // OnSimpleType handles parsing event on the simpleType start elements. The
 // simpleType element defines a simple type and specifies the constraints and
-// information about the values of attributes or text-only elements.
+// information about the values of attributes or text-only elements. A
+// simpleType element in a union defines an anonymous member type of the
+// union, which is named after the union by its position in the union.
 func (opt *Options) OnSimpleType(ele xml.StartElement, protoTree []interface{}) (err error) {
+	if union, ok := opt.SimpleType.Peek().(*SimpleType); ok && opt.InUnion && union.Union {
+		member := SimpleType{Pos: opt.pos, TargetNamespace: opt.TargetNamespace, Anonymous: true}
+		member.Name, member.Parent = opt.anonymousTypeName()
+		if union.Name != "" {
+			member.Name = union.Name
+		}
+		member.Name = fmt.Sprintf("%sMember%d", member.Name, len(union.MemberTypes)+1)
+		opt.SimpleType.Push(&member)
+		return
+	}
 	if opt.SimpleType.Len() == 0 {
-		opt.SimpleType.Push(&SimpleType{})
+		simpleType := SimpleType{Pos: opt.pos, TargetNamespace: opt.TargetNamespace, Anonymous: true}
+		_, simpleType.Parent = opt.anonymousTypeName()
+		opt.SimpleType.Push(&simpleType)
 	}
 	if opt.CurrentEle == "attributeGroup" {
 		// return
@@ -24,15 +41,43 @@ func (opt *Options) OnSimpleType(ele xml.StartElement, protoTree []interface{})
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "name" {
 			opt.SimpleType.Peek().(*SimpleType).Name = attr.Value
+			opt.SimpleType.Peek().(*SimpleType).Anonymous = false
+			opt.SimpleType.Peek().(*SimpleType).Parent = ""
 		}
 	}
 	return
 }
 
-// EndSimpleType handles parsing event on the simpleType end elements.
+// EndSimpleType handles parsing event on the simpleType end elements. The
+// anonymous member type of a union is added to the member types of the
+// union, and the anonymous union type of an element or attribute is added to
+// the proto tree as the type of the declaration.
 func (opt *Options) EndSimpleType(ele xml.EndElement, protoTree []interface{}) (err error) {
+	if opt.InUnion && opt.SimpleType.Len() > 1 {
+		member := opt.SimpleType.Pop().(*SimpleType)
+		union := opt.SimpleType.Peek().(*SimpleType)
+		union.MemberTypes[member.Name] = member.Name
+		union.Members = append(union.Members, member.Name)
+		if member.TargetNamespace != "" {
+			if union.MemberTypeNamespaces == nil {
+				union.MemberTypeNamespaces = make(map[string]string)
+			}
+			union.MemberTypeNamespaces[member.Name] = member.TargetNamespace
+		}
+		opt.ProtoTree = append(opt.ProtoTree, member)
+		return
+	}
+	if simpleType, ok := opt.SimpleType.Peek().(*SimpleType); ok && simpleType.Union && simpleType.Anonymous && !opt.InUnion {
+		opt.ProtoTree = append(opt.ProtoTree, opt.SimpleType.Pop())
+		if opt.Attribute.Len() > 0 {
+			opt.Attribute.Peek().(*Attribute).Type, opt.Attribute.Peek().(*Attribute).TypeNamespace = simpleType.Name, simpleType.TargetNamespace
+		}
+		opt.CurrentEle = ""
+		return
+	}
 	if opt.SimpleType.Len() > 0 && opt.Attribute.Len() > 0 {
-		opt.Attribute.Peek().(*Attribute).Type = opt.SimpleType.Pop().(*SimpleType).Base
+		simpleType := opt.SimpleType.Pop().(*SimpleType)
+		opt.Attribute.Peek().(*Attribute).Type, opt.Attribute.Peek().(*Attribute).TypeNamespace = simpleType.Base, simpleType.BaseNamespace
 		return
 	}
 	if ele.Name.Local == opt.CurrentEle && opt.ComplexType.Len() == 1 {
@@ -10,15 +10,15 @@ package xgen
 
 import "encoding/xml"
 
-// EndTotalDigits handles parsing event on the totalDigits end elements.
+// OnTotalDigits handles parsing event on the totalDigits start elements.
 // TotalDigits specifies the exact number of digits allowed. Must be greater
 // than zero.
-func (opt *Options) EndTotalDigits(ele xml.EndElement, protoTree []interface{}) (err error) {
-	if opt.SimpleType.Len() > 0 && opt.Element.Len() > 0 {
-		if opt.Element.Peek().(*Element).Type, err = opt.GetValueType(opt.SimpleType.Pop().(*SimpleType).Base, opt.ProtoTree); err != nil {
-			return
-		}
-		opt.CurrentEle = ""
+func (opt *Options) OnTotalDigits(ele xml.StartElement, protoTree []interface{}) (err error) {
+	if opt.SimpleType.Peek() == nil {
+		return
+	}
+	if value := opt.facetValue(ele); value != nil {
+		opt.SimpleType.Peek().(*SimpleType).Restriction.TotalDigits = value
 	}
 	return
 }
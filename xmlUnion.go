@@ -15,22 +15,40 @@ import (
 
 // OnUnion handles parsing event on the union start elements. The union
 // element defines a simple type as a collection (union) of values from
-// specified simple data types.
+// specified simple data types. An anonymous union type is named after the
+// declaration which defines it.
 func (opt *Options) OnUnion(ele xml.StartElement, protoTree []interface{}) (err error) {
 	opt.InUnion = true
 	if opt.SimpleType.Peek() == nil {
 		return
 	}
-	opt.SimpleType.Peek().(*SimpleType).Union = true
-	opt.SimpleType.Peek().(*SimpleType).MemberTypes = make(map[string]string)
+	simpleType := opt.SimpleType.Peek().(*SimpleType)
+	simpleType.Union = true
+	simpleType.MemberTypes = make(map[string]string)
+	if simpleType.Anonymous && simpleType.Name == "" {
+		simpleType.Name, _ = opt.anonymousTypeName()
+		if opt.Attribute.Len() == 0 {
+			opt.typeElementDecl(simpleType.Name)
+		}
+	}
 	for _, attr := range ele.Attr {
 		if attr.Name.Local == "memberTypes" {
-			memberTypes := strings.Split(attr.Value, " ")
+			memberTypes := strings.Fields(attr.Value)
 			for _, memberType := range memberTypes {
-				opt.SimpleType.Peek().(*SimpleType).MemberTypes[trimNSPrefix(memberType)], err = opt.GetValueType(memberType, protoTree)
-				if err != nil {
+				var valueType, ns string
+				if valueType, ns, err = opt.getValueType(memberType); err != nil {
 					return
 				}
+				if _, ok := simpleType.MemberTypes[trimNSPrefix(memberType)]; !ok {
+					simpleType.Members = append(simpleType.Members, trimNSPrefix(memberType))
+				}
+				simpleType.MemberTypes[trimNSPrefix(memberType)] = valueType
+				if ns != "" {
+					if simpleType.MemberTypeNamespaces == nil {
+						simpleType.MemberTypeNamespaces = make(map[string]string)
+					}
+					simpleType.MemberTypeNamespaces[trimNSPrefix(memberType)] = ns
+				}
 			}
 			continue
 		}
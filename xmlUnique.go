@@ -0,0 +1,25 @@
+// Copyright 2020 - 2021 The xgen Authors. All rights reserved. Use of this
+// source code is governed by a BSD-style license that can be found in the
+// LICENSE file.
+//
+// Package xgen written in pure Go providing a set of functions that allow you
+// to parse XSD (XML schema files). This library needs Go version 1.10 or
+// later.
+
+package xgen
+
+import "encoding/xml"
+
+// OnUnique handles parsing event on the unique start elements. The unique
+// element defines that an element or an attribute value must be unique
+// within the scope.
+func (opt *Options) OnUnique(ele xml.StartElement, protoTree []interface{}) (err error) {
+	opt.onIdentityConstraint("unique", ele)
+	return
+}
+
+// EndUnique handles parsing event on the unique end elements.
+func (opt *Options) EndUnique(ele xml.EndElement, protoTree []interface{}) (err error) {
+	opt.endIdentityConstraint()
+	return
+}
@@ -10,15 +10,17 @@ package xgen
 
 import "encoding/xml"
 
-// EndWhiteSpace handles parsing event on the whiteSpace end elements.
+// OnWhiteSpace handles parsing event on the whiteSpace start elements.
 // WhiteSpace specifies how white space (line feeds, tabs, spaces, and
 // carriage returns) is handled.
-func (opt *Options) EndWhiteSpace(ele xml.EndElement, protoTree []interface{}) (err error) {
-	if opt.SimpleType.Len() > 0 && opt.Element.Len() > 0 {
-		if opt.Element.Peek().(*Element).Type, err = opt.GetValueType(opt.SimpleType.Pop().(*SimpleType).Base, opt.ProtoTree); err != nil {
-			return
+func (opt *Options) OnWhiteSpace(ele xml.StartElement, protoTree []interface{}) (err error) {
+	if opt.SimpleType.Peek() == nil {
+		return
+	}
+	for _, attr := range ele.Attr {
+		if attr.Name.Local == "value" {
+			opt.SimpleType.Peek().(*SimpleType).Restriction.WhiteSpace = attr.Value
 		}
-		opt.CurrentEle = ""
 	}
 	return
 }
@@ -1,9 +1,11 @@
 package xgen
 
 import (
+	"bytes"
 	"encoding/xml"
 	"io/ioutil"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -27,6 +29,26 @@ func TestGeneratedGo(t *testing.T) {
 			xmlFileName:     "base64.xml",
 			receivingStruct: &schema.TopLevel{},
 		},
+		{
+			xmlFileName:     "wildcard.xml",
+			receivingStruct: &schema.Envelope{},
+		},
+		{
+			xmlFileName:     "substitution.xml",
+			receivingStruct: &schema.Drawing{},
+		},
+		{
+			xmlFileName:     "restriction.xml",
+			receivingStruct: &schema.Directory{},
+		},
+		{
+			xmlFileName:     "sequence.xml",
+			receivingStruct: &schema.Shipment{},
+		},
+		{
+			xmlFileName:     "choice.xml",
+			receivingStruct: &schema.Payments{},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -67,3 +89,244 @@ func TestToTitle(t *testing.T) {
 	test("Привет", "привет")
 	test("Привет мир", "привет мир")
 }
+
+func TestGeneratedGoIdentityConstraints(t *testing.T) {
+	input, err := ioutil.ReadFile(filepath.Join("xmlFixtures", "identity.xml"))
+	require.NoError(t, err)
+
+	catalog := &schema.CatalogType{}
+	require.NoError(t, xml.Unmarshal(input, catalog))
+	assert.NoError(t, schema.CheckCatalogConstraints(catalog))
+
+	catalog.Order = append(catalog.Order, &schema.OrderType{IdAttr: "3", ProductAttr: "C3"})
+	assert.EqualError(t, schema.CheckCatalogConstraints(catalog), "keyref orderProduct: no productKey value matches C3")
+
+	catalog.Order[2].IdAttr = "1"
+	assert.EqualError(t, schema.CheckCatalogConstraints(catalog), "unique orderId: duplicate value 1")
+
+	catalog.Product = append(catalog.Product, &schema.ProductType{SkuAttr: "A1"})
+	assert.EqualError(t, schema.CheckCatalogConstraints(catalog), "key productKey: duplicate value A1")
+
+	// the constraints of a local element declaration are checked on the
+	// values of the element, absent values are not checked.
+	require.Len(t, catalog.Shelf, 1)
+	shelf := catalog.Shelf[0]
+	assert.NoError(t, schema.CheckCatalogTypeShelfConstraints(shelf))
+	shelf.Item = append(shelf.Item, nil, &schema.ItemType{SkuAttr: "A1"})
+	assert.EqualError(t, schema.CheckCatalogTypeShelfConstraints(shelf), "unique shelfItem: duplicate value A1")
+
+	// the absent optional attributes are not checked, the empty ones are.
+	shelf = &schema.ShelfType{}
+	require.NoError(t, xml.Unmarshal([]byte(`<Shelf><Item/><Item/></Shelf>`), shelf))
+	assert.NoError(t, schema.CheckCatalogTypeShelfConstraints(shelf))
+	shelf = &schema.ShelfType{}
+	require.NoError(t, xml.Unmarshal([]byte(`<Shelf><Item sku=""/><Item sku=""/></Shelf>`), shelf))
+	assert.EqualError(t, schema.CheckCatalogTypeShelfConstraints(shelf), "unique shelfItem: duplicate value ")
+}
+
+// TestGeneratedGoModelGroups validates that the elements of a referenced
+// model group are decoded from the content of the referencing element, and
+// the elements of a repeated sequence or group are decoded into lists.
+func TestGeneratedGoModelGroups(t *testing.T) {
+	waybill := &schema.WaybillType{}
+	require.NoError(t, xml.Unmarshal([]byte(`<Waybill><Number>7</Number><Remark>fragile</Remark><Priority>2</Priority><Parcel>box</Parcel><Weight>1.5</Weight></Waybill>`), waybill))
+	require.NotNil(t, waybill.RemarkGroup)
+	assert.Equal(t, schema.RemarkGroup{Remark: "fragile", Priority: 2}, *waybill.RemarkGroup)
+	assert.Equal(t, "box", waybill.Parcel)
+
+	remarshaled, err := xml.Marshal(struct {
+		XMLName xml.Name `xml:"Waybill"`
+		*schema.WaybillType
+	}{WaybillType: waybill})
+	require.NoError(t, err)
+	assert.Equal(t, `<Waybill><Number>7</Number><Remark>fragile</Remark><Priority>2</Priority><Parcel>box</Parcel><Weight>1.5</Weight></Waybill>`, string(remarshaled))
+
+	manifest := &schema.ManifestType{}
+	require.NoError(t, xml.Unmarshal([]byte(`<Manifest><Code>a</Code><Remark>r1</Remark><Code>b</Code><Note>n</Note><Remark>r2</Remark><Priority>1</Priority></Manifest>`), manifest))
+	assert.Equal(t, []string{"a", "b"}, manifest.Code)
+	assert.Equal(t, []string{"n"}, manifest.Note)
+	assert.Equal(t, []string{"r1", "r2"}, manifest.Remark)
+	assert.Equal(t, []int{1}, manifest.Priority)
+}
+
+// TestGeneratedGoSubstitutionGroups validates that the elements which are not
+// in a substitution group are skipped, and the members of the substitution
+// groups of a content with more than one of them are held by their fields.
+func TestGeneratedGoSubstitutionGroups(t *testing.T) {
+	drawing := &schema.Drawing{}
+	require.NoError(t, xml.Unmarshal([]byte(`<Drawing><Title>t</Title><Circle radius="1"/><Unknown/><Square side="2"/></Drawing>`), drawing))
+	require.Len(t, drawing.Shape, 2)
+	assert.Equal(t, "Circle", drawing.Shape[0].XMLName.Local)
+	assert.Equal(t, "Square", drawing.Shape[1].XMLName.Local)
+
+	gallery := &schema.Gallery{}
+	require.NoError(t, xml.Unmarshal([]byte(`<Gallery xmlns="http://example.org/substitution"><Circle radius="1"/><RoundedSquare side="2"/><Caption>c</Caption></Gallery>`), gallery))
+	require.Len(t, gallery.Circle, 1)
+	assert.Equal(t, 1, gallery.Circle[0].RadiusAttr)
+	require.Len(t, gallery.RoundedSquare, 1)
+	assert.Equal(t, 2, gallery.RoundedSquare[0].SideAttr)
+	assert.Empty(t, gallery.Square)
+	assert.Equal(t, "c", gallery.Caption)
+}
+
+// TestGeneratedGoChoiceUnknownElements validates that the elements which are
+// not the alternatives of a choice are skipped by the union of the choice.
+func TestGeneratedGoChoiceUnknownElements(t *testing.T) {
+	payment := &schema.PaymentType{}
+	require.NoError(t, xml.Unmarshal([]byte(`<Payment><Amount>1</Amount><Note>n</Note></Payment>`), payment))
+	assert.Nil(t, payment.CardOrTransfer.Value)
+	require.NoError(t, xml.Unmarshal([]byte(`<Payment><Amount>1</Amount><Card>4111</Card><Note>n</Note></Payment>`), payment))
+	assert.Equal(t, schema.PaymentTypeCardOrTransferCard{Value: "4111"}, payment.CardOrTransfer.Value)
+
+	channel := &schema.ChannelType{}
+	require.NoError(t, xml.Unmarshal([]byte(`<Channel><Fax>0</Fax></Channel>`), channel))
+	assert.Equal(t, schema.ChannelTypeEmailOrPhoneOrFaxFax{Value: "0"}, channel.EmailOrPhoneOrFax.Value)
+
+	topLevel := &schema.TopLevel{}
+	require.NoError(t, xml.Unmarshal([]byte(`<TopLevel><myType1>dGVzdA==</myType1><other/><myType2 length="2">te</myType2></TopLevel>`), topLevel))
+	require.Len(t, topLevel.MyType1OrMyType2, 2)
+	assert.Equal(t, schema.TopLevelMyType1OrMyType2MyType1{Value: []byte("dGVzdA==")}, topLevel.MyType1OrMyType2[0].Value)
+
+	remarshaled, err := xml.Marshal(payment)
+	require.NoError(t, err)
+	assert.Equal(t, `<PaymentType><Amount>1</Amount><Card>4111</Card></PaymentType>`, string(remarshaled))
+}
+
+// TestGeneratedGoMixedContent validates that the character data and the
+// elements of mixed content are decoded and encoded in document order.
+func TestGeneratedGoMixedContent(t *testing.T) {
+	input, err := ioutil.ReadFile(filepath.Join("xmlFixtures", "mixed.xml"))
+	require.NoError(t, err)
+
+	article := &schema.Article{}
+	require.NoError(t, xml.Unmarshal(input, article))
+	require.Len(t, article.Para, 2)
+	assert.Equal(t, "en", article.Para[0].LangAttr)
+	require.Len(t, article.Para[0].Content, 5)
+	assert.Equal(t, "Some ", article.Para[0].Content[0].Text)
+	assert.Equal(t, "b", article.Para[0].Content[1].Element.XMLName.Local)
+	assert.Equal(t, "bold", article.Para[0].Content[1].Element.InnerXML)
+	assert.Equal(t, " and ", article.Para[0].Content[2].Text)
+	assert.Equal(t, "i", article.Para[0].Content[3].Element.XMLName.Local)
+	assert.Equal(t, " text.", article.Para[0].Content[4].Text)
+	assert.Equal(t, "ada", article.Note.AuthorAttr)
+
+	remarshaled, err := xml.Marshal(article)
+	require.NoError(t, err)
+	assert.Equal(t, string(input), string(remarshaled))
+}
+
+// TestGeneratedGoValueConstraints validates that the constructors initialize
+// the default and fixed values, and the values violating a fixed value are
+// reported on the decoded document.
+func TestGeneratedGoValueConstraints(t *testing.T) {
+	settings := schema.NewSettingsType()
+	assert.Equal(t, "2.0", settings.VersionAttr)
+	assert.True(t, settings.EnabledAttr)
+	assert.Equal(t, uint16(3), settings.RetriesAttr)
+	assert.Equal(t, "light", settings.Theme)
+	assert.Equal(t, 1.5, settings.Scale)
+
+	input, err := ioutil.ReadFile(filepath.Join("xmlFixtures", "defaults.xml"))
+	require.NoError(t, err)
+
+	profile := &schema.ProfileType{}
+	require.NoError(t, xml.Unmarshal(input, profile))
+	require.Len(t, profile.Settings, 2)
+	assert.Equal(t, "dark", profile.Settings[0].Theme)
+	assert.False(t, profile.Settings[0].EnabledAttr)
+	assert.Equal(t, uint16(3), profile.Settings[0].RetriesAttr)
+	assert.Equal(t, 0, profile.Settings[0].FontSize)
+	assert.Equal(t, "", profile.Settings[1].Theme)
+	assert.Equal(t, 14, profile.Settings[1].FontSize)
+	assert.Equal(t, "", profile.Settings[1].Encoding)
+	assert.Equal(t, "2.0", profile.Settings[1].VersionAttr)
+	assert.True(t, profile.Settings[1].EnabledAttr)
+	// the derived type decodes its own and inherited attributes and elements
+	require.NotNil(t, profile.UserSettings)
+	assert.Equal(t, "ann", profile.UserSettings.UserAttr)
+	assert.Equal(t, "en", profile.UserSettings.Locale)
+	assert.Equal(t, "dark", profile.UserSettings.Theme)
+	assert.Equal(t, uint16(5), profile.UserSettings.RetriesAttr)
+	assert.Equal(t, "2.0", profile.UserSettings.VersionAttr)
+	require.NotNil(t, profile.Shipping)
+	assert.Equal(t, "US", profile.Shipping.CountryAttr)
+	assert.Equal(t, "Austin", profile.Shipping.City)
+	assert.NoError(t, schema.CheckFixedValues(profile))
+
+	// the absent attributes with a default or fixed value are not encoded
+	output := new(bytes.Buffer)
+	require.NoError(t, xml.NewEncoder(output).EncodeElement(profile, xml.StartElement{Name: xml.Name{Local: "Profile"}}))
+	assert.Equal(t, strings.TrimSpace(string(input)), output.String())
+
+	// the empty elements take the default or fixed value
+	settings = &schema.SettingsType{}
+	require.NoError(t, xml.Unmarshal([]byte(`<Settings><Theme/><FontSize></FontSize><Scale>2</Scale><Encoding/></Settings>`), settings))
+	assert.Equal(t, "light", settings.Theme)
+	assert.Equal(t, 12, settings.FontSize)
+	assert.Equal(t, 2.0, settings.Scale)
+	assert.Equal(t, "UTF-8", settings.Encoding)
+
+	// the optional attributes with a zero value are not encoded
+	output.Reset()
+	require.NoError(t, xml.NewEncoder(output).EncodeElement(&schema.SettingsType{FontSize: 14}, xml.StartElement{Name: xml.Name{Local: "Settings"}}))
+	assert.Equal(t, `<Settings><FontSize>14</FontSize></Settings>`, output.String())
+
+	// the derived type with a nil base type is decoded and encoded
+	userSettings := &schema.UserSettingsType{}
+	require.NoError(t, xml.Unmarshal([]byte(`<UserSettings><Locale>fr</Locale></UserSettings>`), userSettings))
+	assert.Equal(t, "fr", userSettings.Locale)
+	assert.True(t, userSettings.EnabledAttr)
+	output.Reset()
+	require.NoError(t, xml.NewEncoder(output).EncodeElement(&schema.UserSettingsType{UserAttr: "bob"}, xml.StartElement{Name: xml.Name{Local: "UserSettings"}}))
+	assert.Equal(t, `<UserSettings user="bob"></UserSettings>`, output.String())
+
+	profile.Settings[1].Encoding = "ASCII"
+	assert.EqualError(t, schema.CheckFixedValues(profile), "fixed Encoding: value ASCII doesn't match UTF-8")
+
+	profile.Settings[1].Encoding = ""
+	profile.Settings[0].VersionAttr = "1.0"
+	assert.EqualError(t, schema.CheckFixedValues(profile), "fixed version: value 1.0 doesn't match 2.0")
+}
+
+// TestGeneratedGoUnionTypes validates the values of the union simple types
+// are decoded into the first member type accepting them, and encoded as the
+// element or attribute.
+func TestGeneratedGoUnionTypes(t *testing.T) {
+	input, err := ioutil.ReadFile(filepath.Join("xmlFixtures", "union.xml"))
+	require.NoError(t, err)
+
+	box := &schema.BoxType{}
+	require.NoError(t, xml.Unmarshal(input, box))
+	require.NotNil(t, box.AlignAttr)
+	require.NotNil(t, box.AlignAttr.AlignMember1)
+	assert.Equal(t, "left", *box.AlignAttr.AlignMember1)
+	assert.Nil(t, box.AlignAttr.AlignMember2)
+	require.NotNil(t, box.Width.DimensionTypeMember2)
+	assert.Equal(t, "auto", *box.Width.DimensionTypeMember2)
+	assert.Nil(t, box.Margin.MarginMember1)
+	require.NotNil(t, box.Margin.MarginMember2)
+	assert.Equal(t, 5, *box.Margin.MarginMember2)
+
+	output := new(bytes.Buffer)
+	require.NoError(t, xml.NewEncoder(output).EncodeElement(box, xml.StartElement{Name: xml.Name{Local: "Box"}}))
+	assert.Equal(t, strings.TrimSpace(string(input)), output.String())
+
+	box = &schema.BoxType{}
+	require.NoError(t, xml.Unmarshal([]byte(`<Box align="2"><Width> 12 </Width><Margin>none</Margin></Box>`), box))
+	require.NotNil(t, box.AlignAttr.AlignMember2)
+	assert.Equal(t, 2, *box.AlignAttr.AlignMember2)
+	require.NotNil(t, box.Width.Int)
+	assert.Equal(t, 12, *box.Width.Int)
+	require.NotNil(t, box.Margin.MarginMember1)
+	assert.Equal(t, "none", *box.Margin.MarginMember1)
+	require.NoError(t, xml.Unmarshal([]byte(`<Box><Width>1.5</Width><Margin>0</Margin></Box>`), box))
+	assert.Nil(t, box.Width.Int)
+	require.NotNil(t, box.Width.DimensionTypeMember3)
+	assert.Equal(t, 1.5, *box.Width.DimensionTypeMember3)
+	assert.Error(t, xml.Unmarshal([]byte(`<Box align="center"/>`), box))
+
+	output.Reset()
+	require.NoError(t, xml.NewEncoder(output).EncodeElement(&schema.BoxType{}, xml.StartElement{Name: xml.Name{Local: "Box"}}))
+	assert.Equal(t, `<Box></Box>`, output.String())
+}